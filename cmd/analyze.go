@@ -1,79 +1,116 @@
 package cmd
 
 import (
-	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
 
 	"github.com/jackchuka/gh-brag/internal/analyze"
-	"github.com/jackchuka/gh-brag/internal/config"
-	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/store"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
 var (
-	analyzeIn  string
-	analyzeOut string
+	analyzeIn      string
+	analyzeOut     string
+	analyzeExplain bool
+	analyzeTeam    bool
 )
 
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze",
 	Short: "Analyze collected data for insights",
 	Long:  `Generates a detailed YAML report containing metrics, theme clusters, and collaboration insights.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("Analyzing data from %s...\n", analyzeIn)
-
-		f, err := os.Open(analyzeIn)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
 		if err != nil {
-			fmt.Printf("Error opening file: %v\n", err)
-			return
+			return fmt.Errorf("failed to load config: %w", err)
 		}
-		defer func() {
-			_ = f.Close()
-		}()
-
-		var events []data.Event
-		scanner := bufio.NewScanner(f)
-		for scanner.Scan() {
-			var e data.Event
-			if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
-				events = append(events, e)
-			}
+
+		in := eventsPath(cmd, "in", cfg)
+		fmt.Printf("Analyzing data from %s...\n", in)
+
+		events, err := store.LoadEvents(in)
+		if err != nil {
+			return fmt.Errorf("failed to load events: %w", err)
 		}
+		events = cfg.ActiveProfile().Scope().Filter(events)
 
-		cfg, err := config.LoadConfig(rootConfig)
+		excl, err := newExclusions(cfg)
 		if err != nil {
-			fmt.Printf("Warning: error loading config: %v. Using defaults.\n", err)
-			cfg = &config.Config{}
+			return err
 		}
+		events = excl.events(events)
+		excl.report()
 
 		analyzer, err := analyze.New(cfg)
 		if err != nil {
-			fmt.Printf("Error creating analyzer: %v\n", err)
-			return
+			return fmt.Errorf("failed to create analyzer: %w", err)
 		}
 		metrics := analyzer.Analyze(events)
 
 		// Marshal to YAML
-		out, err := yaml.Marshal(metrics)
+		var report any = metrics
+		if analyzeTeam {
+			report = analyzer.AnalyzeTeam(events)
+		}
+		out, err := yaml.Marshal(report)
 		if err != nil {
-			fmt.Printf("Error marshaling metrics to YAML: %v\n", err)
-			return
+			return fmt.Errorf("failed to marshal report: %w", err)
 		}
 
 		if err := os.WriteFile(analyzeOut, out, 0644); err != nil {
-			fmt.Printf("Error writing report: %v\n", err)
-			return
+			return fmt.Errorf("failed to write report: %w", err)
 		}
 		fmt.Printf("Analysis complete. Report written to %s\n", analyzeOut)
+
+		if analyzeExplain {
+			printImpactExplanation(metrics)
+		}
+		return nil
 	},
 }
 
+// printImpactExplanation prints where the impact score came from
+func printImpactExplanation(m analyze.Metrics) {
+	fmt.Printf("\nImpact score: %.1f (%s)\n", m.ImpactScore, m.ImpactTier)
+
+	sections := []struct {
+		title  string
+		shares []analyze.ImpactShare
+	}{
+		{"By action", m.Impact.ByAction},
+		{"By theme", m.Impact.ByTheme},
+		{"By repository", m.Impact.ByRepo},
+		{"By month", m.Impact.ByMonth},
+	}
+	for _, sec := range sections {
+		fmt.Printf("\n%s:\n", sec.title)
+		for _, s := range sec.shares {
+			fmt.Printf("  %-40s %8.1f %5.1f%%\n", s.Name, s.Score, s.Percent)
+		}
+	}
+
+	fmt.Printf("\nTop %d events:\n", len(m.Impact.TopEvents))
+	for i, e := range m.Impact.TopEvents {
+		fmt.Printf("  %2d. %6.1f = %4.1f (%s) x %.1f (%s)  %s\n      %s\n",
+			i+1, e.Score, e.Weight, e.Action, e.Factor, e.Theme, e.Title, e.URL)
+	}
+
+	if len(m.Tickets) > 0 {
+		fmt.Println("\nBy ticket:")
+		for _, t := range m.Tickets {
+			fmt.Printf("  %-12s %-40s %8.1f  %d events\n", t.Key, t.Title, t.Score, len(t.Events))
+		}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(analyzeCmd)
 
-	analyzeCmd.Flags().StringVar(&analyzeIn, "in", "gh-brag.events.jsonl", "Input JSONL file")
+	analyzeCmd.Flags().StringVar(&analyzeIn, "in", "gh-brag.events.jsonl", "Input JSONL file (default from the profile's store)")
 	analyzeCmd.Flags().StringVar(&analyzeOut, "out", "gh-brag.report.yaml", "Output report file")
+	analyzeCmd.Flags().BoolVar(&analyzeExplain, "explain", false, "Print a breakdown of the impact score")
+	addShowExcludedFlag(analyzeCmd)
+	analyzeCmd.Flags().BoolVar(&analyzeTeam, "team", false, "Report per-member metrics and a team rollup (events collected with --team)")
 }
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/llm"
+	"github.com/jackchuka/gh-brag/internal/spinner"
+	"github.com/jackchuka/gh-brag/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	classifyIn        string
+	classifyModel     string
+	classifyEndpoint  string
+	classifyBatchSize int
+	classifyTimeout   time.Duration
+	classifyForce     bool
+)
+
+var classifyCmd = &cobra.Command{
+	Use:   "classify",
+	Short: "Classify unmatched events into themes using an LLM",
+	Long: `Sends events that no theme keyword matched to the configured LLM in batches.
+The assigned theme and confidence are cached on each event in the input file,
+so later analyze and visualize runs stay deterministic and offline.`,
+	RunE: runClassify,
+}
+
+func init() {
+	rootCmd.AddCommand(classifyCmd)
+
+	classifyCmd.Flags().StringVar(&classifyIn, "in", "gh-brag.events.jsonl", "Input JSONL file, updated in place (default from the profile's store)")
+	classifyCmd.Flags().StringVar(&classifyModel, "model", "", "Model name (overrides config)")
+	classifyCmd.Flags().StringVar(&classifyEndpoint, "endpoint", "", "OpenAI-compatible chat completions URL (overrides config)")
+	classifyCmd.Flags().IntVar(&classifyBatchSize, "batch-size", 0, "Events per request (overrides config)")
+	classifyCmd.Flags().DurationVar(&classifyTimeout, "timeout", 60*time.Second, "Request timeout per batch")
+	classifyCmd.Flags().BoolVar(&classifyForce, "force", false, "Reclassify events that already have a cached classification")
+}
+
+func runClassify(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	in := eventsPath(cmd, "in", cfg)
+	events, err := store.LoadEvents(in)
+	if err != nil {
+		return fmt.Errorf("failed to load events: %w", err)
+	}
+
+	analyzer, err := analyze.New(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create analyzer: %w", err)
+	}
+
+	redactor, err := cfg.Redaction.Redactor()
+	if err != nil {
+		return err
+	}
+
+	ruleSet, err := cfg.Rules.Set()
+	if err != nil {
+		return fmt.Errorf("invalid rules: %w", err)
+	}
+
+	// Events from repos the redaction settings deny are never sent, and events
+	// outside the profile or excluded by the rules are left alone (the file is
+	// still rewritten whole)
+	sc := cfg.ActiveProfile().Scope()
+	var pending []int
+	for _, idx := range analyzer.Unmatched(events, classifyForce) {
+		e := events[idx]
+		if redactor.Allowed(e.Repo) && sc.Allows(e.Repo) && ruleSet.Excluded(e) == "" {
+			pending = append(pending, idx)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Println("No unmatched events to classify.")
+		return nil
+	}
+
+	themes := make([]string, 0, len(cfg.Themes))
+	for _, t := range cfg.Themes {
+		themes = append(themes, t.Name)
+	}
+
+	llmCfg := llm.Config{
+		Endpoint:  cfg.LLM.Endpoint,
+		APIKeyEnv: cfg.LLM.APIKeyEnv,
+		Model:     cfg.LLM.Model,
+		Timeout:   classifyTimeout,
+		Redactor:  redactor,
+	}
+	if classifyEndpoint != "" {
+		llmCfg.Endpoint = classifyEndpoint
+	}
+	if classifyModel != "" {
+		llmCfg.Model = classifyModel
+	}
+	if llmCfg.Model == "" {
+		llmCfg.Model = llm.DefaultModel
+	}
+
+	batchSize := cfg.Classification.BatchSize
+	if classifyBatchSize > 0 {
+		batchSize = classifyBatchSize
+	}
+	if batchSize <= 0 {
+		batchSize = 20
+	}
+
+	s := spinner.NewSpinner(fmt.Sprintf(" Classifying %d events...", len(pending)))
+	s.Start()
+
+	classified := 0
+	for start := 0; start < len(pending); start += batchSize {
+		batch := pending[start:min(start+batchSize, len(pending))]
+		s.Suffix = fmt.Sprintf(" Classifying events %d-%d of %d...", start+1, start+len(batch), len(pending))
+
+		items := make([]llm.ClassifyItem, len(batch))
+		for i, idx := range batch {
+			items[i] = llm.ClassifyItem{Title: events[idx].Title, Body: events[idx].Body, Private: events[idx].Private}
+		}
+
+		results, err := llm.Classify(context.Background(), llmCfg, themes, items)
+		if err != nil {
+			s.Stop()
+			// Keep what was classified so far
+			if classified > 0 {
+				if werr := store.WriteEvents(in, events); werr != nil {
+					return fmt.Errorf("classification failed: %w (also failed to save progress: %v)", err, werr)
+				}
+			}
+			return fmt.Errorf("classification failed after %d events: %w", classified, err)
+		}
+
+		now := time.Now()
+		for i, idx := range batch {
+			events[idx].Classification = &data.Classification{
+				Theme:        results[i].Theme,
+				Confidence:   results[i].Confidence,
+				Model:        llmCfg.Model,
+				ClassifiedAt: now,
+			}
+			classified++
+		}
+	}
+
+	s.Stop()
+
+	if err := store.WriteEvents(in, events); err != nil {
+		return fmt.Errorf("failed to save events: %w", err)
+	}
+	fmt.Printf("Classified %d events in %s\n", classified, in)
+	return nil
+}
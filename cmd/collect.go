@@ -2,122 +2,131 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jackchuka/gh-brag/internal/collect"
 	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/importer"
 	"github.com/jackchuka/gh-brag/internal/spinner"
 	"github.com/jackchuka/gh-brag/internal/store"
+	"github.com/jackchuka/gh-brag/internal/team"
 	"github.com/spf13/cobra"
 )
 
 var (
-	collectFrom    string
-	collectTo      string
-	collectOut     string
-	collectInclude string
-	collectUser    string
-	collectOwner   string
-	collectRepo    string
+	collectFrom     string
+	collectTo       string
+	collectOut      string
+	collectInclude  string
+	collectUser     string
+	collectOwner    string
+	collectRepo     string
+	collectTeam     string
+	collectParallel int
+	collectCommits  bool
 )
 
 var collectCmd = &cobra.Command{
 	Use:   "collect",
 	Short: "Collects your activity from GitHub",
-	Long:  `Searches GitHub for your PRs, Issues, and Reviews within a date range and saves them to a file.`,
+	Long: `Searches GitHub for your PRs, Issues, and Reviews within a date range and saves them to a file.
+
+With --team, activity is collected for every member of the team file in parallel
+and each event is tagged with the member's login.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := loadConfig()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			return
+		}
+		out := eventsPath(cmd, "out", cfg)
+		excl, err := newExclusions(cfg)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
 		s := spinner.NewSpinner(fmt.Sprintf(" Collecting data from %s to %s...", collectFrom, collectTo))
 		s.Start()
 		defer s.Stop()
 
 		// Helper to print while spinner is active
+		var mu sync.Mutex
 		printInfo := func(msg string) {
+			mu.Lock()
+			defer mu.Unlock()
 			s.Stop()
 			fmt.Println(msg)
 			s.Start()
 		}
 
-		existingIDs, err := store.LoadExistingIDs(collectOut)
+		existingIDs, err := store.LoadExistingIDs(out)
 		if err != nil {
-			printInfo(fmt.Sprintf("Error loading existing %s: %v", collectOut, err))
+			printInfo(fmt.Sprintf("Error loading existing %s: %v", out, err))
 			return
 		}
 
-		var newEvents []data.Event
-
-		// Helper to build query
-		buildQuery := func(baseQuery string) string {
-			q := baseQuery
-			if collectOwner != "" {
-				q += fmt.Sprintf(" user:%s", collectOwner)
-			}
-			if collectRepo != "" {
-				q += fmt.Sprintf(" repo:%s", collectRepo)
-			}
-			return q
+		opts := collect.Options{
+			User:    collectUser,
+			From:    collectFrom,
+			To:      collectTo,
+			Include: collectInclude,
+			Owner:   collectOwner,
+			Repo:    collectRepo,
+			Scope:   cfg.ActiveProfile().Scope(),
+			Commits: collectCommits || hasImportedCommits(existingIDs),
 		}
 
-		// 1. Authored PRs
-		if collectInclude == "all" || collectInclude == "prs" {
-			q := buildQuery(fmt.Sprintf("author:%s is:pr is:merged merged:%s..%s", collectUser, collectFrom, collectTo))
-			s.Suffix = fmt.Sprintf(" Finding merged PRs (query: %s)...", q)
-
-			res, err := collect.RunSearch("prs", data.EventActionMerged, q)
+		var found []data.Event
+		if collectTeam == "" {
+			found = collectUserActivity(opts, "", func(search collect.Search) {
+				s.Suffix = fmt.Sprintf(" Finding %s (query: %s)...", search.Label, search.Query)
+			}, printInfo)
+		} else {
+			t, err := team.Load(collectTeam)
 			if err != nil {
-				printInfo(fmt.Sprintf("    Error: %v", err))
-			} else {
-				printInfo(fmt.Sprintf("    Found %d PRs", len(res)))
-				for _, r := range res {
-					if !existingIDs[r.ID] {
-						newEvents = append(newEvents, r)
-					}
-				}
+				printInfo(fmt.Sprintf("Error loading team: %v", err))
+				return
 			}
-		}
-
-		// 2. Authored Issues
-		if collectInclude == "all" || collectInclude == "issues" {
-			q := buildQuery(fmt.Sprintf("author:%s is:issue created:%s..%s", collectUser, collectFrom, collectTo))
-			s.Suffix = fmt.Sprintf(" Finding authored Issues (query: %s)...", q)
-
-			res, err := collect.RunSearch("issues", data.EventActionAuthored, q)
+			s.Suffix = " Resolving team members..."
+			members, err := t.Resolve()
 			if err != nil {
-				printInfo(fmt.Sprintf("    Error: %v", err))
-			} else {
-				printInfo(fmt.Sprintf("    Found %d Issues", len(res)))
-				for _, r := range res {
-					if !existingIDs[r.ID] {
-						newEvents = append(newEvents, r)
-					}
-				}
+				printInfo(fmt.Sprintf("Error resolving team: %v", err))
+				return
 			}
+			s.Suffix = fmt.Sprintf(" Collecting activity for %d members...", len(members))
+			found = collectTeamActivity(opts, members, printInfo)
 		}
 
-		// 3. Reviewed PRs
-		if collectInclude == "all" || collectInclude == "reviews" {
-			q := buildQuery(fmt.Sprintf("is:pr reviewed-by:%s updated:%s..%s -author:%s", collectUser, collectFrom, collectTo, collectUser))
-			s.Suffix = fmt.Sprintf(" Finding reviewed PRs (query: %s)...", q)
+		// Excluded events are only dropped here when the rules ask for it;
+		// otherwise they are stored and left out at analyze time
+		if cfg.Rules.OnCollect {
+			found = excl.events(found)
+		}
 
-			res, err := collect.RunSearch("prs", data.EventActionReviewed, q)
-			if err != nil {
-				printInfo(fmt.Sprintf("    Error: %v", err))
-			} else {
-				printInfo(fmt.Sprintf("    Found %d Reviews", len(res)))
-				for _, r := range res {
-					if !existingIDs[r.ID] {
-						newEvents = append(newEvents, r)
-					}
-				}
+		var newEvents []data.Event
+		for _, e := range found {
+			if !existingIDs[e.ID] {
+				existingIDs[e.ID] = true
+				newEvents = append(newEvents, e)
 			}
 		}
 
 		s.Stop() // Stop spinner before final output
+		if cfg.Rules.OnCollect {
+			excl.report()
+		} else if showExcluded {
+			fmt.Fprintln(os.Stderr, "Rules are skipped at collect time unless rules.on_collect is set; excluded events are stored and left out by analyze, daily and the other commands.")
+		}
 
 		if len(newEvents) > 0 {
-			if err := store.AppendEvents(collectOut, newEvents); err != nil {
+			if err := store.AppendEvents(out, newEvents); err != nil {
 				fmt.Printf("Error saving events: %v\n", err)
 			} else {
-				fmt.Printf("Saved %d new events to %s\n", len(newEvents), collectOut)
+				fmt.Printf("Saved %d new events to %s\n", len(newEvents), out)
 			}
 		} else {
 			fmt.Println("No new events found.")
@@ -125,6 +134,74 @@ var collectCmd = &cobra.Command{
 	},
 }
 
+// hasImportedCommits reports whether the events file holds commits from import git,
+// which collect then matches to their PRs by recording the PRs' commit SHAs
+func hasImportedCommits(ids map[string]bool) bool {
+	for id := range ids {
+		if strings.HasPrefix(id, importer.CommitKind+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// collectUserActivity runs all searches for one user. If subject is set,
+// events are tagged with it for team mode.
+func collectUserActivity(opts collect.Options, subject string, onSearch func(collect.Search), printInfo func(string)) []data.Event {
+	prefix := ""
+	if subject != "" {
+		prefix = fmt.Sprintf("[%s] ", subject)
+	}
+
+	var events []data.Event
+	for _, search := range collect.Searches(opts) {
+		if onSearch != nil {
+			onSearch(search)
+		}
+
+		res, err := collect.RunSearch(search)
+		if err != nil {
+			printInfo(fmt.Sprintf("    %sError: %v", prefix, err))
+			continue
+		}
+		res = opts.Scope.Filter(res)
+		printInfo(fmt.Sprintf("    %sFound %d %s", prefix, len(res), search.Label))
+
+		if subject != "" {
+			collect.TagSubject(res, subject)
+		}
+		events = append(events, res...)
+	}
+	return events
+}
+
+// collectTeamActivity collects every member's activity with bounded parallelism
+func collectTeamActivity(opts collect.Options, members []string, printInfo func(string)) []data.Event {
+	results := make([][]data.Event, len(members))
+	sem := make(chan struct{}, max(collectParallel, 1))
+	var wg sync.WaitGroup
+
+	for i, member := range members {
+		wg.Add(1)
+		go func(i int, member string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			memberOpts := opts
+			memberOpts.User = member
+			results[i] = collectUserActivity(memberOpts, member, nil, printInfo)
+		}(i, member)
+	}
+	wg.Wait()
+
+	var events []data.Event
+	for _, r := range results {
+		events = append(events, r...)
+	}
+	return events
+}
+
 func init() {
 	rootCmd.AddCommand(collectCmd)
 
@@ -136,9 +213,14 @@ func init() {
 
 	collectCmd.Flags().StringVar(&collectFrom, "from", defaultFrom, "Start date (YYYY-MM-DD)")
 	collectCmd.Flags().StringVar(&collectTo, "to", defaultTo, "End date (YYYY-MM-DD)")
-	collectCmd.Flags().StringVar(&collectOut, "out", "gh-brag.events.jsonl", "Output file path")
+	collectCmd.Flags().StringVar(&collectOut, "out", "gh-brag.events.jsonl", "Output file path (default from the profile's store)")
 	collectCmd.Flags().StringVar(&collectInclude, "include", "all", "What to include: all, prs, issues, reviews")
 	collectCmd.Flags().StringVar(&collectUser, "user", "@me", "GitHub username (optional, defaults to @me)")
 	collectCmd.Flags().StringVar(&collectOwner, "owner", "", "Filter by owner (user or org)")
 	collectCmd.Flags().StringVar(&collectRepo, "repo", "", "Filter by specific repository (e.g. owner/repo)")
+	collectCmd.Flags().StringVar(&collectTeam, "team", "", "Team file (YAML) listing members to collect for")
+	collectCmd.Flags().IntVar(&collectParallel, "parallel", 4, "Members collected concurrently in team mode")
+	collectCmd.Flags().BoolVar(&collectCommits, "commits", false, "Record the commit SHAs of merged PRs, so commits imported with import git are matched to them (on when the file already has imported commits)")
+	addShowExcludedFlag(collectCmd)
+	collectCmd.MarkFlagsMutuallyExclusive("team", "user")
 }
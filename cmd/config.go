@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configInitForce bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validate, show or create the configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration for unknown keys and invalid values",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := "built-in defaults"
+		if files := config.Files(rootConfig); len(files) > 0 {
+			name = strings.Join(files, ", ")
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			var verr *config.ValidationError
+			if !errors.As(err, &verr) {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "%s is invalid:\n", name)
+			for _, p := range verr.Problems {
+				fmt.Fprintf(os.Stderr, "  - %s\n", p)
+			}
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d problem(s) found", len(verr.Problems))
+		}
+
+		if len(cfg.Sources) > 0 {
+			name = strings.Join(cfg.Sources, ", ")
+		}
+		fmt.Printf("%s is valid\n", name)
+		return nil
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration (defaults merged with every config layer)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		out, err := yaml.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		sources := "built-in defaults"
+		if len(cfg.Sources) > 0 {
+			sources += ", " + strings.Join(cfg.Sources, ", ")
+		}
+		if cfg.Profile != "" {
+			sources += ", profile " + cfg.Profile
+		}
+		fmt.Printf("# Merged from: %s\n", sources)
+		fmt.Print(string(out))
+		return nil
+	},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init [path]",
+	Short: "Write an annotated starter configuration",
+	Long: `Writes the built-in configuration, with comments explaining each setting, to
+path (default .gh-brag.yaml, the project file read from the working directory).
+Pass the user config path, e.g. ~/.config/gh-brag/config.yaml, to apply it
+everywhere instead.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := config.ProjectFile
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		if _, err := os.Stat(path); err == nil && !configInitForce {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := os.WriteFile(path, config.DefaultYAML(), 0644); err != nil {
+			return fmt.Errorf("failed to write config: %w", err)
+		}
+		fmt.Printf("Config written to %s\n", path)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd, configShowCmd, configInitCmd)
+
+	configInitCmd.Flags().BoolVar(&configInitForce, "force", false, "Overwrite an existing file")
+}
@@ -1,16 +1,25 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/jackchuka/gh-brag/internal/config"
 	"github.com/jackchuka/gh-brag/internal/daily"
+	"github.com/jackchuka/gh-brag/internal/data"
 	"github.com/jackchuka/gh-brag/internal/github"
 	"github.com/jackchuka/gh-brag/internal/llm"
+	"github.com/jackchuka/gh-brag/internal/note"
+	"github.com/jackchuka/gh-brag/internal/notify"
 	"github.com/jackchuka/gh-brag/internal/spinner"
+	"github.com/jackchuka/gh-brag/internal/ticket"
 	"github.com/spf13/cobra"
 )
 
@@ -20,9 +29,22 @@ var (
 	dailyTo                  string
 	dailyTz                  string
 	dailyFormat              string
+	dailyTemplate            string
+	dailyPost                string
+	dailyDryRun              bool
 	dailyIncludeLinkedIssues bool
 	dailyIncludeReviews      bool
+	dailyIncludeIssues       bool
+	dailyIncludeInProgress   bool
+	dailyIncludeNextUp       bool
+	dailyIncludeNotes        bool
+	dailyIn                  string
 	dailyOrgs                []string
+	dailyPeriod              string
+	dailySinceLast           bool
+	dailyWeekStart           string
+	dailySprintAnchor        string
+	dailySprintLength        int
 
 	// Summarization flags
 	dailySummarize        bool
@@ -30,6 +52,9 @@ var (
 	dailySummarizeModel   string
 	dailySummarizePrompt  string
 	dailySummarizeTimeout time.Duration
+	dailySummarizeStruct  bool
+	dailySummarizePreview bool
+	dailyVerbose          bool
 )
 
 var dailyCmd = &cobra.Command{
@@ -45,18 +70,35 @@ func init() {
 	dailyCmd.Flags().StringVar(&dailyDate, "date", "", "Report date (YYYY-MM-DD)")
 	dailyCmd.Flags().StringVar(&dailyFrom, "from", "", "Range start (YYYY-MM-DD)")
 	dailyCmd.Flags().StringVar(&dailyTo, "to", "", "Range end (YYYY-MM-DD)")
-	dailyCmd.Flags().StringVar(&dailyTz, "tz", "", "Timezone (IANA name, e.g., America/New_York)")
-	dailyCmd.Flags().StringVar(&dailyFormat, "format", "plain", "Output format: json, yaml, plain")
-	dailyCmd.Flags().BoolVar(&dailyIncludeLinkedIssues, "include-linked-issues", true, "Include linked issues")
+	dailyCmd.Flags().StringVar(&dailyPeriod, "period", "", "Report period: week, last-week, sprint, month, quarter")
+	dailyCmd.Flags().BoolVar(&dailySinceLast, "since-last", false, "Report everything since the last generated report ended")
+	dailyCmd.Flags().StringVar(&dailyWeekStart, "week-start", "", "First day of the week (default from config, monday)")
+	dailyCmd.Flags().StringVar(&dailySprintAnchor, "sprint-anchor", "", "Date any sprint started on (YYYY-MM-DD, default from config)")
+	dailyCmd.Flags().IntVar(&dailySprintLength, "sprint-length", 0, "Sprint length in days (default from config)")
+	dailyCmd.Flags().StringVar(&dailyTz, "tz", "", "Timezone (IANA name, e.g., America/New_York; default from config)")
+	dailyCmd.Flags().StringVar(&dailyFormat, "format", "plain", "Output format: plain, markdown, slack-mrkdwn, html, json, yaml")
+	dailyCmd.Flags().StringVar(&dailyTemplate, "template", "", "Render with a custom Go template file (default from config)")
+	dailyCmd.Flags().StringVar(&dailyPost, "post", "", "Post the report to slack, teams, discord or webhook")
+	dailyCmd.Flags().BoolVar(&dailyDryRun, "dry-run", false, "Print the --post payloads instead of sending them")
+	dailyCmd.Flags().BoolVar(&dailyIncludeLinkedIssues, "include-linked-issues", true, "Include linked issues and imported Jira/Linear tickets")
 	dailyCmd.Flags().BoolVar(&dailyIncludeReviews, "include-reviews", true, "Include submitted reviews")
-	dailyCmd.Flags().StringSliceVar(&dailyOrgs, "org", nil, "Filter by organization(s), repeatable")
+	dailyCmd.Flags().BoolVar(&dailyIncludeIssues, "include-issues", false, "Include issues you opened, commented on, closed or triaged")
+	dailyCmd.Flags().BoolVar(&dailyIncludeInProgress, "include-in-progress", false, "Include your open PRs with their review status")
+	dailyCmd.Flags().BoolVar(&dailyIncludeNextUp, "include-next-up", false, "Include PRs waiting on your review and issues assigned to you")
+	dailyCmd.Flags().BoolVar(&dailyIncludeNotes, "include-notes", true, "Include notes added with gh brag note")
+	dailyCmd.Flags().StringVar(&dailyIn, "in", "gh-brag.events.jsonl", "Events file to read notes and imported tickets from (default from the profile's store)")
+	dailyCmd.Flags().StringSliceVar(&dailyOrgs, "org", nil, "Filter by organization(s), repeatable (default from config)")
+	addShowExcludedFlag(dailyCmd)
+	dailyCmd.Flags().BoolVarP(&dailyVerbose, "verbose", "v", false, "Print details such as summary token usage to stderr")
 
 	// Summarization flags
 	dailyCmd.Flags().BoolVar(&dailySummarize, "summarize", false, "Generate LLM summary using GitHub Models")
 	dailyCmd.Flags().StringVar(&dailySummarizeLang, "summarize-lang", "en", "Output language (en, ja, etc.)")
-	dailyCmd.Flags().StringVar(&dailySummarizeModel, "summarize-model", "openai/gpt-4o", "Model name")
+	dailyCmd.Flags().StringVar(&dailySummarizeModel, "summarize-model", "", "Model name (default from config llm.model, openai/gpt-4o)")
 	dailyCmd.Flags().StringVar(&dailySummarizePrompt, "summarize-prompt", "", "Additional prompt instructions")
 	dailyCmd.Flags().DurationVar(&dailySummarizeTimeout, "summarize-timeout", 30*time.Second, "Request timeout")
+	dailyCmd.Flags().BoolVar(&dailySummarizeStruct, "summarize-structured", false, "Ask for themed bullets citing source URLs and drop bullets without one")
+	dailyCmd.Flags().BoolVar(&dailySummarizePreview, "summarize-preview", false, "Print exactly what --summarize would send to the LLM, without sending it")
 }
 
 func runDaily(cmd *cobra.Command, args []string) error {
@@ -66,12 +108,59 @@ func runDaily(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate format
-	if dailyFormat != "json" && dailyFormat != "yaml" && dailyFormat != "plain" {
-		return fmt.Errorf("invalid format %q: must be json, yaml, or plain", dailyFormat)
+	if !slices.Contains(daily.Formats, dailyFormat) {
+		return fmt.Errorf("invalid format %q: must be one of %v", dailyFormat, daily.Formats)
+	}
+	if dailyTemplate != "" && cmd.Flags().Changed("format") {
+		return errors.New("cannot use --template with --format")
+	}
+	if dailyPost != "" && !slices.Contains(notify.Targets, dailyPost) {
+		return fmt.Errorf("invalid --post target %q: must be one of %v", dailyPost, notify.Targets)
+	}
+	if dailyDryRun && dailyPost == "" {
+		return errors.New("--dry-run requires --post")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// --org, then daily.orgs, replace the profile's orgs and repos; its exclusions still apply
+	sc := cfg.ActiveProfile().Scope()
+	switch {
+	case cmd.Flags().Changed("org"):
+		sc.Orgs, sc.Repos = dailyOrgs, nil
+	case len(cfg.Daily.Orgs) > 0:
+		sc.Orgs, sc.Repos = cfg.Daily.Orgs, nil
+	}
+
+	// An explicit --format overrides the configured template
+	templatePath := dailyTemplate
+	if templatePath == "" && !cmd.Flags().Changed("format") {
+		templatePath = cfg.Daily.Template
+	}
+
+	// Load prompt templates before fetching so mistakes fail fast
+	var summaryCfg llm.Config
+	if dailySummarize || dailySummarizePreview {
+		summaryCfg, err = summaryConfig(cmd, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	excl, err := newExclusions(cfg)
+	if err != nil {
+		return err
 	}
 
 	// Compute date range
-	dateRange, err := daily.ComputeRange(dailyDate, dailyFrom, dailyTo, dailyTz)
+	rangeOpts, statePath, err := dailyRangeOptions(cfg)
+	if err != nil {
+		return err
+	}
+	dateRange, err := daily.ComputeRange(rangeOpts)
 	if err != nil {
 		return fmt.Errorf("failed to compute date range: %w", err)
 	}
@@ -89,7 +178,7 @@ func runDaily(cmd *cobra.Command, args []string) error {
 
 	// Fetch authored PRs
 	s.Suffix = " Fetching authored PRs..."
-	prs, err := daily.FetchAuthoredPRs(dateRange, dailyIncludeLinkedIssues, dailyOrgs)
+	prs, err := daily.FetchAuthoredPRs(dateRange, dailyIncludeLinkedIssues, sc)
 	if err != nil {
 		s.Stop()
 		return fmt.Errorf("failed to fetch PRs: %w", err)
@@ -99,40 +188,266 @@ func runDaily(cmd *cobra.Command, args []string) error {
 	var reviews []daily.ReviewedPR
 	if dailyIncludeReviews {
 		s.Suffix = " Fetching reviews..."
-		reviews, err = daily.FetchReviewedPRs(dateRange, currentUser, dailyOrgs)
+		reviews, err = daily.FetchReviewedPRs(dateRange, currentUser, sc)
 		if err != nil {
 			s.Stop()
 			return fmt.Errorf("failed to fetch reviews: %w", err)
 		}
 	}
 
+	// Notes and imported tickets are local, so a missing events file just means there are none
+	var stored []data.Event
+	if dailyIncludeNotes || dailyIncludeLinkedIssues {
+		stored, err = loadStoreEvents(eventsPath(cmd, "in", cfg))
+		if err != nil {
+			s.Stop()
+			return err
+		}
+	}
+
 	// Aggregate results
+	if dailyIncludeLinkedIssues {
+		prs = daily.LinkTickets(prs, ticket.NewIndex(stored))
+	}
+	prs = keepItems(excl, prs, func(p daily.PRWithIssues) data.Event { return p.Event })
+	reviews = keepItems(excl, reviews, func(r daily.ReviewedPR) data.Event { return r.Event })
 	report := daily.Aggregate(dateRange, prs, reviews)
 
+	// Fetch issue activity
+	if dailyIncludeIssues {
+		s.Suffix = " Fetching issue activity..."
+		report.IssueActivity, err = daily.FetchIssueActivity(dateRange, currentUser, sc)
+		if err != nil {
+			s.Stop()
+			return fmt.Errorf("failed to fetch issue activity: %w", err)
+		}
+	}
+
+	// Fetch open work (current state, independent of the range)
+	if dailyIncludeInProgress {
+		s.Suffix = " Fetching open PRs..."
+		report.InProgress, err = daily.FetchOpenPRs(sc)
+		if err != nil {
+			s.Stop()
+			return fmt.Errorf("failed to fetch open PRs: %w", err)
+		}
+	}
+	if dailyIncludeNextUp {
+		s.Suffix = " Fetching review requests..."
+		report.ReviewRequests, err = daily.FetchReviewRequests(sc)
+		if err != nil {
+			s.Stop()
+			return fmt.Errorf("failed to fetch review requests: %w", err)
+		}
+
+		s.Suffix = " Fetching assigned issues..."
+		report.AssignedIssues, err = daily.FetchAssignedIssues(sc)
+		if err != nil {
+			s.Stop()
+			return fmt.Errorf("failed to fetch assigned issues: %w", err)
+		}
+	}
+
+	if dailyIncludeNotes {
+		report.Notes = daily.NotesInRange(stored, dateRange)
+	}
+
+	report.IssueActivity = keepItems(excl, report.IssueActivity, func(a daily.IssueActivity) data.Event { return a.Event })
+	report.InProgress = keepItems(excl, report.InProgress, func(p daily.PullRequest) data.Event { return p.Event })
+	report.ReviewRequests = keepItems(excl, report.ReviewRequests, func(p daily.PullRequest) data.Event { return p.Event })
+	report.AssignedIssues = excl.events(report.AssignedIssues)
+	report.Notes = excl.events(report.Notes)
+	if showExcluded {
+		s.Stop()
+		excl.report()
+		s.Start()
+	}
+
+	// Show the redacted prompt instead of sending it
+	if dailySummarizePreview {
+		s.Stop()
+		preview, err := llm.Preview(summaryCfg, summaryInput(report))
+		if err != nil {
+			return fmt.Errorf("failed to build summary prompt: %w", err)
+		}
+		fmt.Println(preview)
+		return nil
+	}
+
 	// Generate summary if requested
 	if dailySummarize {
 		s.Suffix = " Generating summary..."
-		summary, err := summarizeReport(report)
+		dropped, err := summarizeReport(summaryCfg, report)
 		if err != nil {
 			s.Stop()
 			fmt.Fprintf(os.Stderr, "Warning: summarization failed: %v\n", err)
-		} else {
-			report.Summary = summary
+		} else if dropped > 0 {
+			s.Stop()
+			fmt.Fprintf(os.Stderr, "Warning: dropped %d summary bullets without a known source URL\n", dropped)
 		}
 	}
 
 	s.Stop()
 
 	// Render output
-	output, err := daily.Render(report, dailyFormat)
+	var output string
+	if templatePath != "" {
+		output, err = daily.RenderTemplateFile(report, templatePath)
+	} else {
+		output, err = daily.Render(report, dailyFormat)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to render report: %w", err)
 	}
 
+	if dailyPost != "" {
+		// Don't record the report as delivered if posting fails, so --since-last retries it
+		if err := postReport(cfg, report, templatePath, output); err != nil {
+			return err
+		}
+		if dailyDryRun {
+			return nil
+		}
+	}
+
 	fmt.Println(output)
+
+	if err := daily.RecordReport(statePath, dateRange.End); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record report state: %v\n", err)
+	}
+	return nil
+}
+
+// postFormats maps each --post target to the built-in format it understands
+var postFormats = map[string]string{
+	"slack":   "slack-mrkdwn",
+	"teams":   "markdown",
+	"discord": "markdown",
+}
+
+// postReport sends the report to the --post target, or prints the payloads with --dry-run.
+// A custom template's output is posted as-is; otherwise the target's native format is rendered.
+func postReport(cfg *config.Config, report *daily.DailyReport, templatePath, rendered string) error {
+	msg := notify.Message{Title: fmt.Sprintf("Daily report (%s)", report.DateLabel)}
+	if report.MultiDay() {
+		msg.Title = fmt.Sprintf("Report (%s)", report.DateLabel)
+	}
+
+	if dailyPost == "webhook" {
+		data, err := daily.RenderJSON(report)
+		if err != nil {
+			return err
+		}
+		msg.JSON = data
+	} else if templatePath != "" {
+		msg.Text = rendered
+	} else {
+		text, err := daily.Render(report, postFormats[dailyPost])
+		if err != nil {
+			return fmt.Errorf("failed to render report: %w", err)
+		}
+		msg.Text = text
+	}
+
+	payloads, err := notify.Payloads(dailyPost, msg)
+	if err != nil {
+		return err
+	}
+
+	if dailyDryRun {
+		for i, p := range payloads {
+			var pretty bytes.Buffer
+			if err := json.Indent(&pretty, p, "", "  "); err != nil {
+				pretty.Reset()
+				pretty.Write(p)
+			}
+			fmt.Printf("# %s payload %d of %d\n%s\n", dailyPost, i+1, len(payloads), pretty.String())
+		}
+		return nil
+	}
+
+	url := notify.ResolveURL(dailyPost, cfg.Daily.Post.URL(dailyPost))
+	if url == "" {
+		return fmt.Errorf("no URL for --post %s: set daily.post.%s in config or %s", dailyPost, dailyPost, notify.EnvVar(dailyPost))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if err := notify.Post(ctx, url, payloads, notify.Options{Attempts: cfg.Daily.Post.Attempts}); err != nil {
+		return fmt.Errorf("failed to post report to %s: %w", dailyPost, err)
+	}
+	fmt.Fprintf(os.Stderr, "Posted report to %s (%d message(s))\n", dailyPost, len(payloads))
 	return nil
 }
 
+// dailyRangeOptions merges range flags with config defaults and loads the --since-last state
+func dailyRangeOptions(cfg *config.Config) (daily.RangeOptions, string, error) {
+	opts := daily.RangeOptions{
+		Date:         dailyDate,
+		From:         dailyFrom,
+		To:           dailyTo,
+		Period:       dailyPeriod,
+		SinceLast:    dailySinceLast,
+		TZ:           cfg.Daily.TZ,
+		WeekStart:    time.Monday,
+		SprintAnchor: cfg.Daily.Sprint.Anchor,
+		SprintLength: cfg.Daily.Sprint.LengthDays,
+	}
+
+	if dailyTz != "" {
+		opts.TZ = dailyTz
+	}
+
+	weekStart := cfg.Daily.WeekStart
+	if dailyWeekStart != "" {
+		weekStart = dailyWeekStart
+	}
+	if weekStart != "" {
+		d, err := daily.ParseWeekday(weekStart)
+		if err != nil {
+			return opts, "", err
+		}
+		opts.WeekStart = d
+	}
+	if dailySprintAnchor != "" {
+		opts.SprintAnchor = dailySprintAnchor
+	}
+	if dailySprintLength > 0 {
+		opts.SprintLength = dailySprintLength
+	}
+
+	var holidays []string
+	if cfg.Daily.Holidays != "" {
+		h, err := daily.LoadHolidays(cfg.Daily.Holidays)
+		if err != nil {
+			return opts, "", fmt.Errorf("failed to load holidays: %w", err)
+		}
+		holidays = h
+	}
+	cal, err := daily.NewCalendar(cfg.Daily.WorkDays, holidays)
+	if err != nil {
+		return opts, "", fmt.Errorf("invalid daily.work_days: %w", err)
+	}
+	opts.Calendar = cal
+
+	statePath := cfg.Daily.StateFile
+	if statePath == "" {
+		statePath = daily.DefaultStatePath()
+	}
+	if dailySinceLast {
+		state, err := daily.LoadState(statePath)
+		if err != nil {
+			return opts, "", fmt.Errorf("failed to read report state %s: %w", statePath, err)
+		}
+		if state.LastEnd.IsZero() {
+			fmt.Fprintln(os.Stderr, "No previous report recorded; reporting since the previous working day.")
+		}
+		opts.LastEnd = state.LastEnd
+	}
+
+	return opts, statePath, nil
+}
+
 func validateDailyFlags() error {
 	// Check for mutually exclusive flags
 	if dailyDate != "" && (dailyFrom != "" || dailyTo != "") {
@@ -144,44 +459,125 @@ func validateDailyFlags() error {
 		return errors.New("--from and --to must be used together")
 	}
 
+	// Only one way of selecting the range
+	modes := 0
+	for _, set := range []bool{dailyDate != "", dailyFrom != "", dailyPeriod != "", dailySinceLast} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return errors.New("use only one of --date, --from/--to, --period and --since-last")
+	}
+
 	return nil
 }
 
-// summarizeReport generates an LLM summary of the daily report
-func summarizeReport(report *daily.DailyReport) (string, error) {
-	cfg := llm.Config{
-		Model:   dailySummarizeModel,
-		Lang:    dailySummarizeLang,
-		Prompt:  dailySummarizePrompt,
-		Timeout: dailySummarizeTimeout,
+// summaryConfig builds the summarization settings, loading any prompt template files
+func summaryConfig(cmd *cobra.Command, cfg *config.Config) (llm.Config, error) {
+	summaryCfg := llm.Config{
+		Endpoint:   cfg.LLM.Endpoint,
+		APIKeyEnv:  cfg.LLM.APIKeyEnv,
+		Model:      cfg.LLM.Model,
+		Lang:       dailySummarizeLang,
+		Prompt:     dailySummarizePrompt,
+		Timeout:    dailySummarizeTimeout,
+		Structured: cfg.Daily.Summary.Structured,
+
+		MaxPromptTokens: cfg.LLM.MaxPromptTokens,
+	}
+	if dailyVerbose {
+		summaryCfg.OnUsage = func(u llm.Usage) {
+			fmt.Fprintf(os.Stderr, "\nLLM %s\n", u)
+		}
+	}
+	if dailySummarizeModel != "" {
+		summaryCfg.Model = dailySummarizeModel
+	}
+	if cmd.Flags().Changed("summarize-structured") {
+		summaryCfg.Structured = dailySummarizeStruct
+	}
+
+	redactor, err := cfg.Redaction.Redactor()
+	if err != nil {
+		return llm.Config{}, err
+	}
+	summaryCfg.Redactor = redactor
+
+	for _, p := range []struct {
+		name, path string
+		dst        *string
+	}{
+		{"system", cfg.Daily.Summary.SystemPrompt, &summaryCfg.SystemTemplate},
+		{"user", cfg.Daily.Summary.UserPrompt, &summaryCfg.UserTemplate},
+	} {
+		if p.path == "" {
+			continue
+		}
+		raw, err := os.ReadFile(p.path)
+		if err != nil {
+			return llm.Config{}, fmt.Errorf("failed to read %s prompt template: %w", p.name, err)
+		}
+		if _, err := llm.ParsePromptTemplate(p.name, string(raw)); err != nil {
+			return llm.Config{}, err
+		}
+		*p.dst = string(raw)
 	}
+	return summaryCfg, nil
+}
 
+// summaryInput converts the report into the data sent to the LLM
+func summaryInput(report *daily.DailyReport) llm.SummaryInput {
 	input := llm.SummaryInput{
 		DateLabel: report.DateLabel,
 	}
 
-	// Convert issue groups
+	// Convert issue groups, rolling those with an epic up under it
+	epics := make(map[string]int)
 	for _, ig := range report.IssueGroups {
 		entry := llm.IssueEntry{
 			Title: ig.Issue.Title,
 			URL:   ig.Issue.URL,
+			Body:  ig.Issue.Body,
 		}
 		for _, pr := range ig.PRs {
 			entry.PRs = append(entry.PRs, llm.PREntry{
-				Title: pr.Title,
-				URL:   pr.URL,
-				Body:  pr.Body,
+				Title:   pr.Title,
+				URL:     pr.URL,
+				Body:    pr.Body,
+				Status:  strings.Join(pr.Badges(), ", "),
+				Private: pr.Private,
 			})
+			// Linked issues usually live next to their PRs; treat them as private if any PR is
+			entry.Private = entry.Private || pr.Private
+		}
+		epic := ig.Issue.Epic
+		if epic == nil {
+			input.IssueGroups = append(input.IssueGroups, entry)
+			continue
 		}
-		input.IssueGroups = append(input.IssueGroups, entry)
+		i, ok := epics[epic.URL]
+		if !ok {
+			i = len(input.Epics)
+			epics[epic.URL] = i
+			input.Epics = append(input.Epics, llm.EpicEntry{
+				Title: epic.Title,
+				URL:   epic.URL,
+				Body:  epic.Body,
+			})
+		}
+		input.Epics[i].Private = input.Epics[i].Private || entry.Private
+		input.Epics[i].Issues = append(input.Epics[i].Issues, entry)
 	}
 
 	// Convert standalone PRs
 	for _, pr := range report.StandalonePRs {
 		input.StandalonePRs = append(input.StandalonePRs, llm.PREntry{
-			Title: pr.Title,
-			URL:   pr.URL,
-			Body:  pr.Body,
+			Title:   pr.Title,
+			URL:     pr.URL,
+			Body:    pr.Body,
+			Status:  strings.Join(pr.Badges(), ", "),
+			Private: pr.Private,
 		})
 	}
 
@@ -198,5 +594,85 @@ func summarizeReport(report *daily.DailyReport) (string, error) {
 		})
 	}
 
-	return llm.Summarize(context.Background(), cfg, input)
+	// Convert issue activity
+	for _, ia := range report.IssueActivity {
+		input.IssueActivity = append(input.IssueActivity, llm.IssueActivityEntry{
+			Title:   ia.Title,
+			URL:     ia.URL,
+			Actions: ia.ActionSummary(),
+		})
+	}
+
+	// Convert notes
+	for _, n := range report.Notes {
+		input.Notes = append(input.Notes, llm.NoteEntry{
+			ID:    n.ID,
+			Title: n.Title,
+			URL:   n.URL,
+			Repo:  n.Repo,
+			Theme: n.Theme,
+		})
+	}
+
+	// Convert open work
+	for _, pr := range report.InProgress {
+		input.InProgress = append(input.InProgress, llm.PREntry{
+			Title:  pr.Title,
+			URL:    pr.URL,
+			Status: strings.Join(pr.Badges(), ", "),
+		})
+	}
+	for _, pr := range report.ReviewRequests {
+		input.ReviewRequests = append(input.ReviewRequests, llm.PREntry{
+			Title: pr.Title,
+			URL:   pr.URL,
+		})
+	}
+	for _, issue := range report.AssignedIssues {
+		input.AssignedIssues = append(input.AssignedIssues, llm.IssueEntry{
+			Title: issue.Title,
+			URL:   issue.URL,
+		})
+	}
+
+	return input
+}
+
+// summarizeReport fills the report's LLM summary. In structured mode it returns
+// how many bullets were dropped for citing no known source.
+func summarizeReport(cfg llm.Config, report *daily.DailyReport) (int, error) {
+	input := summaryInput(report)
+	if !cfg.Structured {
+		summary, err := llm.Summarize(context.Background(), cfg, input)
+		if err != nil {
+			return 0, err
+		}
+		report.Summary = summary
+		return 0, nil
+	}
+
+	summary, err := llm.SummarizeStructured(context.Background(), cfg, input)
+	if err != nil {
+		return 0, err
+	}
+	for _, theme := range summary.Themes {
+		t := daily.SummaryTheme{Title: theme.Title}
+		for _, b := range theme.Bullets {
+			t.Bullets = append(t.Bullets, daily.SummaryBullet{Text: b.Text, Sources: links(b.Sources)})
+		}
+		report.SummaryThemes = append(report.SummaryThemes, t)
+	}
+	return summary.Dropped, nil
+}
+
+// links returns the sources that are URLs; notes without one are cited by ID,
+// which is kept out of the report as there is nothing to link to
+func links(sources []string) []string {
+	var urls []string
+	for _, s := range sources {
+		if !strings.HasPrefix(s, note.Kind+":") {
+			urls = append(urls, s)
+		}
+	}
+	return urls
 }
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+var showExcluded bool
+
+// addShowExcludedFlag registers --show-excluded on a command that applies the config's rules
+func addShowExcludedFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&showExcluded, "show-excluded", false, "List events the config's rules excluded, and why, on stderr")
+}
+
+// exclusions applies the config's exclusion rules and remembers what they dropped
+type exclusions struct {
+	set      *rules.Set
+	excluded []rules.Exclusion
+}
+
+func newExclusions(cfg *config.Config) (*exclusions, error) {
+	set, err := cfg.Rules.Set()
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+	return &exclusions{set: set}, nil
+}
+
+// events returns the events the rules keep
+func (x *exclusions) events(events []data.Event) []data.Event {
+	kept, excluded := x.set.Filter(events)
+	x.excluded = append(x.excluded, excluded...)
+	return kept
+}
+
+// keepItems returns the items whose event the rules keep
+func keepItems[T any](x *exclusions, items []T, event func(T) data.Event) []T {
+	kept := items[:0:0]
+	for _, item := range items {
+		e := event(item)
+		if rule := x.set.Excluded(e); rule != "" {
+			x.excluded = append(x.excluded, rules.Exclusion{Event: e, Rule: rule})
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+// report lists the excluded events on stderr when --show-excluded is set
+func (x *exclusions) report() {
+	if !showExcluded {
+		return
+	}
+	if len(x.excluded) == 0 {
+		fmt.Fprintln(os.Stderr, "No events excluded.")
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Excluded %d event(s):\n", len(x.excluded))
+	for _, ex := range x.excluded {
+		e := ex.Event
+		fmt.Fprintf(os.Stderr, "  %s: %s#%d %q by %s\n", ex.Rule, e.Repo, e.Number, e.Title, e.Author)
+	}
+}
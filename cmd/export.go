@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/export"
+	"github.com/jackchuka/gh-brag/internal/store"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	exportIn      string
+	exportOut     string
+	exportFormat  string
+	exportTable   string
+	exportColumns []string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export events or metrics as CSV, NDJSON or Parquet",
+	Long: `Writes collected events, or a metrics table, as a flat table for spreadsheets
+and tools such as DuckDB. Each event is one row with its resolved theme and
+impact; labels, reviewers and commits are joined with ";" in CSV and Parquet,
+and stay arrays in NDJSON. The weekly, repos and themes tables hold the weekly
+trend, repository stats and theme mix that analyze reports.`,
+	Example: `  gh brag export --format csv --out events.csv
+  gh brag export --format parquet --out events.parquet --columns id,repo,theme,impact,closedAt
+  gh brag export --table repos --format ndjson`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportIn, "in", "gh-brag.events.jsonl", "Input JSONL file (default from the profile's store)")
+	exportCmd.Flags().StringVar(&exportOut, "out", "", "Output file (default: stdout)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", export.FormatCSV, "Output format: "+strings.Join(export.Formats, ", "))
+	exportCmd.Flags().StringVar(&exportTable, "table", export.TableEvents, "Table to export: "+strings.Join(export.Tables, ", "))
+	exportCmd.Flags().StringSliceVar(&exportColumns, "columns", nil, "Columns to export, in order (default: all; for events, a common subset)")
+	addShowExcludedFlag(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	if !slices.Contains(export.Formats, exportFormat) {
+		return fmt.Errorf("invalid format %q: must be %s", exportFormat, strings.Join(export.Formats, ", "))
+	}
+	if !slices.Contains(export.Tables, exportTable) {
+		return fmt.Errorf("invalid table %q: must be %s", exportTable, strings.Join(export.Tables, ", "))
+	}
+	if exportFormat == export.FormatParquet && exportOut == "" && term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("parquet is binary: pass --out or redirect stdout to a file")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	events, err := store.LoadEvents(eventsPath(cmd, "in", cfg))
+	if err != nil {
+		return fmt.Errorf("failed to load events: %w", err)
+	}
+	events = cfg.ActiveProfile().Scope().Filter(events)
+
+	excl, err := newExclusions(cfg)
+	if err != nil {
+		return err
+	}
+	events = excl.events(events)
+	excl.report()
+
+	analyzer, err := analyze.New(cfg)
+	if err != nil {
+		return err
+	}
+
+	var table export.Table
+	columns := exportColumns
+	switch exportTable {
+	case export.TableEvents:
+		table = export.Events(events, analyzer)
+		if len(columns) == 0 {
+			columns = export.DefaultEventColumns
+		}
+	case export.TableWeekly:
+		table = export.Weekly(analyzer.Analyze(events))
+	case export.TableRepos:
+		table = export.Repos(analyzer.Analyze(events))
+	case export.TableThemes:
+		table = export.Themes(analyzer.Analyze(events))
+	}
+	if len(columns) > 0 {
+		if table, err = table.Select(columns); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := export.Write(&buf, table, exportFormat); err != nil {
+		return fmt.Errorf("failed to export: %w", err)
+	}
+	if exportOut == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(exportOut, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	fmt.Printf("Exported %d rows of the %s table to %s\n", len(table.Rows), exportTable, exportOut)
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/graph"
+	"github.com/jackchuka/gh-brag/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphIn       string
+	graphOut      string
+	graphFormat   string
+	graphUser     string
+	graphMinCount int
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export your collaboration graph",
+	Long: `Builds a weighted directed graph of review interactions (reviewer → author)
+and exports it for Graphviz (dot), Markdown (mermaid) or other tools (json).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(graph.Formats, graphFormat) {
+			return fmt.Errorf("invalid format %q: must be %s", graphFormat, strings.Join(graph.Formats, ", "))
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		events, err := store.LoadEvents(eventsPath(cmd, "in", cfg))
+		if err != nil {
+			return fmt.Errorf("failed to load events: %w", err)
+		}
+		events = cfg.ActiveProfile().Scope().Filter(events)
+
+		excl, err := newExclusions(cfg)
+		if err != nil {
+			return err
+		}
+		events = excl.events(events)
+		excl.report()
+
+		self := graphUser
+		if self == "" {
+			self = analyze.InferSelf(events)
+		}
+
+		g := analyze.BuildGraph(events, self).Filter(graphMinCount)
+		out, err := graph.Render(g, graphFormat)
+		if err != nil {
+			return err
+		}
+
+		if graphOut == "" {
+			fmt.Print(out)
+			return nil
+		}
+		if err := os.WriteFile(graphOut, []byte(out), 0644); err != nil {
+			return fmt.Errorf("failed to write graph: %w", err)
+		}
+		fmt.Printf("Graph with %d people and %d edges written to %s\n", len(g.Nodes), len(g.Edges), graphOut)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().StringVar(&graphIn, "in", "gh-brag.events.jsonl", "Input JSONL file (default from the profile's store)")
+	graphCmd.Flags().StringVar(&graphOut, "out", "", "Output file (default: stdout)")
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "Output format: dot, mermaid, json")
+	graphCmd.Flags().StringVar(&graphUser, "user", "", "Your login, used as the reviewer of your reviews (default: inferred from your PRs)")
+	graphCmd.Flags().IntVar(&graphMinCount, "min-count", 1, "Only include edges with at least this many interactions")
+	addShowExcludedFlag(graphCmd)
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/importer"
+	"github.com/jackchuka/gh-brag/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importOut        string
+	importGitPath    string
+	importGitAuthors []string
+	importGitRepo    string
+	importGitSince   string
+	importGitUntil   string
+	importBaseURL    string
+	importFormat     string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import activity from outside GitHub search into the events file",
+}
+
+var importGitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Import commits from a local git repository",
+	Long: `Reads the repository's history with git log and stores each non-merge commit
+as an event, with its message, timestamps and diffstat. Commits that belong to
+PRs already in the events file (matched by SHA) or that were imported before
+are skipped, so it is safe to run repeatedly. PR commit SHAs are recorded by
+collect --commits, and by collect once the file holds imported commits.
+
+Repositories with a GitHub origin are named after it and link to the commit;
+others are named local/<directory> unless --repo is given.`,
+	Example: `  gh brag import git --path ~/src/tool --author me@example.com
+  gh brag import git --path . --since 2026-01-01 --repo acme/firmware`,
+	Args: cobra.NoArgs,
+	RunE: runImportGit,
+}
+
+var importJiraCmd = &cobra.Command{
+	Use:   "jira <file>",
+	Short: "Import tickets from a Jira CSV or JSON export",
+	Long: `Reads a Jira CSV export ("Export Excel CSV") or the JSON of the REST search API
+and stores each ticket as an issue event keyed by its ticket key, e.g. ABC-123.
+PRs and commits that mention a key in their title, branch name or body are then
+grouped under the ticket by analyze and daily. Tickets already in the events file
+are updated in place, so re-importing a newer export is safe.`,
+	Example: `  gh brag import jira jira.csv --base-url https://acme.atlassian.net
+  gh brag import jira search.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImportTickets(cmd, args[0], importer.Jira)
+	},
+}
+
+var importLinearCmd = &cobra.Command{
+	Use:   "linear <file>",
+	Short: "Import issues from a Linear CSV or JSON export",
+	Long: `Reads a Linear CSV export or the JSON of a GraphQL issues query and stores
+each issue as an issue event keyed by its identifier, e.g. ENG-42. PRs and commits
+that mention an identifier in their title, branch name or body are then grouped
+under the issue by analyze and daily. Issues already in the events file are
+updated in place, so re-importing a newer export is safe.`,
+	Example: `  gh brag import linear export.csv --base-url https://linear.app/acme
+  gh brag import linear issues.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImportTickets(cmd, args[0], importer.Linear)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importGitCmd, importJiraCmd, importLinearCmd)
+
+	importCmd.PersistentFlags().StringVar(&importOut, "out", "gh-brag.events.jsonl", "Events file to add to (default from the profile's store)")
+
+	importGitCmd.Flags().StringVar(&importGitPath, "path", ".", "Path inside the git repository")
+	importGitCmd.Flags().StringSliceVar(&importGitAuthors, "author", nil, "Author email or name, repeatable (default: the repository's user.email)")
+	importGitCmd.Flags().StringVar(&importGitRepo, "repo", "", "Repository name for the events (default from the origin remote, else local/<dir>)")
+	importGitCmd.Flags().StringVar(&importGitSince, "since", "", "Only commits on or after this date (YYYY-MM-DD)")
+	importGitCmd.Flags().StringVar(&importGitUntil, "until", "", "Only commits on or before this date (YYYY-MM-DD)")
+
+	for _, c := range []*cobra.Command{importJiraCmd, importLinearCmd} {
+		c.Flags().StringVar(&importBaseURL, "base-url", "", "Site to link tickets to, e.g. https://acme.atlassian.net or https://linear.app/acme (required for CSV)")
+		c.Flags().StringVar(&importFormat, "format", "", "Export format: csv or json (default: detected from the content)")
+	}
+}
+
+func runImportGit(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	excl, err := newExclusions(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	authors := importGitAuthors
+	if len(authors) == 0 {
+		email, err := importer.AuthorEmail(ctx, importGitPath)
+		if err != nil || email == "" {
+			return fmt.Errorf("no --author given and no user.email configured in %s", importGitPath)
+		}
+		authors = []string{email}
+	}
+
+	events, err := importer.Git(ctx, importer.GitOptions{
+		Path:    importGitPath,
+		Authors: authors,
+		Repo:    importGitRepo,
+		Since:   importGitSince,
+		Until:   importGitUntil,
+		Hosts:   cfg.ActiveProfile().Hosts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read git history: %w", err)
+	}
+
+	// As with collect, excluded commits are only dropped here when the rules ask for it
+	if cfg.Rules.OnCollect {
+		events = excl.events(events)
+		excl.report()
+	}
+
+	out := eventsPath(cmd, "out", cfg)
+	existing, err := loadStoreEvents(out)
+	if err != nil {
+		return err
+	}
+	fresh, skipped := importer.Dedupe(events, existing)
+	if len(fresh) == 0 {
+		fmt.Printf("No new commits found (%d already in %s).\n", skipped, out)
+		return nil
+	}
+	if err := store.AppendEvents(out, fresh); err != nil {
+		return fmt.Errorf("failed to save events: %w", err)
+	}
+	fmt.Printf("Imported %d commits from %s into %s (%d already there)\n", len(fresh), fresh[0].Repo, out, skipped)
+	return nil
+}
+
+// runImportTickets reads a Jira or Linear export ("-" for stdin) and merges its
+// tickets into the events file
+func runImportTickets(cmd *cobra.Command, file string, read func(io.Reader, importer.TicketOptions) ([]data.Event, error)) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	tickets, err := read(r, importer.TicketOptions{Format: importFormat, BaseURL: importBaseURL, Name: filepath.Base(file)})
+	if err != nil {
+		return err
+	}
+	if len(tickets) == 0 {
+		fmt.Println("No tickets found.")
+		return nil
+	}
+
+	out := eventsPath(cmd, "out", cfg)
+	existing, err := loadStoreEvents(out)
+	if err != nil {
+		return err
+	}
+	merged, added, updated := importer.Merge(tickets, existing)
+	if err := store.WriteEvents(out, merged); err != nil {
+		return fmt.Errorf("failed to save events: %w", err)
+	}
+	fmt.Printf("Imported %d tickets into %s (%d new, %d updated)\n", len(tickets), out, added, updated)
+	return nil
+}
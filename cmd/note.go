@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/note"
+	"github.com/jackchuka/gh-brag/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	noteIn     string
+	noteDate   string
+	noteTitle  string
+	noteTheme  string
+	noteURL    string
+	noteImpact float64
+	noteFrom   string
+	noteTo     string
+)
+
+var noteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Record accomplishments that happened outside GitHub",
+	Long: `Notes are manual entries, such as a talk, an incident you led or mentoring,
+stored as events next to collected GitHub activity. They count towards themes,
+the impact score, the dashboard and the daily report like any other event.`,
+}
+
+var noteAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a note",
+	Example: `  gh brag note add --title "Led the checkout outage postmortem" --theme Maintenance --impact 15
+  gh brag note add --date 2026-03-04 --title "Spoke at GopherCon" --url https://example.com/talk`,
+	Args: cobra.NoArgs,
+	RunE: runNoteAdd,
+}
+
+var noteListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List notes, oldest first",
+	Args:  cobra.NoArgs,
+	RunE:  runNoteList,
+}
+
+var noteEditCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Change the fields of a note given as flags",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNoteEdit,
+}
+
+var noteRmCmd = &cobra.Command{
+	Use:   "rm <id>...",
+	Short: "Remove notes",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runNoteRm,
+}
+
+func init() {
+	rootCmd.AddCommand(noteCmd)
+	noteCmd.AddCommand(noteAddCmd, noteListCmd, noteEditCmd, noteRmCmd)
+
+	noteCmd.PersistentFlags().StringVar(&noteIn, "in", "gh-brag.events.jsonl", "Events file, updated in place (default from the profile's store)")
+
+	for _, c := range []*cobra.Command{noteAddCmd, noteEditCmd} {
+		c.Flags().StringVar(&noteDate, "date", "", "Day it happened (YYYY-MM-DD, default today)")
+		c.Flags().StringVar(&noteTitle, "title", "", "What you did")
+		c.Flags().StringVar(&noteTheme, "theme", "", "Theme from the config (default: matched by keywords)")
+		c.Flags().StringVar(&noteURL, "url", "", "Link to a doc, recording or ticket")
+		c.Flags().Float64Var(&noteImpact, "impact", 0, "Impact weight, replacing metrics.action_weights.noted")
+	}
+	_ = noteAddCmd.MarkFlagRequired("title")
+
+	noteListCmd.Flags().StringVar(&noteFrom, "from", "", "Only notes on or after this day (YYYY-MM-DD)")
+	noteListCmd.Flags().StringVar(&noteTo, "to", "", "Only notes on or before this day (YYYY-MM-DD)")
+}
+
+func runNoteAdd(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	now := time.Now()
+	date, err := note.ParseDate(noteDate, now, time.Local)
+	if err != nil {
+		return err
+	}
+	theme, err := noteThemeName(cfg, noteTheme)
+	if err != nil {
+		return err
+	}
+
+	e, err := note.New(note.Fields{Date: date, Title: noteTitle, Theme: theme, URL: noteURL, Impact: noteImpact}, now)
+	if err != nil {
+		return err
+	}
+
+	path := eventsPath(cmd, "in", cfg)
+	if err := store.AppendEvents(path, []data.Event{e}); err != nil {
+		return fmt.Errorf("failed to save note: %w", err)
+	}
+	fmt.Printf("Added %s to %s\n", e.ID, path)
+	return nil
+}
+
+func runNoteList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	for _, day := range []string{noteFrom, noteTo} {
+		if _, err := time.Parse(note.DateLayout, day); day != "" && err != nil {
+			return fmt.Errorf("invalid date %q (want YYYY-MM-DD)", day)
+		}
+	}
+
+	events, err := loadStoreEvents(eventsPath(cmd, "in", cfg))
+	if err != nil {
+		return err
+	}
+
+	// YYYY-MM-DD days compare correctly as strings
+	var notes []data.Event
+	for _, e := range events {
+		day := note.Day(e)
+		if note.Is(e) && (noteFrom == "" || day >= noteFrom) && (noteTo == "" || day <= noteTo) {
+			notes = append(notes, e)
+		}
+	}
+	if len(notes) == 0 {
+		fmt.Println("No notes found.")
+		return nil
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return note.Day(notes[i]) < note.Day(notes[j])
+	})
+
+	fmt.Printf("%-22s %-10s %-12s %6s  %s\n", "ID", "DATE", "THEME", "IMPACT", "TITLE")
+	for _, e := range notes {
+		theme, impact := "-", "-"
+		if e.Theme != "" {
+			theme = e.Theme
+		}
+		if e.Impact > 0 {
+			impact = fmt.Sprintf("%.1f", e.Impact)
+		}
+		fmt.Printf("%-22s %-10s %-12s %6s  %s\n", e.ID, note.Day(e), theme, impact, e.Title)
+		if e.URL != "" {
+			fmt.Printf("%-22s %s\n", "", e.URL)
+		}
+	}
+	return nil
+}
+
+func runNoteEdit(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	path := eventsPath(cmd, "in", cfg)
+	events, err := loadStoreEvents(path)
+	if err != nil {
+		return err
+	}
+	i, err := note.Find(events, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Only the flags given change the note
+	f := note.FieldsOf(events[i])
+	flags := cmd.Flags()
+	if flags.Changed("date") {
+		if f.Date, err = note.ParseDate(noteDate, time.Now(), time.Local); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("title") {
+		f.Title = noteTitle
+	}
+	if flags.Changed("theme") {
+		if f.Theme, err = noteThemeName(cfg, noteTheme); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("url") {
+		f.URL = noteURL
+	}
+	if flags.Changed("impact") {
+		f.Impact = noteImpact
+	}
+	if err := f.Validate(); err != nil {
+		return err
+	}
+
+	note.Apply(&events[i], f)
+	if err := store.WriteEvents(path, events); err != nil {
+		return fmt.Errorf("failed to save notes: %w", err)
+	}
+	fmt.Printf("Updated %s\n", events[i].ID)
+	return nil
+}
+
+func runNoteRm(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	path := eventsPath(cmd, "in", cfg)
+	events, err := loadStoreEvents(path)
+	if err != nil {
+		return err
+	}
+
+	// Check every ID before removing any, so a typo leaves the file untouched
+	remove := make(map[string]bool)
+	for _, id := range args {
+		i, err := note.Find(events, id)
+		if err != nil {
+			return err
+		}
+		remove[events[i].ID] = true
+	}
+
+	kept := events[:0]
+	for _, e := range events {
+		if !remove[e.ID] {
+			kept = append(kept, e)
+		}
+	}
+	if err := store.WriteEvents(path, kept); err != nil {
+		return fmt.Errorf("failed to save notes: %w", err)
+	}
+	fmt.Printf("Removed %d note(s) from %s\n", len(remove), path)
+	return nil
+}
+
+// noteThemeName returns the configured theme named name, ignoring case
+func noteThemeName(cfg *config.Config, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	names := make([]string, 0, len(cfg.Themes))
+	for _, t := range cfg.Themes {
+		if strings.EqualFold(t.Name, name) {
+			return t.Name, nil
+		}
+		names = append(names, t.Name)
+	}
+	return "", fmt.Errorf("unknown theme %q (want one of %s)", name, strings.Join(names, ", "))
+}
@@ -1,13 +1,22 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/github"
+	"github.com/jackchuka/gh-brag/internal/store"
 	"github.com/spf13/cobra"
 )
 
-var rootConfig string
+var (
+	rootConfig  string
+	rootProfile string
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "gh-brag",
@@ -28,5 +37,39 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&rootConfig, "config", "", "Path to configuration file (e.g., gh-brag-config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&rootConfig, "config", "", "Path to configuration file, applied over ~/.config/gh-brag/config.yaml and ./.gh-brag.yaml")
+	rootCmd.PersistentFlags().StringVar(&rootProfile, "profile", "", "Named profile from the config (hosts, filters, store and settings)")
+}
+
+// loadConfig loads the layered config with the active profile applied and
+// points GitHub API calls at the profile's hosts
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.LoadConfig(rootConfig, rootProfile)
+	if err != nil {
+		return nil, err
+	}
+	github.SetHosts(cfg.ActiveProfile().Hosts)
+	return cfg, nil
+}
+
+// eventsPath returns the events file: the flag's value when set, else the
+// active profile's store, else the flag's default
+func eventsPath(cmd *cobra.Command, flag string, cfg *config.Config) string {
+	path, _ := cmd.Flags().GetString(flag)
+	if store := cfg.ActiveProfile().Store; store != "" && !cmd.Flags().Changed(flag) {
+		return store
+	}
+	return path
+}
+
+// loadStoreEvents loads an events file; a missing file holds no events
+func loadStoreEvents(path string) ([]data.Event, error) {
+	events, err := store.LoadEvents(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+	return events, nil
 }
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveIn   string
+	serveAddr string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve an interactive dashboard in the browser",
+	Long: `Serves a dashboard of your collected events at the given address, with date
+range, theme, repository and collaborator filters, charts and clickable event
+lists. The page reads a small JSON API (/api/filters, /api/metrics and
+/api/events) that runs the same analysis as analyze, and the events file is
+re-read on every request, so reload the page after collecting.
+
+The server listens on localhost by default; it has no authentication, so only
+bind it to other interfaces on a network you trust.`,
+	Example: `  gh brag serve
+  gh brag serve --addr localhost:9000 --in team.events.jsonl`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveIn, "in", "gh-brag.events.jsonl", "Input JSONL file (default from the profile's store)")
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Address to listen on")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	analyzer, err := analyze.New(cfg)
+	if err != nil {
+		return err
+	}
+	set, err := cfg.Rules.Set()
+	if err != nil {
+		return fmt.Errorf("invalid rules: %w", err)
+	}
+
+	path := eventsPath(cmd, "in", cfg)
+	scope := cfg.ActiveProfile().Scope()
+	load := func() ([]data.Event, error) {
+		events, err := loadStoreEvents(path)
+		if err != nil {
+			return nil, err
+		}
+		kept, _ := set.Filter(scope.Filter(events))
+		return kept, nil
+	}
+
+	ln, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	srv := &http.Server{
+		Handler:           server.New(analyzer, load),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdown)
+	}()
+
+	fmt.Printf("Serving %s at http://%s (Ctrl+C to stop)\n", path, ln.Addr())
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
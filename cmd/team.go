@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/store"
+	"github.com/jackchuka/gh-brag/internal/team"
+	"github.com/jackchuka/gh-brag/internal/visualize"
+	"github.com/spf13/cobra"
+)
+
+var (
+	teamIn   string
+	teamFile string
+)
+
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Work with activity collected in team mode",
+}
+
+var teamSummaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Show a team summary for managers",
+	Long: `Displays what the team worked on as a whole and what each member focused on.
+Members are listed alphabetically without scores or rankings.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		events, err := store.LoadEvents(eventsPath(cmd, "in", cfg))
+		if err != nil {
+			return fmt.Errorf("failed to load events: %w", err)
+		}
+		events = cfg.ActiveProfile().Scope().Filter(events)
+
+		analyzer, err := analyze.New(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create analyzer: %w", err)
+		}
+		excl, err := newExclusions(cfg)
+		if err != nil {
+			return err
+		}
+		events = excl.events(events)
+		excl.report()
+
+		name := ""
+		if teamFile != "" {
+			t, err := team.Load(teamFile)
+			if err != nil {
+				return err
+			}
+			name = t.Name
+		}
+
+		visualize.NewTeamSummary(name, analyzer.AnalyzeTeam(events)).Render()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(teamCmd)
+	teamCmd.AddCommand(teamSummaryCmd)
+
+	teamSummaryCmd.Flags().StringVar(&teamIn, "in", "gh-brag.events.jsonl", "Input JSONL file collected with --team (default from the profile's store)")
+	teamSummaryCmd.Flags().StringVar(&teamFile, "team", "", "Team file (YAML), used for the team name")
+	addShowExcludedFlag(teamSummaryCmd)
+}
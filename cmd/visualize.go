@@ -1,14 +1,10 @@
 package cmd
 
 import (
-	"bufio"
-	"encoding/json"
 	"fmt"
-	"os"
 
 	"github.com/jackchuka/gh-brag/internal/analyze"
-	"github.com/jackchuka/gh-brag/internal/config"
-	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/store"
 	"github.com/jackchuka/gh-brag/internal/visualize"
 	"github.com/spf13/cobra"
 )
@@ -21,42 +17,38 @@ var visualizeCmd = &cobra.Command{
 	Use:   "visualize",
 	Short: "Visualize your activity trends",
 	Long:  `Displays a TUI dashboard showing your activity trends, impact, and collaboration.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		f, err := os.Open(visualizeIn)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
 		if err != nil {
-			fmt.Printf("Error opening file: %v\n", err)
-			return
+			return fmt.Errorf("failed to load config: %w", err)
 		}
-		defer func() {
-			_ = f.Close()
-		}()
-
-		var events []data.Event
-		scanner := bufio.NewScanner(f)
-		for scanner.Scan() {
-			var e data.Event
-			if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
-				events = append(events, e)
-			}
+
+		events, err := store.LoadEvents(eventsPath(cmd, "in", cfg))
+		if err != nil {
+			return fmt.Errorf("failed to load events: %w", err)
 		}
-		cfg, err := config.LoadConfig(rootConfig)
+		events = cfg.ActiveProfile().Scope().Filter(events)
+
+		excl, err := newExclusions(cfg)
 		if err != nil {
-			fmt.Printf("Warning: error loading config: %v. Using defaults.\n", err)
-			cfg = &config.Config{}
+			return err
 		}
+		events = excl.events(events)
+		excl.report()
 
 		analyzer, err := analyze.New(cfg)
 		if err != nil {
-			fmt.Printf("Error creating analyzer: %v\n", err)
-			return
+			return fmt.Errorf("failed to create analyzer: %w", err)
 		}
 		metrics := analyzer.Analyze(events)
 		visualize.NewDashboard(metrics).Render()
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(visualizeCmd)
 
-	visualizeCmd.Flags().StringVar(&visualizeIn, "in", "gh-brag.events.jsonl", "Input JSONL file")
+	addShowExcludedFlag(visualizeCmd)
+	visualizeCmd.Flags().StringVar(&visualizeIn, "in", "gh-brag.events.jsonl", "Input JSONL file (default from the profile's store)")
 }
@@ -9,6 +9,7 @@ import (
 // Analyzer encapsulates the analysis configuration and provides methods for various analyses.
 type Analyzer struct {
 	config *config.Config
+	custom []compiledMetric
 }
 
 // New creates a new Analyzer instance with the provided configuration.
@@ -16,7 +17,12 @@ func New(config *config.Config) (*Analyzer, error) {
 	if config == nil {
 		return nil, errors.New("config is nil")
 	}
+	custom, err := compileCustomMetrics(config.CustomMetrics)
+	if err != nil {
+		return nil, err
+	}
 	return &Analyzer{
 		config: config,
+		custom: custom,
 	}, nil
 }
@@ -21,7 +21,7 @@ func (a *Analyzer) collaboration(events []data.Event) Collaboration {
 	reviewersMap := make(map[string]int)
 
 	for _, e := range events {
-		if e.Action == data.EventActionReviewed {
+		if e.Action == data.EventActionReviewed && (e.Subject == "" || e.Author != e.Subject) {
 			reviewees[e.Author]++
 		}
 
@@ -62,6 +62,27 @@ func TestCollaboration(t *testing.T) {
 				Reviewees: []UserStat{},
 			},
 		},
+		{
+			name: "Team mode skips reviewing own PRs",
+			events: []data.Event{
+				{
+					Action:  data.EventActionReviewed,
+					Author:  "alice",
+					Subject: "alice",
+				},
+				{
+					Action:  data.EventActionReviewed,
+					Author:  "bob",
+					Subject: "alice",
+				},
+			},
+			expected: Collaboration{
+				Reviewers: []UserStat{},
+				Reviewees: []UserStat{
+					{Login: "bob", Count: 1},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,39 @@
+package analyze
+
+import "github.com/jackchuka/gh-brag/internal/data"
+
+// DropCountedCommits drops imported commits whose SHA belongs to another event,
+// such as the PR they were merged in. import git skips those commits itself,
+// but not when the PR is collected after the commits were imported.
+func DropCountedCommits(events []data.Event) []data.Event {
+	shas := make(map[string]bool)
+	for _, e := range events {
+		if e.Action == data.EventActionCommitted {
+			continue
+		}
+		for _, sha := range e.Commits {
+			shas[sha] = true
+		}
+	}
+	if len(shas) == 0 {
+		return events
+	}
+
+	kept := make([]data.Event, 0, len(events))
+	for _, e := range events {
+		if e.Action == data.EventActionCommitted && counted(e, shas) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
+
+func counted(e data.Event, shas map[string]bool) bool {
+	for _, sha := range e.Commits {
+		if shas[sha] {
+			return true
+		}
+	}
+	return false
+}
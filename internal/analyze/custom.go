@@ -0,0 +1,108 @@
+package analyze
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/expr"
+)
+
+// GroupValue is a custom metric's value for one group-by key.
+type GroupValue struct {
+	Key   string
+	Value float64
+}
+
+// CustomMetric is the evaluated result of a config-defined metric.
+type CustomMetric struct {
+	Name      string
+	Expr      string
+	Unit      string       `yaml:",omitempty"`
+	Value     float64      // Value over all events (0 when the expression has no data)
+	Groups    []GroupValue `yaml:",omitempty"` // Sorted by value descending when group_by is set
+	Error     string       `yaml:",omitempty"` // Evaluation error, e.g. a type mismatch on some event
+	Dashboard bool         `yaml:"-"`
+}
+
+// compiledMetric pairs a custom metric definition with its compiled program.
+type compiledMetric struct {
+	def     config.CustomMetric
+	program *expr.Program
+}
+
+// compileCustomMetrics compiles every custom metric in the config.
+func compileCustomMetrics(defs []config.CustomMetric) ([]compiledMetric, error) {
+	compiled := make([]compiledMetric, 0, len(defs))
+	for _, def := range defs {
+		program, err := expr.Compile(def.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("custom metric %q: %w", def.Name, err)
+		}
+		if def.GroupBy != "" && !expr.IsField(def.GroupBy) {
+			return nil, fmt.Errorf("custom metric %q: unknown group_by field %q", def.Name, def.GroupBy)
+		}
+		compiled = append(compiled, compiledMetric{def: def, program: program})
+	}
+	return compiled, nil
+}
+
+// customMetrics evaluates the compiled custom metrics over events.
+// Evaluation errors are reported on the affected metric rather than failing the analysis.
+func (a *Analyzer) customMetrics(events []data.Event, themeMap map[string]string) []CustomMetric {
+	if len(a.custom) == 0 {
+		return nil
+	}
+
+	records := make([]expr.Record, len(events))
+	for i, e := range events {
+		records[i] = expr.EventRecord(e, themeMap[e.ID])
+	}
+
+	results := make([]CustomMetric, 0, len(a.custom))
+	for _, m := range a.custom {
+		result := CustomMetric{
+			Name:      m.def.Name,
+			Expr:      m.def.Expr,
+			Unit:      m.def.Unit,
+			Dashboard: m.def.Dashboard,
+		}
+
+		value, _, err := m.program.Eval(records)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.Value = value
+
+		if m.def.GroupBy != "" {
+			groups := make(map[string][]expr.Record)
+			for _, rec := range records {
+				for _, key := range expr.GroupKeys(rec, m.def.GroupBy) {
+					groups[key] = append(groups[key], rec)
+				}
+			}
+			for key, recs := range groups {
+				v, ok, err := m.program.Eval(recs)
+				if err != nil {
+					result.Error = fmt.Sprintf("group %q: %v", key, err)
+					continue
+				}
+				if ok {
+					result.Groups = append(result.Groups, GroupValue{Key: key, Value: v})
+				}
+			}
+			sort.Slice(result.Groups, func(i, j int) bool {
+				if result.Groups[i].Value != result.Groups[j].Value {
+					return result.Groups[i].Value > result.Groups[j].Value
+				}
+				return result.Groups[i].Key < result.Groups[j].Key
+			})
+		}
+
+		results = append(results, result)
+	}
+	return results
+}
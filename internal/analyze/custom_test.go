@@ -0,0 +1,73 @@
+package analyze
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+func TestCustomMetrics(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		Themes: []config.Theme{
+			{Name: "Feature", Keywords: []string{"feat"}},
+		},
+		CustomMetrics: []config.CustomMetric{
+			{Name: "Platform merges", Expr: `count(action == "merged" && repo =~ "^platform/")`, Dashboard: true},
+			{Name: "Features by repo", Expr: `count(theme == "Feature")`, GroupBy: "repo"},
+			{Name: "Broken", Expr: `sum(title)`},
+		},
+	}
+	analyzer, err := New(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Now()
+	events := []data.Event{
+		{ID: "1", Action: data.EventActionMerged, Repo: "platform/api", Title: "feat: a", Timestamps: data.Timestamps{UpdatedAt: now}},
+		{ID: "2", Action: data.EventActionMerged, Repo: "platform/api", Title: "feat: b", Timestamps: data.Timestamps{UpdatedAt: now}},
+		{ID: "3", Action: data.EventActionReviewed, Repo: "tools/cli", Title: "feat: c", Timestamps: data.Timestamps{UpdatedAt: now}},
+	}
+
+	got := analyzer.Analyze(events).Custom
+	if len(got) != 3 {
+		t.Fatalf("expected 3 custom metrics, got %d", len(got))
+	}
+
+	if got[0].Value != 2 || !got[0].Dashboard {
+		t.Errorf("expected platform merges 2 on dashboard, got %+v", got[0])
+	}
+
+	wantGroups := []GroupValue{{Key: "platform/api", Value: 2}, {Key: "tools/cli", Value: 1}}
+	if got[1].Value != 3 || !reflect.DeepEqual(got[1].Groups, wantGroups) {
+		t.Errorf("expected 3 features grouped %v, got %+v", wantGroups, got[1])
+	}
+
+	if !strings.Contains(got[2].Error, "needs numbers") {
+		t.Errorf("expected evaluation error on broken metric, got %+v", got[2])
+	}
+}
+
+func TestNewRejectsInvalidCustomMetric(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(&config.Config{
+		CustomMetrics: []config.CustomMetric{{Name: "Bad", Expr: "count(nope)"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), `custom metric "Bad"`) {
+		t.Errorf("expected compile error for custom metric, got %v", err)
+	}
+
+	_, err = New(&config.Config{
+		CustomMetrics: []config.CustomMetric{{Name: "Bad group", Expr: "count()", GroupBy: "nope"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unknown group_by field") {
+		t.Errorf("expected group_by error, got %v", err)
+	}
+}
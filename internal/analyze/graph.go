@@ -0,0 +1,175 @@
+package analyze
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// GraphNode is a person in the collaboration graph.
+type GraphNode struct {
+	Login    string `json:"login"`
+	Given    int    `json:"given"`    // Reviews this person gave
+	Received int    `json:"received"` // Reviews this person received
+}
+
+// GraphEdge is a weighted reviewer → author relationship.
+type GraphEdge struct {
+	From  string    `json:"from"` // Reviewer
+	To    string    `json:"to"`   // Author
+	Count int       `json:"count"`
+	Repos []string  `json:"repos"`
+	First time.Time `json:"first"`
+	Last  time.Time `json:"last"`
+}
+
+// CollabGraph is a weighted directed graph of review interactions.
+type CollabGraph struct {
+	Nodes []GraphNode `json:"nodes"` // Sorted by login
+	Edges []GraphEdge `json:"edges"` // Sorted by count descending
+}
+
+// BuildGraph builds the review graph from events.
+// self is the reviewer for reviewed events collected without a subject.
+// An interaction on the same PR between the same pair is counted once.
+func BuildGraph(events []data.Event, self string) CollabGraph {
+	type edgeKey struct{ from, to string }
+	edges := make(map[edgeKey]*GraphEdge)
+	repos := make(map[edgeKey]map[string]bool)
+	seen := make(map[string]bool)
+
+	add := func(from, to string, e data.Event) {
+		if from == "" || to == "" || from == to {
+			return
+		}
+		key := edgeKey{from, to}
+		if seen[from+"\x00"+to+"\x00"+e.URL] {
+			return
+		}
+		seen[from+"\x00"+to+"\x00"+e.URL] = true
+
+		at := interactionTime(e)
+		edge, ok := edges[key]
+		if !ok {
+			edge = &GraphEdge{From: from, To: to, First: at, Last: at}
+			edges[key] = edge
+			repos[key] = make(map[string]bool)
+		}
+		edge.Count++
+		if at.Before(edge.First) {
+			edge.First = at
+		}
+		if at.After(edge.Last) {
+			edge.Last = at
+		}
+		if e.Repo != "" {
+			repos[key][e.Repo] = true
+		}
+	}
+
+	for _, e := range events {
+		switch e.Action {
+		case data.EventActionReviewed:
+			reviewer := e.Subject
+			if reviewer == "" {
+				reviewer = self
+			}
+			add(reviewer, e.Author, e)
+		case data.EventActionMerged:
+			for _, reviewer := range e.Reviewers {
+				add(reviewer, e.Author, e)
+			}
+		}
+	}
+
+	nodes := make(map[string]*GraphNode)
+	node := func(login string) *GraphNode {
+		if n, ok := nodes[login]; ok {
+			return n
+		}
+		n := &GraphNode{Login: login}
+		nodes[login] = n
+		return n
+	}
+
+	var g CollabGraph
+	for key, edge := range edges {
+		for r := range repos[key] {
+			edge.Repos = append(edge.Repos, r)
+		}
+		sort.Strings(edge.Repos)
+		node(edge.From).Given += edge.Count
+		node(edge.To).Received += edge.Count
+		g.Edges = append(g.Edges, *edge)
+	}
+	for _, n := range nodes {
+		g.Nodes = append(g.Nodes, *n)
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].Login < g.Nodes[j].Login
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].Count != g.Edges[j].Count {
+			return g.Edges[i].Count > g.Edges[j].Count
+		}
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+
+	return g
+}
+
+// Filter returns the graph with only edges of at least minCount interactions
+// and the nodes they connect.
+func (g CollabGraph) Filter(minCount int) CollabGraph {
+	var out CollabGraph
+	keep := make(map[string]bool)
+	given := make(map[string]int)
+	received := make(map[string]int)
+	for _, e := range g.Edges {
+		if e.Count < minCount {
+			continue
+		}
+		out.Edges = append(out.Edges, e)
+		keep[e.From], keep[e.To] = true, true
+		given[e.From] += e.Count
+		received[e.To] += e.Count
+	}
+	for _, n := range g.Nodes {
+		if keep[n.Login] {
+			out.Nodes = append(out.Nodes, GraphNode{Login: n.Login, Given: given[n.Login], Received: received[n.Login]})
+		}
+	}
+	return out
+}
+
+// InferSelf guesses the collecting user's login as the most frequent author
+// of merged PRs and authored issues without a subject.
+func InferSelf(events []data.Event) string {
+	counts := make(map[string]int)
+	for _, e := range events {
+		if e.Subject == "" && e.Author != "" && (e.Action == data.EventActionMerged || e.Action == data.EventActionAuthored) {
+			counts[e.Author]++
+		}
+	}
+
+	best := ""
+	for login, c := range counts {
+		if c > counts[best] || (c == counts[best] && login < best) {
+			best = login
+		}
+	}
+	return best
+}
+
+// interactionTime returns when a review interaction happened
+func interactionTime(e data.Event) time.Time {
+	if e.Action == data.EventActionMerged && !e.Timestamps.ClosedAt.IsZero() {
+		return e.Timestamps.ClosedAt
+	}
+	return e.Timestamps.UpdatedAt
+}
@@ -0,0 +1,69 @@
+package analyze
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+func TestBuildGraph(t *testing.T) {
+	t.Parallel()
+
+	jan := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	events := []data.Event{
+		// I reviewed bob twice in two repos
+		{Action: data.EventActionReviewed, URL: "u1", Repo: "org/a", Author: "bob", Timestamps: data.Timestamps{UpdatedAt: jan}},
+		{Action: data.EventActionReviewed, URL: "u2", Repo: "org/b", Author: "bob", Timestamps: data.Timestamps{UpdatedAt: mar}},
+		// carol reviewed my PR, self-review ignored
+		{Action: data.EventActionMerged, URL: "u3", Repo: "org/a", Author: "me", Reviewers: []string{"carol", "me"}, Timestamps: data.Timestamps{ClosedAt: mar}},
+		// Same interaction seen from carol's side in team mode is not double counted
+		{Action: data.EventActionReviewed, URL: "u3", Repo: "org/a", Author: "me", Subject: "carol", Timestamps: data.Timestamps{UpdatedAt: mar}},
+	}
+
+	g := BuildGraph(events, "me")
+
+	wantEdges := []GraphEdge{
+		{From: "me", To: "bob", Count: 2, Repos: []string{"org/a", "org/b"}, First: jan, Last: mar},
+		{From: "carol", To: "me", Count: 1, Repos: []string{"org/a"}, First: mar, Last: mar},
+	}
+	if !reflect.DeepEqual(g.Edges, wantEdges) {
+		t.Errorf("expected edges %+v, got %+v", wantEdges, g.Edges)
+	}
+
+	wantNodes := []GraphNode{
+		{Login: "bob", Received: 2},
+		{Login: "carol", Given: 1},
+		{Login: "me", Given: 2, Received: 1},
+	}
+	if !reflect.DeepEqual(g.Nodes, wantNodes) {
+		t.Errorf("expected nodes %+v, got %+v", wantNodes, g.Nodes)
+	}
+
+	filtered := g.Filter(2)
+	if len(filtered.Edges) != 1 || len(filtered.Nodes) != 2 {
+		t.Errorf("expected 1 edge and 2 nodes after filtering, got %+v", filtered)
+	}
+}
+
+func TestInferSelf(t *testing.T) {
+	t.Parallel()
+
+	events := []data.Event{
+		{Action: data.EventActionMerged, Author: "me"},
+		{Action: data.EventActionAuthored, Author: "me"},
+		{Action: data.EventActionReviewed, Author: "bob"},
+		{Action: data.EventActionReviewed, Author: "bob"},
+		{Action: data.EventActionReviewed, Author: "bob"},
+	}
+
+	if got := InferSelf(events); got != "me" {
+		t.Errorf("expected me, got %s", got)
+	}
+	if got := InferSelf(nil); got != "" {
+		t.Errorf("expected empty login, got %s", got)
+	}
+}
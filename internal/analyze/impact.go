@@ -0,0 +1,169 @@
+package analyze
+
+import (
+	"sort"
+
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/ticket"
+)
+
+const defaultTopEvents = 10
+
+// defaultImpactTiers apply when the config defines none.
+var defaultImpactTiers = []config.ImpactTier{
+	{Name: "Powerhouse", Above: 500},
+	{Name: "Driver", Above: 200},
+	{Name: "Active", Above: 0},
+}
+
+// ImpactShare is the impact contributed by one action, theme, repo or month.
+type ImpactShare struct {
+	Name    string
+	Score   float64
+	Percent float64 // Share of the total ImpactScore
+}
+
+// EventImpact is the impact contributed by a single event.
+type EventImpact struct {
+	ID     string
+	Title  string
+	URL    string
+	Repo   string
+	Action data.EventAction
+	Theme  string
+	Weight float64 // Action weight, or the event's own impact (notes)
+	Factor float64 // Theme multiplier
+	Score  float64 // Weight * Factor
+}
+
+// ImpactBreakdown explains how ImpactScore was computed.
+type ImpactBreakdown struct {
+	ByAction  []ImpactShare // Sorted by score descending
+	ByTheme   []ImpactShare // Sorted by score descending
+	ByRepo    []ImpactShare // Sorted by score descending
+	ByMonth   []ImpactShare // Sorted chronologically (YYYY-MM)
+	TopEvents []EventImpact // Highest scoring events
+}
+
+// Score returns the theme and impact of a single event, as Analyze computes them.
+// Imported tickets are not activity and score nothing.
+func (a *Analyzer) Score(e data.Event) EventImpact {
+	if ticket.Is(e) {
+		return EventImpact{ID: e.ID, Title: e.Title, URL: e.URL, Action: e.Action}
+	}
+	return a.eventImpact(e, a.themeOf(e))
+}
+
+// eventImpact scores a single event given its theme.
+func (a *Analyzer) eventImpact(e data.Event, theme string) EventImpact {
+	// Action Weight (a note's own impact replaces it)
+	weight := e.Impact
+	if weight <= 0 {
+		weight = a.config.Metrics.ActionWeights[e.Action]
+	}
+	if weight == 0 {
+		weight = 1.0 // Default if unknown
+	}
+
+	// Theme Weight
+	multiplier := a.config.Metrics.ThemeWeights[theme]
+	if multiplier == 0 {
+		multiplier = 1.0
+	}
+
+	return EventImpact{
+		ID:     e.ID,
+		Title:  e.Title,
+		URL:    e.URL,
+		Repo:   e.Repo,
+		Action: e.Action,
+		Theme:  theme,
+		Weight: weight,
+		Factor: multiplier,
+		Score:  weight * multiplier,
+	}
+}
+
+// impactBreakdown aggregates per-event impacts into a breakdown.
+func (a *Analyzer) impactBreakdown(events []data.Event, impacts []EventImpact, total float64) ImpactBreakdown {
+	byAction := make(map[string]float64)
+	byTheme := make(map[string]float64)
+	byRepo := make(map[string]float64)
+	byMonth := make(map[string]float64)
+
+	for i, imp := range impacts {
+		byAction[string(imp.Action)] += imp.Score
+		byTheme[imp.Theme] += imp.Score
+		if imp.Repo != "" { // Notes may have no repository
+			byRepo[imp.Repo] += imp.Score
+		}
+		byMonth[events[i].Timestamps.UpdatedAt.Format("2006-01")] += imp.Score
+	}
+
+	top := make([]EventImpact, len(impacts))
+	copy(top, impacts)
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Score > top[j].Score
+	})
+	n := a.config.Metrics.TopEvents
+	if n <= 0 {
+		n = defaultTopEvents
+	}
+	if len(top) > n {
+		top = top[:n]
+	}
+
+	months := sortShares(byMonth, total)
+	sort.Slice(months, func(i, j int) bool {
+		return months[i].Name < months[j].Name
+	})
+
+	return ImpactBreakdown{
+		ByAction:  sortShares(byAction, total),
+		ByTheme:   sortShares(byTheme, total),
+		ByRepo:    sortShares(byRepo, total),
+		ByMonth:   months,
+		TopEvents: top,
+	}
+}
+
+// impactTier returns the name of the highest tier whose threshold the score exceeds.
+// Scores below every threshold fall into the lowest tier.
+func (a *Analyzer) impactTier(score float64) string {
+	tiers := a.config.Metrics.ImpactTiers
+	if len(tiers) == 0 {
+		tiers = defaultImpactTiers
+	}
+
+	sorted := make([]config.ImpactTier, len(tiers))
+	copy(sorted, tiers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Above > sorted[j].Above
+	})
+
+	for _, t := range sorted {
+		if score > t.Above {
+			return t.Name
+		}
+	}
+	return sorted[len(sorted)-1].Name
+}
+
+func sortShares(m map[string]float64, total float64) []ImpactShare {
+	s := make([]ImpactShare, 0, len(m))
+	for k, v := range m {
+		share := ImpactShare{Name: k, Score: v}
+		if total > 0 {
+			share.Percent = v / total * 100
+		}
+		s = append(s, share)
+	}
+	sort.Slice(s, func(i, j int) bool {
+		if s[i].Score != s[j].Score {
+			return s[i].Score > s[j].Score
+		}
+		return s[i].Name < s[j].Name
+	})
+	return s
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/ticket"
 )
 
 // TrendPoint represents activity count for a specific date.
@@ -21,19 +22,29 @@ type Metrics struct {
 	RepoStats     RepoStats
 	Theme         []Theme
 	Collaboration Collaboration
+	Tickets       []TicketSummary `yaml:",omitempty"` // Imported tickets the events mention, by impact
 
 	// Derived metrics
 	PeriodStart     time.Time
 	PeriodEnd       time.Time
 	ImpactScore     float64            // Sum of action weights * theme weights
+	ImpactTier      string             // Tier name from metrics.impact_tiers
+	Impact          ImpactBreakdown    // Where ImpactScore came from
 	Velocity        float64            // events per week
 	OwnershipCount  int                // Number of repos with >= ownership threshold
 	WeeklyTrend     []TrendPoint       // Number of events per week (ordered)
 	ContributionMix map[string]float64 // Percentage of events per theme
+
+	// Config-defined metrics
+	Custom []CustomMetric `yaml:",omitempty"`
 }
 
 // Analyze computes all advanced metrics and analysis from the given events.
+// Imported tickets are not activity; they only group the events that mention them.
+// Imported commits that belong to a collected PR are counted once, as the PR.
 func (a *Analyzer) Analyze(events []data.Event) Metrics {
+	events, tickets := ticket.Split(events)
+	events = DropCountedCommits(events)
 	if len(events) == 0 {
 		return Metrics{}
 	}
@@ -60,22 +71,15 @@ func (a *Analyzer) Analyze(events []data.Event) Metrics {
 
 	// Impact Score Calculation
 	var totalImpact float64
-	for _, e := range events {
-		// Action Weight
-		weight := a.config.Metrics.ActionWeights[e.Action]
-		if weight == 0 {
-			weight = 1.0 // Default if unknown
-		}
-
-		// Theme Weight
-		theme := themeMap[e.ID]
-		multiplier := a.config.Metrics.ThemeWeights[theme]
-		if multiplier == 0 {
-			multiplier = 1.0
-		}
-		totalImpact += weight * multiplier
+	impacts := make([]EventImpact, len(events))
+	for i, e := range events {
+		impacts[i] = a.eventImpact(e, themeMap[e.ID])
+		totalImpact += impacts[i].Score
 	}
 	report.ImpactScore = totalImpact
+	report.ImpactTier = a.impactTier(totalImpact)
+	report.Impact = a.impactBreakdown(events, impacts, totalImpact)
+	report.Tickets = a.ticketSummaries(ticket.NewIndex(tickets), events, impacts)
 
 	// Velocity and Trend Calculation
 	var minDate, maxDate time.Time
@@ -129,6 +133,9 @@ func (a *Analyzer) Analyze(events []data.Event) Metrics {
 	// Collaboration Graph (Who you work with)
 	report.Collaboration = a.collaboration(events)
 
+	// Custom Metrics
+	report.Custom = a.customMetrics(events, themeMap)
+
 	return report
 }
 
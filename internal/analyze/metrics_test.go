@@ -1,6 +1,7 @@
 package analyze
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -73,6 +74,104 @@ func TestAnalyze(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Impact breakdown",
+			events: []data.Event{
+				{ID: "1", Action: data.EventActionMerged, Repo: "org/a", Title: "feat: a", Timestamps: data.Timestamps{UpdatedAt: now}},
+				{ID: "2", Action: data.EventActionAuthored, Repo: "org/b", Title: "random", Timestamps: data.Timestamps{UpdatedAt: now}},
+				{ID: "3", Action: data.EventActionAuthored, Repo: "org/b", Title: "random", Timestamps: data.Timestamps{UpdatedAt: now}},
+			},
+			validate: func(t *testing.T, m Metrics) {
+				// merged: 10 * 2 = 20, authored: 2 * (5 * 1) = 10
+				if len(m.Impact.ByAction) != 2 {
+					t.Fatalf("expected 2 actions, got %v", m.Impact.ByAction)
+				}
+				merged := m.Impact.ByAction[0]
+				if merged.Name != "merged" || merged.Score != 20 || math.Abs(merged.Percent-66.67) > 0.01 {
+					t.Errorf("expected merged to contribute 20 (66.67%%), got %v", merged)
+				}
+				if len(m.Impact.ByRepo) != 2 || m.Impact.ByRepo[0].Name != "org/a" {
+					t.Errorf("expected org/a to lead by repo, got %v", m.Impact.ByRepo)
+				}
+				if len(m.Impact.ByMonth) != 1 || m.Impact.ByMonth[0].Score != 30 {
+					t.Errorf("expected single month with score 30, got %v", m.Impact.ByMonth)
+				}
+				if len(m.Impact.TopEvents) != 3 || m.Impact.TopEvents[0].ID != "1" {
+					t.Errorf("expected event 1 to be the top event, got %v", m.Impact.TopEvents)
+				}
+				if m.Impact.TopEvents[0].Weight != 10 || m.Impact.TopEvents[0].Factor != 2 {
+					t.Errorf("expected weight 10 and factor 2, got %v", m.Impact.TopEvents[0])
+				}
+			},
+		},
+		{
+			name: "Notes use their own impact and theme",
+			events: []data.Event{
+				{ID: "note:1", Kind: "note", Action: data.EventActionNoted, Title: "Led incident review", Theme: "Feature", Impact: 8, Timestamps: data.Timestamps{UpdatedAt: now}},
+				{ID: "note:2", Kind: "note", Action: data.EventActionNoted, Title: "Mentored interns", Timestamps: data.Timestamps{UpdatedAt: now}},
+			},
+			validate: func(t *testing.T, m Metrics) {
+				// (8 * 2) + (unweighted noted 1 * 1) = 17
+				if m.ImpactScore != 17.0 {
+					t.Errorf("expected impact score 17.0, got %f", m.ImpactScore)
+				}
+				if len(m.RepoStats.Summary) != 0 || len(m.Impact.ByRepo) != 0 {
+					t.Errorf("expected notes without a repo to be left out of repo stats, got %v and %v", m.RepoStats.Summary, m.Impact.ByRepo)
+				}
+			},
+		},
+		{
+			name: "Tickets group the events that mention them",
+			events: []data.Event{
+				{ID: "jira:ABC-1", Kind: "issue", Key: "ABC-1", Title: "feat: checkout", URL: "https://acme.atlassian.net/browse/ABC-1"},
+				{ID: "jira:ABC-2", Kind: "issue", Key: "ABC-2", Title: "Unworked ticket"},
+				{ID: "1", Action: data.EventActionMerged, Repo: "org/a", Title: "feat: pay button", Branch: "abc-1-pay", Timestamps: data.Timestamps{UpdatedAt: now}},
+				{ID: "2", Action: data.EventActionAuthored, Repo: "org/a", Title: "ABC-1 follow-up", Timestamps: data.Timestamps{UpdatedAt: now}},
+				{ID: "3", Action: data.EventActionAuthored, Repo: "org/a", Title: "random", Timestamps: data.Timestamps{UpdatedAt: now}},
+			},
+			validate: func(t *testing.T, m Metrics) {
+				// Tickets are not activity: (10 * 2) + (5 * 1) + (5 * 1) = 30
+				if m.ImpactScore != 30.0 {
+					t.Errorf("expected impact score 30.0, got %f", m.ImpactScore)
+				}
+				if len(m.Tickets) != 1 || m.Tickets[0].Key != "ABC-1" || m.Tickets[0].Score != 25 {
+					t.Fatalf("expected ABC-1 with score 25, got %v", m.Tickets)
+				}
+				if len(m.Tickets[0].Events) != 2 || m.Tickets[0].Events[0].ID != "1" {
+					t.Errorf("expected events 1 and 2 under ABC-1, got %v", m.Tickets[0].Events)
+				}
+			},
+		},
+		{
+			name: "Imported commits count per repository",
+			events: []data.Event{
+				{ID: "commit:a", Kind: "commit", Action: data.EventActionCommitted, Repo: "local/tool", Title: "fix: a", Timestamps: data.Timestamps{UpdatedAt: now}},
+				{ID: "commit:b", Kind: "commit", Action: data.EventActionCommitted, Repo: "local/tool", Title: "fix: b", Timestamps: data.Timestamps{UpdatedAt: now}},
+			},
+			validate: func(t *testing.T, m Metrics) {
+				if len(m.RepoStats.Summary) != 1 || m.RepoStats.Summary[0].Commits != 2 {
+					t.Errorf("expected 2 commits in local/tool, got %v", m.RepoStats.Summary)
+				}
+			},
+		},
+		{
+			name: "Imported commits already in a PR collected later are counted once",
+			events: []data.Event{
+				// Imported first, so import git could not skip them
+				{ID: "commit:a", Kind: "commit", Action: data.EventActionCommitted, Repo: "org/tool", Title: "fix: a", Commits: []string{"a"}, Timestamps: data.Timestamps{UpdatedAt: now}},
+				{ID: "commit:c", Kind: "commit", Action: data.EventActionCommitted, Repo: "org/tool", Title: "fix: c", Commits: []string{"c"}, Timestamps: data.Timestamps{UpdatedAt: now}},
+				// Collected afterwards with its commit SHAs
+				{ID: "pr:1", Kind: "prs", Action: data.EventActionMerged, Repo: "org/tool", Title: "fix: a and b", Commits: []string{"a", "b", "m"}, Timestamps: data.Timestamps{UpdatedAt: now}},
+			},
+			validate: func(t *testing.T, m Metrics) {
+				if len(m.RepoStats.Summary) != 1 || m.RepoStats.Summary[0].Merged != 1 || m.RepoStats.Summary[0].Commits != 1 {
+					t.Errorf("expected 1 merged PR and 1 commit in org/tool, got %v", m.RepoStats.Summary)
+				}
+				if len(m.WeeklyTrend) != 1 || m.WeeklyTrend[0].Count != 2 {
+					t.Errorf("expected 2 events counted, got %v", m.WeeklyTrend)
+				}
+			},
+		},
 		{
 			name: "Ownership count threshold",
 			events: []data.Event{
@@ -121,3 +220,41 @@ func TestAnalyze(t *testing.T) {
 		})
 	}
 }
+
+func TestImpactTier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tiers    []config.ImpactTier
+		score    float64
+		expected string
+	}{
+		{name: "Default top tier", score: 501, expected: "Powerhouse"},
+		{name: "Default threshold is exclusive", score: 500, expected: "Driver"},
+		{name: "Default floor", score: 0, expected: "Active"},
+		{
+			name:     "Configured tiers in any order",
+			tiers:    []config.ImpactTier{{Name: "Low", Above: 0}, {Name: "High", Above: 50}},
+			score:    60,
+			expected: "High",
+		},
+		{
+			name:     "Below every threshold",
+			tiers:    []config.ImpactTier{{Name: "Low", Above: 10}, {Name: "High", Above: 50}},
+			score:    5,
+			expected: "Low",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			analyzer, _ := New(&config.Config{Metrics: config.Metrics{ImpactTiers: tt.tiers}})
+			if got := analyzer.impactTier(tt.score); got != tt.expected {
+				t.Errorf("expected tier %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ type RepoSummary struct {
 	Merged   int
 	Issues   int
 	Reviewed int
+	Commits  int // Imported with gh brag import git
 }
 
 type RepoStats struct {
@@ -21,9 +22,13 @@ func (a *Analyzer) repoStats(events []data.Event) RepoStats {
 	issues := make(map[string]int)
 	merged := make(map[string]int)
 	reviewed := make(map[string]int)
+	commits := make(map[string]int)
 	summary := make(map[string]RepoSummary)
 
 	for _, e := range events {
+		if e.Repo == "" { // Notes may have no repository
+			continue
+		}
 		if e.Action == data.EventActionMerged {
 			merged[e.Repo]++
 		}
@@ -33,11 +38,15 @@ func (a *Analyzer) repoStats(events []data.Event) RepoStats {
 		if e.Action == data.EventActionAuthored {
 			issues[e.Repo]++
 		}
+		if e.Action == data.EventActionCommitted {
+			commits[e.Repo]++
+		}
 		summary[e.Repo] = RepoSummary{
 			Name:     e.Repo,
 			Merged:   merged[e.Repo],
 			Issues:   issues[e.Repo],
 			Reviewed: reviewed[e.Repo],
+			Commits:  commits[e.Repo],
 		}
 	}
 
@@ -47,7 +56,7 @@ func (a *Analyzer) repoStats(events []data.Event) RepoStats {
 }
 
 func sortSummary(m map[string]RepoSummary) []RepoSummary {
-	// merged desc, issues desc, reviewed desc
+	// merged desc, issues desc, reviewed desc, commits desc
 	var s []RepoSummary
 	for _, v := range m {
 		s = append(s, v)
@@ -59,7 +68,10 @@ func sortSummary(m map[string]RepoSummary) []RepoSummary {
 		if s[i].Issues != s[j].Issues {
 			return s[i].Issues > s[j].Issues
 		}
-		return s[i].Reviewed > s[j].Reviewed
+		if s[i].Reviewed != s[j].Reviewed {
+			return s[i].Reviewed > s[j].Reviewed
+		}
+		return s[i].Commits > s[j].Commits
 	})
 	return s
 }
@@ -0,0 +1,46 @@
+package analyze
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// MemberMetrics are the metrics of one team member.
+type MemberMetrics struct {
+	Login   string
+	Metrics Metrics
+}
+
+// TeamMetrics are per-member metrics plus a rollup over the whole team.
+type TeamMetrics struct {
+	Members []MemberMetrics // Sorted by login
+	Rollup  Metrics
+}
+
+// AnalyzeTeam partitions events by subject and analyzes each member separately.
+// Events without a subject are only counted in the rollup.
+func (a *Analyzer) AnalyzeTeam(events []data.Event) TeamMetrics {
+	bySubject := make(map[string][]data.Event)
+	for _, e := range events {
+		if e.Subject != "" {
+			bySubject[e.Subject] = append(bySubject[e.Subject], e)
+		}
+	}
+
+	report := TeamMetrics{
+		Rollup: a.Analyze(events),
+	}
+	for login, memberEvents := range bySubject {
+		report.Members = append(report.Members, MemberMetrics{
+			Login:   login,
+			Metrics: a.Analyze(memberEvents),
+		})
+	}
+	sort.Slice(report.Members, func(i, j int) bool {
+		return strings.ToLower(report.Members[i].Login) < strings.ToLower(report.Members[j].Login)
+	})
+
+	return report
+}
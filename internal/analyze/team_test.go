@@ -0,0 +1,41 @@
+package analyze
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+func TestAnalyzeTeam(t *testing.T) {
+	t.Parallel()
+
+	analyzer, _ := New(&config.Config{})
+
+	now := time.Now()
+	events := []data.Event{
+		{ID: "1@bob", Action: data.EventActionMerged, Repo: "org/a", Author: "bob", Subject: "bob", Timestamps: data.Timestamps{UpdatedAt: now}},
+		{ID: "2@alice", Action: data.EventActionMerged, Repo: "org/a", Author: "alice", Subject: "alice", Timestamps: data.Timestamps{UpdatedAt: now}},
+		{ID: "1@alice", Action: data.EventActionReviewed, Repo: "org/a", Author: "bob", Subject: "alice", Timestamps: data.Timestamps{UpdatedAt: now}},
+		{ID: "3", Action: data.EventActionMerged, Repo: "org/b", Author: "me", Timestamps: data.Timestamps{UpdatedAt: now}},
+	}
+
+	got := analyzer.AnalyzeTeam(events)
+
+	if len(got.Members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(got.Members))
+	}
+	if got.Members[0].Login != "alice" || got.Members[1].Login != "bob" {
+		t.Errorf("expected members sorted by login, got %s, %s", got.Members[0].Login, got.Members[1].Login)
+	}
+	if got.Members[0].Metrics.ImpactScore != 2 {
+		t.Errorf("expected alice to have 2 events worth of impact, got %f", got.Members[0].Metrics.ImpactScore)
+	}
+	if len(got.Members[0].Metrics.Collaboration.Reviewees) != 1 || got.Members[0].Metrics.Collaboration.Reviewees[0].Login != "bob" {
+		t.Errorf("expected alice to have reviewed bob, got %v", got.Members[0].Metrics.Collaboration.Reviewees)
+	}
+	if got.Rollup.ImpactScore != 4 {
+		t.Errorf("expected rollup over all 4 events, got %f", got.Rollup.ImpactScore)
+	}
+}
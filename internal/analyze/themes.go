@@ -5,8 +5,12 @@ import (
 	"strings"
 
 	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/ticket"
 )
 
+// OtherTheme is the cluster for events no theme matched
+const OtherTheme = "Other"
+
 type Theme struct {
 	Name  string
 	Count int
@@ -18,52 +22,8 @@ func (a *Analyzer) theme(events []data.Event) []Theme {
 	clusters := make(map[string][]data.Event)
 
 	for _, e := range events {
-		title := strings.ToLower(e.Title)
-		matched := false
-		// 1. Label Search (Highest Priority)
-	LabelLoop:
-		for _, label := range e.Labels {
-			labelLower := strings.ToLower(label)
-			for _, tm := range a.config.Themes {
-				for _, k := range tm.Keywords {
-					if strings.Contains(labelLower, strings.ToLower(k)) {
-						clusters[tm.Name] = append(clusters[tm.Name], e)
-						matched = true
-						break LabelLoop
-					}
-				}
-			}
-		}
-
-		// 2. Title Search (Secondary Fallback - First Appearance)
-		if !matched {
-			bestIndex := -1
-			var bestTheme string
-
-			for _, tm := range a.config.Themes {
-				for _, k := range tm.Keywords {
-					idx := strings.Index(title, strings.ToLower(k))
-					if idx != -1 {
-						if bestIndex == -1 || idx < bestIndex {
-							bestIndex = idx
-							bestTheme = tm.Name
-						}
-						if idx == 0 {
-							break // Can't get better than the start
-						}
-					}
-				}
-			}
-
-			if bestTheme != "" {
-				clusters[bestTheme] = append(clusters[bestTheme], e)
-				matched = true
-			}
-		}
-
-		if !matched {
-			clusters["Other"] = append(clusters["Other"], e)
-		}
+		name := a.themeOf(e)
+		clusters[name] = append(clusters[name], e)
 	}
 
 	var themes []Theme
@@ -77,3 +37,94 @@ func (a *Analyzer) theme(events []data.Event) []Theme {
 
 	return themes
 }
+
+// themeOf returns the theme for a single event.
+// A theme set by hand wins, then keyword matches; otherwise a cached LLM
+// classification is used if confident enough.
+func (a *Analyzer) themeOf(e data.Event) string {
+	if name := a.explicitTheme(e); name != "" {
+		return name
+	}
+	if name := a.matchTheme(e); name != "" {
+		return name
+	}
+
+	// 3. Cached LLM Classification (Last Resort)
+	if c := e.Classification; c != nil && c.Theme != "" && c.Confidence >= a.config.Classification.MinConfidence {
+		for _, tm := range a.config.Themes {
+			if tm.Name == c.Theme {
+				return tm.Name
+			}
+		}
+	}
+
+	return OtherTheme
+}
+
+// explicitTheme returns the configured theme set by hand on the event, such as
+// a note's --theme, or "" if none is set or it is no longer configured.
+func (a *Analyzer) explicitTheme(e data.Event) string {
+	if e.Theme == "" {
+		return ""
+	}
+	for _, tm := range a.config.Themes {
+		if strings.EqualFold(tm.Name, e.Theme) {
+			return tm.Name
+		}
+	}
+	return ""
+}
+
+// matchTheme returns the theme whose keywords match the event, or "" if none do.
+func (a *Analyzer) matchTheme(e data.Event) string {
+	// 1. Label Search (Highest Priority)
+	for _, label := range e.Labels {
+		labelLower := strings.ToLower(label)
+		for _, tm := range a.config.Themes {
+			for _, k := range tm.Keywords {
+				if strings.Contains(labelLower, strings.ToLower(k)) {
+					return tm.Name
+				}
+			}
+		}
+	}
+
+	// 2. Title Search (Secondary Fallback - First Appearance)
+	title := strings.ToLower(e.Title)
+	bestIndex := -1
+	var bestTheme string
+
+	for _, tm := range a.config.Themes {
+		for _, k := range tm.Keywords {
+			idx := strings.Index(title, strings.ToLower(k))
+			if idx != -1 {
+				if bestIndex == -1 || idx < bestIndex {
+					bestIndex = idx
+					bestTheme = tm.Name
+				}
+				if idx == 0 {
+					break // Can't get better than the start
+				}
+			}
+		}
+	}
+
+	return bestTheme
+}
+
+// Unmatched returns the indexes of events no theme keyword matches and that have no theme set by hand.
+// Events with a cached classification are skipped unless includeClassified is set, and imported
+// tickets are never themed.
+func (a *Analyzer) Unmatched(events []data.Event, includeClassified bool) []int {
+	var idx []int
+	for i, e := range events {
+		if ticket.Is(e) || a.explicitTheme(e) != "" || a.matchTheme(e) != "" {
+			continue
+		}
+		if e.Classification != nil && !includeClassified {
+			continue
+		}
+		idx = append(idx, i)
+	}
+	return idx
+}
@@ -16,6 +16,7 @@ func TestTheme(t *testing.T) {
 			{Name: "Feature", Keywords: []string{"feat", "feature"}},
 			{Name: "Bug Fix", Keywords: []string{"fix", "bug"}},
 		},
+		Classification: config.Classification{MinConfidence: 0.5},
 	}
 	analyzer, _ := New(cfg)
 
@@ -109,6 +110,71 @@ func TestTheme(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Cached classification for unmatched event",
+			events: []data.Event{
+				{ID: "5", Title: "random work", Classification: &data.Classification{Theme: "Feature", Confidence: 0.9}},
+			},
+			expected: []Theme{
+				{
+					Name:  "Feature",
+					Count: 1,
+					Items: []data.Event{{ID: "5", Title: "random work", Classification: &data.Classification{Theme: "Feature", Confidence: 0.9}}},
+				},
+			},
+		},
+		{
+			name: "Low confidence classification stays Other",
+			events: []data.Event{
+				{ID: "6", Title: "random work", Classification: &data.Classification{Theme: "Feature", Confidence: 0.2}},
+			},
+			expected: []Theme{
+				{
+					Name:  "Other",
+					Count: 1,
+					Items: []data.Event{{ID: "6", Title: "random work", Classification: &data.Classification{Theme: "Feature", Confidence: 0.2}}},
+				},
+			},
+		},
+		{
+			name: "Keyword match wins over classification",
+			events: []data.Event{
+				{ID: "7", Title: "fix crash", Classification: &data.Classification{Theme: "Feature", Confidence: 0.9}},
+			},
+			expected: []Theme{
+				{
+					Name:  "Bug Fix",
+					Count: 1,
+					Items: []data.Event{{ID: "7", Title: "fix crash", Classification: &data.Classification{Theme: "Feature", Confidence: 0.9}}},
+				},
+			},
+		},
+		{
+			name: "Theme set by hand wins over keywords",
+			events: []data.Event{
+				{ID: "8", Title: "fix the on-call rota", Theme: "feature"},
+			},
+			expected: []Theme{
+				{
+					Name:  "Feature",
+					Count: 1,
+					Items: []data.Event{{ID: "8", Title: "fix the on-call rota", Theme: "feature"}},
+				},
+			},
+		},
+		{
+			name: "Unconfigured theme set by hand falls back to keywords",
+			events: []data.Event{
+				{ID: "9", Title: "fix the on-call rota", Theme: "Ops"},
+			},
+			expected: []Theme{
+				{
+					Name:  "Bug Fix",
+					Count: 1,
+					Items: []data.Event{{ID: "9", Title: "fix the on-call rota", Theme: "Ops"}},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -135,3 +201,29 @@ func TestTheme(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmatched(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		Themes: []config.Theme{
+			{Name: "Feature", Keywords: []string{"feat"}},
+		},
+	}
+	analyzer, _ := New(cfg)
+
+	events := []data.Event{
+		{ID: "1", Title: "feat: matched"},
+		{ID: "2", Title: "unmatched"},
+		{ID: "3", Title: "classified", Classification: &data.Classification{Theme: "Feature", Confidence: 1}},
+		{ID: "4", Title: "mentored interns", Theme: "Feature"},
+		{ID: "jira:ABC-1", Title: "imported ticket", Key: "ABC-1"},
+	}
+
+	if got := analyzer.Unmatched(events, false); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("expected [1], got %v", got)
+	}
+	if got := analyzer.Unmatched(events, true); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("expected [1 2], got %v", got)
+	}
+}
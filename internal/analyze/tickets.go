@@ -0,0 +1,53 @@
+package analyze
+
+import (
+	"sort"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/ticket"
+)
+
+// TicketSummary is an imported Jira or Linear ticket with the events that mention its key.
+type TicketSummary struct {
+	Key    string
+	Title  string
+	URL    string
+	Score  float64       // Impact of the linked events
+	Events []EventImpact // Linked PRs and commits, highest score first
+}
+
+// ticketSummaries groups events under the tickets whose keys they mention.
+// Tickets no event mentions are left out.
+func (a *Analyzer) ticketSummaries(idx ticket.Index, events []data.Event, impacts []EventImpact) []TicketSummary {
+	if len(idx) == 0 {
+		return nil
+	}
+
+	byKey := make(map[string]*TicketSummary)
+	for i, e := range events {
+		for _, t := range idx.Linked(e) {
+			s, ok := byKey[t.Key]
+			if !ok {
+				s = &TicketSummary{Key: t.Key, Title: t.Title, URL: t.URL}
+				byKey[t.Key] = s
+			}
+			s.Score += impacts[i].Score
+			s.Events = append(s.Events, impacts[i])
+		}
+	}
+
+	summaries := make([]TicketSummary, 0, len(byKey))
+	for _, s := range byKey {
+		sort.SliceStable(s.Events, func(i, j int) bool {
+			return s.Events[i].Score > s.Events[j].Score
+		})
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Score != summaries[j].Score {
+			return summaries[i].Score > summaries[j].Score
+		}
+		return summaries[i].Key < summaries[j].Key
+	})
+	return summaries
+}
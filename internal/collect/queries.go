@@ -0,0 +1,78 @@
+package collect
+
+import (
+	"fmt"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/scope"
+)
+
+// Options scopes the searches run by collect
+type Options struct {
+	User    string // GitHub login or @me
+	From    string // YYYY-MM-DD
+	To      string // YYYY-MM-DD
+	Include string // all, prs, issues, reviews
+	Owner   string // Optional user or org filter
+	Repo    string // Optional owner/repo filter
+	Scope   scope.Scope
+	Commits bool // Record the commit SHAs of merged PRs, to match commits imported with import git
+}
+
+// Search is a single GitHub search to run
+type Search struct {
+	Label   string // Human readable name, e.g. "merged PRs"
+	Kind    string // prs, issues
+	Action  data.EventAction
+	Query   string
+	Commits bool // Fetch the SHAs of each PR's commits
+}
+
+// Searches returns the searches for the included activity types,
+// one per org or repo of opts.Scope
+func Searches(opts Options) []Search {
+	var searches []Search
+
+	// Helper to add a search, narrowed by the filters
+	add := func(label, kind string, action data.EventAction, baseQuery string, commits bool) {
+		q := baseQuery
+		if opts.Owner != "" {
+			q += fmt.Sprintf(" user:%s", opts.Owner)
+		}
+		if opts.Repo != "" {
+			q += fmt.Sprintf(" repo:%s", opts.Repo)
+		}
+		for _, query := range opts.Scope.Queries(q) {
+			searches = append(searches, Search{Label: label, Kind: kind, Action: action, Query: query, Commits: commits})
+		}
+	}
+
+	// 1. Authored PRs
+	if opts.Include == "all" || opts.Include == "prs" {
+		add("merged PRs", "prs", data.EventActionMerged,
+			fmt.Sprintf("author:%s is:pr is:merged merged:%s..%s", opts.User, opts.From, opts.To), opts.Commits)
+	}
+
+	// 2. Authored Issues
+	if opts.Include == "all" || opts.Include == "issues" {
+		add("authored Issues", "issues", data.EventActionAuthored,
+			fmt.Sprintf("author:%s is:issue created:%s..%s", opts.User, opts.From, opts.To), false)
+	}
+
+	// 3. Reviewed PRs
+	if opts.Include == "all" || opts.Include == "reviews" {
+		add("reviewed PRs", "prs", data.EventActionReviewed,
+			fmt.Sprintf("is:pr reviewed-by:%s updated:%s..%s -author:%s", opts.User, opts.From, opts.To, opts.User), false)
+	}
+
+	return searches
+}
+
+// TagSubject marks events as the activity of subject (team mode).
+// The subject is appended to each ID so teammates reviewing the same PR don't collide.
+func TagSubject(events []data.Event, subject string) {
+	for i := range events {
+		events[i].Subject = subject
+		events[i].ID = fmt.Sprintf("%s@%s", events[i].ID, subject)
+	}
+}
@@ -0,0 +1,59 @@
+package collect
+
+import (
+	"testing"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearches(t *testing.T) {
+	opts := Options{User: "alice", From: "2026-01-01", To: "2026-06-30", Include: "all", Owner: "acme"}
+
+	searches := Searches(opts)
+	require.Len(t, searches, 3)
+	assert.Equal(t, "author:alice is:pr is:merged merged:2026-01-01..2026-06-30 user:acme", searches[0].Query)
+	assert.Equal(t, data.EventActionAuthored, searches[1].Action)
+	assert.False(t, searches[0].Commits, "commit SHAs are opt-in")
+	assert.Equal(t, "is:pr reviewed-by:alice updated:2026-01-01..2026-06-30 -author:alice user:acme", searches[2].Query)
+
+	opts.Commits = true
+	searches = Searches(opts)
+	assert.True(t, searches[0].Commits, "merged PRs")
+	assert.False(t, searches[1].Commits)
+	assert.False(t, searches[2].Commits, "reviewed PRs are someone else's commits")
+
+	opts.Include = "reviews"
+	opts.Owner = ""
+	opts.Repo = "acme/api"
+	searches = Searches(opts)
+	require.Len(t, searches, 1)
+	assert.Equal(t, "is:pr reviewed-by:alice updated:2026-01-01..2026-06-30 -author:alice repo:acme/api", searches[0].Query)
+}
+
+func TestSearches_Scope(t *testing.T) {
+	opts := Options{
+		User:    "@me",
+		From:    "2026-01-01",
+		To:      "2026-06-30",
+		Include: "prs",
+		Scope:   scope.Scope{Orgs: []string{"acme"}, Repos: []string{"cli/cli"}, ExcludeRepos: []string{"acme/secret"}},
+	}
+
+	searches := Searches(opts)
+	require.Len(t, searches, 2)
+	assert.Equal(t, "author:@me is:pr is:merged merged:2026-01-01..2026-06-30 org:acme -repo:acme/secret", searches[0].Query)
+	assert.Equal(t, "author:@me is:pr is:merged merged:2026-01-01..2026-06-30 repo:cli/cli -repo:acme/secret", searches[1].Query)
+	assert.Equal(t, "merged PRs", searches[1].Label)
+}
+
+func TestTagSubject(t *testing.T) {
+	events := []data.Event{{ID: "pr:https://github.com/acme/api/pull/1:reviewed"}}
+
+	TagSubject(events, "bob")
+
+	assert.Equal(t, "bob", events[0].Subject)
+	assert.Equal(t, "pr:https://github.com/acme/api/pull/1:reviewed@bob", events[0].ID)
+}
@@ -8,8 +8,13 @@ import (
 	"github.com/jackchuka/gh-brag/internal/github"
 )
 
-func RunSearch(kind string, action data.EventAction, query string) ([]data.Event, error) {
-	nodes, err := github.RunSearch(query, github.QueryBasic)
+// RunSearch runs a search and converts its results to events
+func RunSearch(s Search) ([]data.Event, error) {
+	queryType := github.QueryBasic
+	if s.Commits {
+		queryType = github.QueryWithCommits
+	}
+	nodes, err := github.RunSearch(s.Query, queryType)
 	if err != nil {
 		return nil, err
 	}
@@ -19,13 +24,13 @@ func RunSearch(kind string, action data.EventAction, query string) ([]data.Event
 
 	// Map Kind to ID Prefix (prs -> pr, issues -> issue)
 	var idPrefix string
-	switch kind {
+	switch s.Kind {
 	case "prs":
 		idPrefix = "pr"
 	case "issues":
 		idPrefix = "issue"
 	default:
-		idPrefix = kind
+		idPrefix = s.Kind
 	}
 
 	for _, n := range nodes {
@@ -48,20 +53,26 @@ func RunSearch(kind string, action data.EventAction, query string) ([]data.Event
 		}
 
 		// Generate ID: prefix:url:action
-		id := fmt.Sprintf("%s:%s:%s", idPrefix, n.URL, action)
+		id := fmt.Sprintf("%s:%s:%s", idPrefix, n.URL, s.Action)
 
 		evt := data.Event{
 			ID:        id,
-			Action:    action,
-			Kind:      kind,
+			Action:    s.Action,
+			Kind:      s.Kind,
 			URL:       n.URL,
 			Repo:      n.Repository.NameWithOwner,
+			Private:   n.Repository.IsPrivate,
+			Fork:      n.Repository.IsFork,
+			Archived:  n.Repository.IsArchived,
 			Number:    n.Number,
 			Title:     n.Title,
 			Body:      n.Body,
 			Author:    n.Author.Login,
+			AuthorBot: n.Author.Typename == "Bot",
 			Labels:    labels,
 			Reviewers: reviewers,
+			Branch:    n.HeadRef,
+			Commits:   n.CommitSHAs(),
 			Timestamps: data.Timestamps{
 				CreatedAt: n.CreatedAt,
 				UpdatedAt: n.UpdatedAt,
@@ -69,7 +80,7 @@ func RunSearch(kind string, action data.EventAction, query string) ([]data.Event
 			},
 			Source: data.Source{
 				Tool:      "gh api graphql",
-				Query:     query,
+				Query:     s.Query,
 				FetchedAt: fetchedAt,
 			},
 		}
@@ -3,9 +3,15 @@ package config
 import (
 	"embed"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/redact"
+	"github.com/jackchuka/gh-brag/internal/rules"
+	"github.com/jackchuka/gh-brag/internal/scope"
 	"gopkg.in/yaml.v3"
 )
 
@@ -18,6 +24,13 @@ var defaultConfigFS embed.FS
 type Theme struct {
 	Name     string   `yaml:"name"`
 	Keywords []string `yaml:"keywords"`
+	Remove   bool     `yaml:"remove,omitempty"` // Drop the same-named theme from earlier layers
+}
+
+// ImpactTier names a band of impact scores.
+type ImpactTier struct {
+	Name  string  `yaml:"name"`
+	Above float64 `yaml:"above"` // Scores strictly above this reach the tier
 }
 
 // Metrics defines the metrics configuration.
@@ -25,44 +38,243 @@ type Metrics struct {
 	OwnershipThreshold int                          `yaml:"ownership_threshold"`
 	ActionWeights      map[data.EventAction]float64 `yaml:"action_weights"`
 	ThemeWeights       map[string]float64           `yaml:"theme_weights"`
+	ImpactTiers        []ImpactTier                 `yaml:"impact_tiers"`
+	TopEvents          int                          `yaml:"top_events"` // Events listed in the impact breakdown
+}
+
+// LLM defines the model endpoint used for summarization and classification.
+type LLM struct {
+	Endpoint  string `yaml:"endpoint"`    // OpenAI-compatible chat completions URL (empty uses GitHub Models)
+	Model     string `yaml:"model"`       // Model name passed to the endpoint
+	APIKeyEnv string `yaml:"api_key_env"` // Environment variable holding the API key for custom endpoints
+
+	MaxPromptTokens int `yaml:"max_prompt_tokens"` // Summary prompt budget (0 estimates it from the model's context window)
+}
+
+// Classification defines the LLM theme classification pass for unmatched events.
+type Classification struct {
+	BatchSize     int     `yaml:"batch_size"`     // Events sent per request
+	MinConfidence float64 `yaml:"min_confidence"` // Cached classifications below this are treated as "Other"
+}
+
+// CustomMetric defines a team-specific metric as an expression over event fields,
+// e.g. count(action == "merged" && repo =~ "platform/.*").
+type CustomMetric struct {
+	Name      string `yaml:"name"`
+	Expr      string `yaml:"expr"`
+	GroupBy   string `yaml:"group_by"`  // Optional event field to break the metric down by
+	Unit      string `yaml:"unit"`      // Optional label shown next to the value
+	Dashboard bool   `yaml:"dashboard"` // Show as a KPI card on the dashboard
+}
+
+// Sprint defines the team's sprint cadence for `daily --period sprint`.
+type Sprint struct {
+	Anchor     string `yaml:"anchor"`      // YYYY-MM-DD on which any sprint started
+	LengthDays int    `yaml:"length_days"` // Sprint length in days
+}
+
+// Post holds the webhook URLs for daily --post.
+// GH_BRAG_<TARGET>_WEBHOOK (GH_BRAG_WEBHOOK_URL for webhook) overrides each URL.
+type Post struct {
+	Slack    string `yaml:"slack"`
+	Teams    string `yaml:"teams"`
+	Discord  string `yaml:"discord"`
+	Webhook  string `yaml:"webhook"`  // Receives the report as JSON
+	Attempts int    `yaml:"attempts"` // Tries per message before giving up
+}
+
+// URL returns the configured URL for a --post target
+func (p Post) URL(target string) string {
+	switch target {
+	case "slack":
+		return p.Slack
+	case "teams":
+		return p.Teams
+	case "discord":
+		return p.Discord
+	case "webhook":
+		return p.Webhook
+	}
+	return ""
+}
+
+// Summary defines how `daily --summarize` prompts the model.
+type Summary struct {
+	SystemPrompt string `yaml:"system_prompt"` // Go template file replacing the built-in system prompt
+	UserPrompt   string `yaml:"user_prompt"`   // Go template file replacing the built-in user prompt
+	Structured   bool   `yaml:"structured"`    // Request JSON themes with source URLs and drop unsourced bullets
+}
+
+// Daily defines defaults for the daily report.
+type Daily struct {
+	WeekStart string   `yaml:"week_start"` // First day of the week (monday, sunday, ...)
+	WorkDays  []string `yaml:"work_days"`  // Working weekdays for the default range
+	Holidays  string   `yaml:"holidays"`   // Path to an .ics or .yaml holiday list
+	Sprint    Sprint   `yaml:"sprint"`
+	Template  string   `yaml:"template"` // Default --template path
+	Post      Post     `yaml:"post"`
+	Summary   Summary  `yaml:"summary"`
+	Orgs      []string `yaml:"orgs"`       // Default --org filter
+	TZ        string   `yaml:"tz"`         // Default --tz (IANA name)
+	StateFile string   `yaml:"state_file"` // Where --since-last remembers the last report (empty uses the XDG state dir)
+}
+
+// Redaction controls what is removed from titles and bodies before they are sent to an LLM.
+type Redaction struct {
+	Secrets          bool          `yaml:"secrets"`            // Mask tokens, keys and emails
+	Rules            []redact.Rule `yaml:"rules"`              // Extra regex rules
+	Allow            []string      `yaml:"allow"`              // Orgs or repos that may be sent (empty allows all)
+	Deny             []string      `yaml:"deny"`               // Orgs or repos that are never sent
+	PrivateTitleOnly bool          `yaml:"private_title_only"` // Send only titles from private repos
+}
+
+// Redactor compiles the redaction settings
+func (r Redaction) Redactor() (*redact.Redactor, error) {
+	return redact.New(redact.Options{
+		Rules:            r.Rules,
+		Secrets:          r.Secrets,
+		Allow:            r.Allow,
+		Deny:             r.Deny,
+		PrivateTitleOnly: r.PrivateTitleOnly,
+	})
+}
+
+// Rules drop noise such as bot PRs, forks and archived repos from collect, analyze and daily.
+type Rules struct {
+	Exclude   []rules.Rule `yaml:"exclude"`
+	Include   []rules.Rule `yaml:"include"`    // Keep events an exclude rule matches
+	OnCollect bool         `yaml:"on_collect"` // Also drop excluded events when collecting, so they're never stored
+}
+
+// Set compiles the rules
+func (r Rules) Set() (*rules.Set, error) {
+	return rules.New(r.Exclude, r.Include)
+}
+
+// Profile is a named bundle of hosts, filters and a store path, selected with --profile.
+// A profile may also set any other config key (themes, metrics, llm, ...); those
+// settings are applied over the layered config when the profile is active.
+type Profile struct {
+	Hosts        []string `yaml:"hosts,omitempty"`         // GitHub hosts to search (empty uses gh's default host)
+	Orgs         []string `yaml:"orgs,omitempty"`          // Only activity in these orgs
+	Repos        []string `yaml:"repos,omitempty"`         // Only activity in these repos (owner/name)
+	ExcludeOrgs  []string `yaml:"exclude_orgs,omitempty"`  // Never activity in these orgs
+	ExcludeRepos []string `yaml:"exclude_repos,omitempty"` // Never activity in these repos
+	Store        string   `yaml:"store,omitempty"`         // Events file for collect, analyze, visualize and classify
+
+	settings []*yaml.Node // Other keys from each layer defining the profile, in order
+}
+
+// Scope returns the profile's org and repo filters
+func (p Profile) Scope() scope.Scope {
+	return scope.Scope{
+		Orgs:         p.Orgs,
+		Repos:        p.Repos,
+		ExcludeOrgs:  p.ExcludeOrgs,
+		ExcludeRepos: p.ExcludeRepos,
+	}
 }
 
 // Config represents the global configuration for gh-brag.
 type Config struct {
-	Themes  []Theme `yaml:"themes"`
-	Metrics Metrics `yaml:"metrics"`
+	Themes         []Theme        `yaml:"themes"`
+	ThemesMerge    string         `yaml:"themes_merge,omitempty"` // How a file's themes combine with earlier layers: patch, append or replace
+	Metrics        Metrics        `yaml:"metrics"`
+	CustomMetrics  []CustomMetric `yaml:"custom_metrics"`
+	Daily          Daily          `yaml:"daily"`
+	LLM            LLM            `yaml:"llm"`
+	Redaction      Redaction      `yaml:"redaction"`
+	Rules          Rules          `yaml:"rules"`
+	Classification Classification `yaml:"classification"`
+
+	Profile  string              `yaml:"profile,omitempty"`  // Profile used without --profile; after loading, the active profile
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"` // Named profiles
+
+	Sources []string `yaml:"-"` // Files and GH_BRAG_* variables applied over the defaults, in order
 }
 
-// LoadConfig loads the configuration. It starts with embedded defaults
-// and overlays them with the provided YAML file if it exists.
-func LoadConfig(path string) (*Config, error) {
-	// 1. Start with embedded defaults
+// DefaultYAML returns the annotated built-in configuration
+func DefaultYAML() []byte {
 	data, err := defaultConfigFS.ReadFile(defaultConfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read embedded config: %w", err)
+		panic(fmt.Sprintf("embedded config missing: %v", err))
 	}
+	return data
+}
 
+// ActiveProfile returns the profile selected when loading, or an empty profile
+func (c *Config) ActiveProfile() Profile {
+	if p := c.Profiles[c.Profile]; p != nil {
+		return *p
+	}
+	return Profile{}
+}
+
+// LoadConfig loads the configuration. It starts with embedded defaults and
+// overlays, in order, the user file, the project file (see Files), the file at
+// path, the settings of the profile (falling back to GH_BRAG_PROFILE, then the
+// profile key), and GH_BRAG_* environment variables. Unknown keys are rejected
+// and the result is checked with Validate.
+func LoadConfig(path, profile string) (*Config, error) {
 	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	if err := decodeLayer(DefaultYAML(), &cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal embedded config: %w", err)
 	}
 
-	// 2. Overlay with user config if provided
-	if path == "" {
-		return &cfg, nil
+	for _, file := range Files(path) {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("config file %s does not exist", file)
+			}
+			return nil, err
+		}
+		if err := decodeLayer(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", file, err)
+		}
+		cfg.Sources = append(cfg.Sources, file)
 	}
 
-	userData, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("config file %s does not exist", path)
+	if profile == "" {
+		profile = os.Getenv(EnvName("profile"))
+	}
+	if profile == "" {
+		profile = cfg.Profile
+	}
+	if profile != "" {
+		if err := cfg.useProfile(profile); err != nil {
+			return nil, err
 		}
-		return nil, err
 	}
 
-	if err := yaml.Unmarshal(userData, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal user config: %w", err)
+	applied, err := applyEnv(&cfg, os.Getenv)
+	if err != nil {
+		return nil, fmt.Errorf("invalid environment: %w", err)
 	}
+	cfg.Sources = append(cfg.Sources, applied...)
 
+	if len(cfg.Sources) > 0 || cfg.Profile != "" {
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", strings.Join(cfg.Sources, ", "), err)
+		}
+	}
 	return &cfg, nil
 }
+
+// useProfile applies the named profile's settings and makes it the active profile
+func (c *Config) useProfile(name string) error {
+	p, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("unknown profile %q (no profiles configured)", name)
+		}
+		return fmt.Errorf("unknown profile %q (want one of %s)", name, strings.Join(slices.Sorted(maps.Keys(c.Profiles)), ", "))
+	}
+	for _, settings := range p.settings {
+		if err := decodeNode(settings, c); err != nil {
+			return fmt.Errorf("invalid profile %s: %w", name, err)
+		}
+	}
+	c.Profile = name
+	return nil
+}
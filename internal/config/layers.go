@@ -0,0 +1,207 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ProjectFile is the per-repository config file read from the working directory
+const ProjectFile = ".gh-brag.yaml"
+
+// envPrefix starts every environment override: GH_BRAG_LLM_MODEL sets llm.model
+const envPrefix = "GH_BRAG_"
+
+// Theme merge strategies for themes_merge
+const (
+	ThemesPatch   = "patch"   // Same-named themes are replaced in place, others appended (default)
+	ThemesAppend  = "append"  // Same-named themes gain the keywords, others appended
+	ThemesReplace = "replace" // The layer's themes replace all earlier ones
+)
+
+var themeStrategies = []string{ThemesPatch, ThemesAppend, ThemesReplace}
+
+// UserConfigPath returns $XDG_CONFIG_HOME/gh-brag/config.yaml,
+// falling back to ~/.config/gh-brag/config.yaml
+func UserConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gh-brag", "config.yaml")
+}
+
+// Files returns the config files LoadConfig overlays on the defaults, lowest
+// precedence first: the user file, the project file, then explicit.
+// Discovered files that don't exist are left out; explicit is always last.
+func Files(explicit string) []string {
+	var files []string
+	for _, path := range []string{UserConfigPath(), ProjectFile} {
+		if path == "" || samePath(path, explicit) {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+	if explicit != "" {
+		files = append(files, explicit)
+	}
+	return files
+}
+
+func samePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// mergeThemes combines the themes of one layer with those of earlier layers.
+// Themes marked remove are dropped by name.
+func mergeThemes(base, layer []Theme, strategy string) []Theme {
+	var merged []Theme
+	if strategy != ThemesReplace {
+		merged = append(merged, base...)
+	}
+	for _, t := range layer {
+		i := -1
+		for j, m := range merged {
+			if strings.EqualFold(m.Name, t.Name) {
+				i = j
+				break
+			}
+		}
+		switch {
+		case t.Remove:
+			if i >= 0 {
+				merged = append(merged[:i], merged[i+1:]...)
+			}
+		case i < 0:
+			merged = append(merged, t)
+		case strategy == ThemesAppend:
+			merged[i].Keywords = appendNew(merged[i].Keywords, t.Keywords)
+		default:
+			merged[i] = t
+		}
+	}
+	return merged
+}
+
+// appendNew appends the keywords in add that aren't already in keywords
+func appendNew(keywords, add []string) []string {
+	out := append([]string(nil), keywords...)
+	for _, k := range add {
+		found := false
+		for _, existing := range out {
+			if strings.EqualFold(existing, k) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// applyEnv overrides scalar and string list settings from GH_BRAG_<PATH>
+// variables, where PATH is the setting's YAML path in upper case with dots
+// as underscores (GH_BRAG_DAILY_TZ, GH_BRAG_REDACTION_DENY="org-a,org-b").
+// It returns the names of the variables it applied.
+func applyEnv(cfg *Config, getenv func(string) string) ([]string, error) {
+	var applied []string
+	var v validator
+	walkSettings(reflect.ValueOf(cfg).Elem(), "", func(path string, field reflect.Value) {
+		name := EnvName(path)
+		raw := getenv(name)
+		if raw == "" {
+			return
+		}
+		if err := setFromEnv(field, raw); err != nil {
+			v.add(name, "%v", err)
+			return
+		}
+		applied = append(applied, name)
+	})
+	return applied, v.err()
+}
+
+// EnvName returns the environment variable that overrides the setting at a YAML path
+func EnvName(path string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
+
+// walkSettings calls fn for every setting of v that an environment variable can hold
+func walkSettings(v reflect.Value, path string, fn func(string, reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if !f.IsExported() || name == "-" || name == "themes_merge" || name == "profile" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		field := v.Field(i)
+		switch f.Type.Kind() {
+		case reflect.Struct:
+			walkSettings(field, joinPath(path, name), fn)
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Float64:
+			fn(joinPath(path, name), field)
+		case reflect.Slice:
+			if f.Type.Elem().Kind() == reflect.String {
+				fn(joinPath(path, name), field)
+			}
+		}
+	}
+}
+
+func setFromEnv(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		list := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			list.Index(i).SetString(item)
+		}
+		field.Set(list)
+	}
+	return nil
+}
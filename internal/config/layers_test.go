@@ -0,0 +1,294 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolate points config discovery at empty temporary directories
+func isolate(t *testing.T) (userDir, projectDir string) {
+	t.Helper()
+	userDir, projectDir = t.TempDir(), t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", userDir)
+	t.Chdir(projectDir)
+	return filepath.Join(userDir, "gh-brag"), projectDir
+}
+
+func themeNames(themes []Theme) []string {
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+func TestLoadConfig_Layers(t *testing.T) {
+	userDir, projectDir := isolate(t)
+	require.NoError(t, os.MkdirAll(userDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(userDir, "config.yaml"), []byte(`llm:
+  model: user-model
+daily:
+  orgs: [acme]
+  tz: Asia/Tokyo
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ProjectFile), []byte(`llm:
+  model: project-model
+metrics:
+  action_weights:
+    merged: 20
+`), 0644))
+	explicit := writeConfig(t, "daily:\n  tz: Europe/Berlin\n")
+	t.Setenv("GH_BRAG_DAILY_ORGS", "acme, globex")
+
+	cfg, err := LoadConfig(explicit, "")
+	require.NoError(t, err)
+	assert.Equal(t, "project-model", cfg.LLM.Model)
+	assert.Equal(t, "Europe/Berlin", cfg.Daily.TZ)
+	assert.Equal(t, []string{"acme", "globex"}, cfg.Daily.Orgs)
+	assert.Equal(t, 20.0, cfg.Metrics.ActionWeights["merged"])
+	assert.Equal(t, 5.0, cfg.Metrics.ActionWeights["authored"], "maps merge key by key")
+	assert.Equal(t, []string{
+		filepath.Join(userDir, "config.yaml"),
+		ProjectFile,
+		explicit,
+		"GH_BRAG_DAILY_ORGS",
+	}, cfg.Sources)
+}
+
+func TestLoadConfig_MissingFiles(t *testing.T) {
+	isolate(t)
+
+	cfg, err := LoadConfig("", "")
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Sources)
+
+	_, err = LoadConfig("nope.yaml", "")
+	assert.EqualError(t, err, "config file nope.yaml does not exist")
+}
+
+func TestLoadConfig_ExplicitProjectFileReadOnce(t *testing.T) {
+	_, projectDir := isolate(t)
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ProjectFile), []byte(`themes_merge: append
+themes:
+  - name: Docs
+    keywords: [adr]
+`), 0644))
+
+	cfg, err := LoadConfig(ProjectFile, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ProjectFile}, cfg.Sources)
+	assert.Equal(t, []string{"doc", "readme", "guide", "adr"}, cfg.Themes[3].Keywords)
+}
+
+func TestLoadConfig_ThemeStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		want     []string
+		keywords []string // Keywords of the Feature theme, when kept
+	}{
+		{
+			name: "patch by default",
+			yaml: `themes:
+  - name: feature
+    keywords: [feat]
+  - name: Security
+    keywords: [cve]
+  - name: Docs
+    remove: true
+`,
+			want:     []string{"feature", "Maintenance", "Bug Fix", "Refactor", "Security"},
+			keywords: []string{"feat"},
+		},
+		{
+			name: "append",
+			yaml: `themes_merge: append
+themes:
+  - name: Feature
+    keywords: [epic, feat]
+`,
+			want:     []string{"Feature", "Maintenance", "Bug Fix", "Docs", "Refactor"},
+			keywords: []string{"feat", "feature", "new", "intro", "support", "introduce", "epic"},
+		},
+		{
+			name: "replace",
+			yaml: `themes_merge: replace
+themes:
+  - name: Feature
+    keywords: [feat]
+`,
+			want:     []string{"Feature"},
+			keywords: []string{"feat"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolate(t)
+			cfg, err := LoadConfig(writeConfig(t, tt.yaml), "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, themeNames(cfg.Themes))
+			assert.Equal(t, tt.keywords, cfg.Themes[0].Keywords)
+			assert.Empty(t, cfg.ThemesMerge)
+		})
+	}
+}
+
+func TestLoadConfig_ThemeErrors(t *testing.T) {
+	isolate(t)
+
+	_, err := LoadConfig(writeConfig(t, "themes_merge: merge\nthemes: []\n"), "")
+	require.Error(t, err)
+	assert.Equal(t, []string{`themes_merge (line 1): unknown strategy "merge" (want one of patch, append, replace)`}, problems(t, err))
+
+	_, err = LoadConfig(writeConfig(t, `themes:
+  - name: Infra
+    keywords: [tf]
+  - name: infra
+    keywords: [k8s]
+`), "")
+	require.Error(t, err)
+	assert.Equal(t, []string{`themes[1].name (line 4): duplicate theme "infra"`}, problems(t, err))
+}
+
+func TestLoadConfig_Env(t *testing.T) {
+	isolate(t)
+	t.Setenv("GH_BRAG_LLM_MODEL", "llama3.1")
+	t.Setenv("GH_BRAG_LLM_MAX_PROMPT_TOKENS", "4000")
+	t.Setenv("GH_BRAG_DAILY_SUMMARY_STRUCTURED", "true")
+	t.Setenv("GH_BRAG_CLASSIFICATION_MIN_CONFIDENCE", "0.8")
+
+	cfg, err := LoadConfig("", "")
+	require.NoError(t, err)
+	assert.Equal(t, "llama3.1", cfg.LLM.Model)
+	assert.Equal(t, 4000, cfg.LLM.MaxPromptTokens)
+	assert.True(t, cfg.Daily.Summary.Structured)
+	assert.Equal(t, 0.8, cfg.Classification.MinConfidence)
+
+	t.Setenv("GH_BRAG_METRICS_TOP_EVENTS", "ten")
+	_, err = LoadConfig("", "")
+	require.Error(t, err)
+	assert.Equal(t, []string{`GH_BRAG_METRICS_TOP_EVENTS: invalid integer "ten"`}, problems(t, err))
+
+	t.Setenv("GH_BRAG_METRICS_TOP_EVENTS", "")
+	t.Setenv("GH_BRAG_DAILY_TZ", "Mars/Olympus")
+	_, err = LoadConfig("", "")
+	require.Error(t, err)
+	assert.Equal(t, []string{`daily.tz: unknown timezone "Mars/Olympus"`}, problems(t, err))
+}
+
+const profilesYAML = `profile: oss
+profiles:
+  work:
+    hosts: [github.acme.com]
+    orgs: [acme]
+    exclude_repos: [acme/secret]
+    store: work.events.jsonl
+    themes:
+      - name: Incident
+        keywords: [sev1, postmortem]
+    metrics:
+      action_weights:
+        reviewed: 4
+    llm:
+      model: acme/llm
+  oss:
+    repos: [cli/cli]
+    store: oss.events.jsonl
+`
+
+func TestLoadConfig_Profiles(t *testing.T) {
+	isolate(t)
+	path := writeConfig(t, profilesYAML)
+
+	cfg, err := LoadConfig(path, "work")
+	require.NoError(t, err)
+	assert.Equal(t, "work", cfg.Profile)
+	p := cfg.ActiveProfile()
+	assert.Equal(t, []string{"github.acme.com"}, p.Hosts)
+	assert.Equal(t, "work.events.jsonl", p.Store)
+	assert.False(t, p.Scope().Allows("acme/secret"))
+	assert.True(t, p.Scope().Allows("acme/api"))
+	assert.Equal(t, "Incident", cfg.Themes[len(cfg.Themes)-1].Name, "profile themes merge like a layer")
+	assert.Equal(t, 4.0, cfg.Metrics.ActionWeights["reviewed"])
+	assert.Equal(t, 10.0, cfg.Metrics.ActionWeights["merged"])
+	assert.Equal(t, "acme/llm", cfg.LLM.Model)
+
+	// The profile key picks the default; another profile's settings aren't applied
+	cfg, err = LoadConfig(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "oss", cfg.Profile)
+	assert.Equal(t, "oss.events.jsonl", cfg.ActiveProfile().Store)
+	assert.Equal(t, "openai/gpt-4o", cfg.LLM.Model)
+	assert.Len(t, cfg.Themes, 5)
+
+	// GH_BRAG_PROFILE beats the profile key, and environment settings beat the profile
+	t.Setenv("GH_BRAG_PROFILE", "work")
+	t.Setenv("GH_BRAG_LLM_MODEL", "env-model")
+	cfg, err = LoadConfig(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "work", cfg.Profile)
+	assert.Equal(t, "env-model", cfg.LLM.Model)
+
+	_, err = LoadConfig(path, "home")
+	assert.EqualError(t, err, `unknown profile "home" (want one of oss, work)`)
+}
+
+func TestLoadConfig_ProfilesAcrossLayers(t *testing.T) {
+	_, projectDir := isolate(t)
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ProjectFile), []byte(profilesYAML), 0644))
+	path := writeConfig(t, `profiles:
+  work:
+    store: override.jsonl
+    llm:
+      max_prompt_tokens: 8000
+`)
+
+	cfg, err := LoadConfig(path, "work")
+	require.NoError(t, err)
+	p := cfg.ActiveProfile()
+	assert.Equal(t, "override.jsonl", p.Store)
+	assert.Equal(t, []string{"acme"}, p.Orgs, "later layers override a profile key by key")
+	assert.Equal(t, "acme/llm", cfg.LLM.Model)
+	assert.Equal(t, 8000, cfg.LLM.MaxPromptTokens)
+}
+
+func TestLoadConfig_ProfileErrors(t *testing.T) {
+	isolate(t)
+
+	_, err := LoadConfig(writeConfig(t, `profiles:
+  work:
+    hosts: [https://github.acme.com]
+    repos: [acme]
+    stor: work.jsonl
+    metrics:
+      top_event: 3
+`), "")
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		`profiles.work.stor (line 5): unknown field (did you mean "store"?)`,
+		`profiles.work.metrics.top_event (line 7): unknown field (did you mean "top_events"?)`,
+	}, problems(t, err))
+
+	_, err = LoadConfig(writeConfig(t, `profiles:
+  work:
+    hosts: [https://github.acme.com]
+    repos: [acme]
+`), "work")
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		`profiles.work.hosts[0]: invalid host "https://github.acme.com" (want a hostname such as github.example.com)`,
+		`profiles.work.repos[0]: invalid repository "acme" (want owner/name)`,
+	}, problems(t, err))
+
+	_, err = LoadConfig(writeConfig(t, "profiles:\n  work:\n    profile: oss\n"), "")
+	require.Error(t, err)
+	assert.Equal(t, []string{`profiles.work.profile (line 3): not allowed inside a profile`}, problems(t, err))
+
+	_, err = LoadConfig(writeConfig(t, "llm:\n  model: x\n"), "work")
+	assert.EqualError(t, err, `unknown profile "work" (no profiles configured)`)
+}
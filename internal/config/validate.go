@@ -0,0 +1,547 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/expr"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is one invalid setting
+type Problem struct {
+	Field   string // Dotted path such as metrics.action_weights.merged
+	Line    int    // Line in the file, when known
+	Message string
+}
+
+func (p Problem) String() string {
+	switch {
+	case p.Field == "":
+		return p.Message
+	case p.Line > 0:
+		return fmt.Sprintf("%s (line %d): %s", p.Field, p.Line, p.Message)
+	default:
+		return fmt.Sprintf("%s: %s", p.Field, p.Message)
+	}
+}
+
+// ValidationError lists every problem found in a config
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0].String()
+	}
+	lines := make([]string, 0, len(e.Problems)+1)
+	lines = append(lines, fmt.Sprintf("%d problems:", len(e.Problems)))
+	for _, p := range e.Problems {
+		lines = append(lines, "  - "+p.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// knownActions are the event actions metrics.action_weights may weigh
+var knownActions = []data.EventAction{data.EventActionMerged, data.EventActionAuthored, data.EventActionReviewed, data.EventActionNoted, data.EventActionCommitted}
+
+// Validate checks the config for values that would otherwise be silently ignored
+// or misbehave, such as unknown actions, negative weights and duplicate names
+func (c *Config) Validate() error {
+	var v validator
+
+	themes := make(map[string]bool)
+	for i, t := range c.Themes {
+		field := fmt.Sprintf("themes[%d]", i)
+		switch {
+		case strings.TrimSpace(t.Name) == "":
+			v.add(field+".name", "must not be empty")
+		case themes[strings.ToLower(t.Name)]:
+			v.add(field+".name", "duplicate theme %q", t.Name)
+		}
+		themes[strings.ToLower(t.Name)] = true
+		for j, k := range t.Keywords {
+			if strings.TrimSpace(k) == "" {
+				v.add(fmt.Sprintf("%s.keywords[%d]", field, j), "must not be empty")
+			}
+		}
+	}
+
+	m := c.Metrics
+	if m.OwnershipThreshold < 0 {
+		v.add("metrics.ownership_threshold", "must not be negative")
+	}
+	if m.TopEvents < 0 {
+		v.add("metrics.top_events", "must not be negative")
+	}
+	for _, action := range slices.Sorted(maps.Keys(m.ActionWeights)) {
+		w := m.ActionWeights[action]
+		field := "metrics.action_weights." + string(action)
+		if !slices.Contains(knownActions, action) {
+			v.add(field, "unknown action (want one of %s)", joinActions())
+		}
+		if w < 0 {
+			v.add(field, "weight must not be negative")
+		}
+	}
+	for _, theme := range slices.Sorted(maps.Keys(m.ThemeWeights)) {
+		w := m.ThemeWeights[theme]
+		if w < 0 {
+			v.add("metrics.theme_weights."+theme, "weight must not be negative")
+		}
+	}
+	tiers := make(map[string]bool)
+	for i, t := range m.ImpactTiers {
+		field := fmt.Sprintf("metrics.impact_tiers[%d].name", i)
+		switch {
+		case strings.TrimSpace(t.Name) == "":
+			v.add(field, "must not be empty")
+		case tiers[t.Name]:
+			v.add(field, "duplicate tier %q", t.Name)
+		}
+		tiers[t.Name] = true
+	}
+
+	metrics := make(map[string]bool)
+	for i, cm := range c.CustomMetrics {
+		field := fmt.Sprintf("custom_metrics[%d]", i)
+		switch {
+		case strings.TrimSpace(cm.Name) == "":
+			v.add(field+".name", "must not be empty")
+		case metrics[cm.Name]:
+			v.add(field+".name", "duplicate metric %q", cm.Name)
+		}
+		metrics[cm.Name] = true
+		if _, err := expr.Compile(cm.Expr); err != nil {
+			v.add(field+".expr", "%v", err)
+		}
+		if cm.GroupBy != "" && !expr.IsField(cm.GroupBy) {
+			v.add(field+".group_by", "unknown field %q", cm.GroupBy)
+		}
+	}
+
+	d := c.Daily
+	if d.WeekStart != "" && !isWeekday(d.WeekStart) {
+		v.add("daily.week_start", "invalid weekday %q", d.WeekStart)
+	}
+	for i, day := range d.WorkDays {
+		if !isWeekday(day) {
+			v.add(fmt.Sprintf("daily.work_days[%d]", i), "invalid weekday %q", day)
+		}
+	}
+	if d.Sprint.Anchor != "" {
+		if _, err := time.Parse("2006-01-02", d.Sprint.Anchor); err != nil {
+			v.add("daily.sprint.anchor", "invalid date %q (want YYYY-MM-DD)", d.Sprint.Anchor)
+		}
+	}
+	if d.Sprint.LengthDays < 0 {
+		v.add("daily.sprint.length_days", "must not be negative")
+	}
+	for _, target := range []string{"slack", "teams", "discord", "webhook"} {
+		if u := d.Post.URL(target); u != "" && !isHTTPURL(u) {
+			v.add("daily.post."+target, "must be an http(s) URL")
+		}
+	}
+	if d.TZ != "" {
+		if _, err := time.LoadLocation(d.TZ); err != nil {
+			v.add("daily.tz", "unknown timezone %q", d.TZ)
+		}
+	}
+	if d.Post.Attempts < 0 {
+		v.add("daily.post.attempts", "must not be negative")
+	}
+
+	if c.LLM.Endpoint != "" && !isHTTPURL(c.LLM.Endpoint) {
+		v.add("llm.endpoint", "must be an http(s) URL")
+	}
+	if c.LLM.MaxPromptTokens < 0 {
+		v.add("llm.max_prompt_tokens", "must not be negative")
+	}
+	if c.Classification.BatchSize < 0 {
+		v.add("classification.batch_size", "must not be negative")
+	}
+	if mc := c.Classification.MinConfidence; mc < 0 || mc > 1 {
+		v.add("classification.min_confidence", "must be between 0 and 1")
+	}
+	if _, err := c.Redaction.Redactor(); err != nil {
+		v.add("redaction.rules", "%v", err)
+	}
+	if _, err := c.Rules.Set(); err != nil {
+		// rules.New prefixes the message with the rule, e.g. "exclude[0]: ..."
+		rule, msg, _ := strings.Cut(err.Error(), ": ")
+		v.add("rules."+rule, "%s", msg)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.Profiles)) {
+		p := c.Profiles[name]
+		field := "profiles." + name
+		for i, host := range p.Hosts {
+			if host == "" || strings.Contains(host, "/") {
+				v.add(fmt.Sprintf("%s.hosts[%d]", field, i), "invalid host %q (want a hostname such as github.example.com)", host)
+			}
+		}
+		for _, list := range []struct {
+			key   string
+			repos []string
+		}{{"repos", p.Repos}, {"exclude_repos", p.ExcludeRepos}} {
+			for i, repo := range list.repos {
+				if owner, name, ok := strings.Cut(repo, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+					v.add(fmt.Sprintf("%s.%s[%d]", field, list.key, i), "invalid repository %q (want owner/name)", repo)
+				}
+			}
+		}
+	}
+
+	return v.err()
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(field, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+func joinActions() string {
+	names := make([]string, len(knownActions))
+	for i, a := range knownActions {
+		names[i] = string(a)
+	}
+	return strings.Join(names, ", ")
+}
+
+// isWeekday accepts full or three-letter weekday names in any case
+func isWeekday(s string) bool {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := d.String()
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// decodeLayer overlays one YAML layer onto cfg, reporting unknown keys with
+// their path and line, and type mismatches as yaml reports them. The layer's
+// themes are merged into cfg.Themes according to its themes_merge strategy.
+func decodeLayer(raw []byte, cfg *Config) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(raw, &root); err != nil {
+		return err
+	}
+	if len(root.Content) == 0 {
+		return nil // Empty file
+	}
+	return decodeNode(root.Content[0], cfg)
+}
+
+// decodeNode overlays the mapping node doc onto cfg (see decodeLayer)
+func decodeNode(doc *yaml.Node, cfg *Config) error {
+	var problems []Problem
+	checkFields(doc, reflect.TypeOf(cfg), "", &problems)
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	// Themes are taken out of the document so they merge instead of replacing,
+	// and profiles are kept aside until one is used
+	var themesNode, profilesNode, strategyKey *yaml.Node
+	if doc.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(doc.Content); i += 2 {
+			switch doc.Content[i].Value {
+			case "themes":
+				themesNode = doc.Content[i+1]
+				doc.Content = append(doc.Content[:i:i], doc.Content[i+2:]...)
+				i -= 2
+			case "profiles":
+				profilesNode = doc.Content[i+1]
+				doc.Content = append(doc.Content[:i:i], doc.Content[i+2:]...)
+				i -= 2
+			case "themes_merge":
+				strategyKey = doc.Content[i]
+			}
+		}
+	}
+
+	cfg.ThemesMerge = ""
+	err := doc.Decode(cfg)
+	strategy := cfg.ThemesMerge
+	cfg.ThemesMerge = ""
+	if err != nil {
+		return typeProblems(err)
+	}
+	if strategy == "" {
+		strategy = ThemesPatch
+	} else if !slices.Contains(themeStrategies, strategy) {
+		return &ValidationError{Problems: []Problem{{
+			Field:   "themes_merge",
+			Line:    strategyKey.Line,
+			Message: fmt.Sprintf("unknown strategy %q (want one of %s)", strategy, strings.Join(themeStrategies, ", ")),
+		}}}
+	}
+	if profilesNode != nil {
+		if err := decodeProfiles(profilesNode, cfg); err != nil {
+			return err
+		}
+	}
+	if themesNode == nil {
+		return nil
+	}
+
+	var themes []Theme
+	if err := themesNode.Decode(&themes); err != nil {
+		return typeProblems(err)
+	}
+	// Merging by name would otherwise hide a name repeated within the layer
+	seen := make(map[string]bool)
+	for i, t := range themes {
+		name := strings.ToLower(t.Name)
+		if seen[name] && name != "" {
+			problems = append(problems, Problem{
+				Field:   fmt.Sprintf("themes[%d].name", i),
+				Line:    themesNode.Content[i].Line,
+				Message: fmt.Sprintf("duplicate theme %q", t.Name),
+			})
+		}
+		seen[name] = true
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	cfg.Themes = mergeThemes(cfg.Themes, themes, strategy)
+	return nil
+}
+
+// decodeProfiles overlays each profile's own keys onto cfg.Profiles and keeps
+// its other settings for useProfile
+func decodeProfiles(node *yaml.Node, cfg *Config) error {
+	if node.Kind != yaml.MappingNode {
+		return typeProblems(node.Decode(&cfg.Profiles))
+	}
+	own := yamlFields(reflect.TypeOf(Profile{}))
+
+	var problems []Problem
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, body := node.Content[i].Value, node.Content[i+1]
+		fields := &yaml.Node{Kind: yaml.MappingNode}
+		settings := &yaml.Node{Kind: yaml.MappingNode}
+		if body.Kind == yaml.MappingNode {
+			for j := 0; j+1 < len(body.Content); j += 2 {
+				key := body.Content[j]
+				switch _, ok := own[key.Value]; {
+				case key.Value == "profile" || key.Value == "profiles":
+					problems = append(problems, Problem{
+						Field:   "profiles." + name + "." + key.Value,
+						Line:    key.Line,
+						Message: "not allowed inside a profile",
+					})
+				case ok:
+					fields.Content = append(fields.Content, key, body.Content[j+1])
+				default:
+					settings.Content = append(settings.Content, key, body.Content[j+1])
+				}
+			}
+		} else if body.Tag != "!!null" {
+			problems = append(problems, Problem{Field: "profiles." + name, Line: body.Line, Message: "must be a mapping"})
+			continue
+		}
+
+		if cfg.Profiles == nil {
+			cfg.Profiles = make(map[string]*Profile)
+		}
+		p := cfg.Profiles[name]
+		if p == nil {
+			p = &Profile{}
+			cfg.Profiles[name] = p
+		}
+		if err := fields.Decode(p); err != nil {
+			return typeProblems(err)
+		}
+		if len(settings.Content) > 0 {
+			p.settings = append(p.settings, settings)
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// typeProblems converts yaml type errors into problems
+func typeProblems(err error) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+	problems := make([]Problem, len(typeErr.Errors))
+	for i, msg := range typeErr.Errors {
+		problems[i] = Problem{Message: msg}
+	}
+	return &ValidationError{Problems: problems}
+}
+
+var unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// profileSchema lists the keys a profile accepts: its own and any config key
+type profileSchema struct {
+	Profile `yaml:",inline"`
+	Config  `yaml:",inline"`
+}
+
+// schemas maps types to the type their YAML is checked against, for types
+// whose YAML carries more keys than their fields
+var schemas = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(Profile{}): reflect.TypeOf(profileSchema{}),
+}
+
+// checkFields walks node alongside type t and records keys t has no field for
+func checkFields(node *yaml.Node, t reflect.Type, path string, problems *[]Problem) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			checkFields(child, t, path, problems)
+		}
+		return
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			checkFields(node.Alias, t, path, problems)
+		}
+		return
+	}
+	if schema, ok := schemas[t]; ok {
+		t = schema
+	}
+	// Types with their own unmarshaler decide what they accept
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	// Mismatched kinds are left to the decoder, which reports them as type errors
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field := joinPath(path, key.Value)
+			ft, ok := fields[key.Value]
+			if !ok {
+				msg := "unknown field"
+				if s := suggest(key.Value, fields); s != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", s)
+				}
+				*problems = append(*problems, Problem{Field: field, Line: key.Line, Message: msg})
+				continue
+			}
+			checkFields(value, ft, field, problems)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			checkFields(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value), problems)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, child := range node.Content {
+			checkFields(child, t.Elem(), fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	}
+}
+
+// yamlFields maps the YAML keys of struct type t to their field types
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			for k, v := range yamlFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// suggest returns the known key closest to key, if it is a likely typo
+func suggest(key string, fields map[string]reflect.Type) string {
+	best, bestDist := "", 3
+	for name := range fields {
+		if d := editDistance(key, name); d < bestDist || (d == bestDist && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	if bestDist > 2 {
+		return ""
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
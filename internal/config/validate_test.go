@@ -0,0 +1,157 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func problems(t *testing.T, err error) []string {
+	t.Helper()
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr), "expected a ValidationError, got %v", err)
+	out := make([]string, len(verr.Problems))
+	for i, p := range verr.Problems {
+		out[i] = p.String()
+	}
+	return out
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	isolate(t)
+
+	cfg, err := LoadConfig("", "")
+	require.NoError(t, err)
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, 10.0, cfg.Metrics.ActionWeights[data.EventActionMerged])
+}
+
+func TestLoadConfig_UnknownFields(t *testing.T) {
+	isolate(t)
+
+	path := writeConfig(t, `themes:
+  - name: Feature
+    keywrds: [feat]
+metrics:
+  ownership_treshold: 3
+  action_weights:
+    merged: 1
+daily:
+  post:
+    slak: https://hooks.example.com
+`)
+
+	_, err := LoadConfig(path, "")
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		`themes[0].keywrds (line 3): unknown field (did you mean "keywords"?)`,
+		`metrics.ownership_treshold (line 5): unknown field (did you mean "ownership_threshold"?)`,
+		`daily.post.slak (line 10): unknown field (did you mean "slack"?)`,
+	}, problems(t, err))
+}
+
+func TestLoadConfig_TypeError(t *testing.T) {
+	isolate(t)
+
+	path := writeConfig(t, "metrics:\n  top_events: lots\n")
+
+	_, err := LoadConfig(path, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2: cannot unmarshal !!str `lots` into int")
+}
+
+func TestLoadConfig_InlineAndCustomUnmarshalers(t *testing.T) {
+	isolate(t)
+
+	path := writeConfig(t, `redaction:
+  rules:
+    - name: ticket
+      pattern: 'ACME-\d+'
+      replace: "[ticket]"
+`)
+
+	cfg, err := LoadConfig(path, "")
+	require.NoError(t, err)
+	require.Len(t, cfg.Redaction.Rules, 1)
+	assert.Equal(t, "[ticket]", cfg.Redaction.Rules[0].Replace)
+}
+
+func TestValidate(t *testing.T) {
+	isolate(t)
+
+	cfg, err := LoadConfig("", "")
+	require.NoError(t, err)
+
+	cfg.Themes = append(cfg.Themes, Theme{Name: "feature"}, Theme{Name: " ", Keywords: []string{""}})
+	cfg.Metrics.ActionWeights["mergd"] = 3
+	cfg.Metrics.ActionWeights[data.EventActionReviewed] = -1
+	cfg.Metrics.ThemeWeights["Docs"] = -0.5
+	cfg.Metrics.ImpactTiers = append(cfg.Metrics.ImpactTiers, ImpactTier{Name: "Active"})
+	cfg.CustomMetrics = []CustomMetric{{Name: "m", Expr: "count(", GroupBy: "nope"}, {Name: "m", Expr: "count()"}}
+	cfg.Daily.WeekStart = "funday"
+	cfg.Daily.WorkDays = []string{"mon", "Tuesday", "someday"}
+	cfg.Daily.Sprint.Anchor = "2026/01/05"
+	cfg.Daily.Post.Slack = "hooks.slack.com/x"
+	cfg.LLM.Endpoint = "localhost:11434"
+	cfg.Classification.MinConfidence = 1.5
+	cfg.Redaction.Rules = []redact.Rule{{Name: "bad", Pattern: "("}}
+
+	got := problems(t, cfg.Validate())
+	assert.Equal(t, []string{
+		`themes[5].name: duplicate theme "feature"`,
+		`themes[6].name: must not be empty`,
+		`themes[6].keywords[0]: must not be empty`,
+		`metrics.action_weights.mergd: unknown action (want one of merged, authored, reviewed, noted, committed)`,
+		`metrics.action_weights.reviewed: weight must not be negative`,
+		`metrics.theme_weights.Docs: weight must not be negative`,
+		`metrics.impact_tiers[3].name: duplicate tier "Active"`,
+		got[7], // expression syntax error, worded by the expr package
+		`custom_metrics[0].group_by: unknown field "nope"`,
+		`custom_metrics[1].name: duplicate metric "m"`,
+		`daily.week_start: invalid weekday "funday"`,
+		`daily.work_days[2]: invalid weekday "someday"`,
+		`daily.sprint.anchor: invalid date "2026/01/05" (want YYYY-MM-DD)`,
+		`daily.post.slack: must be an http(s) URL`,
+		`llm.endpoint: must be an http(s) URL`,
+		`classification.min_confidence: must be between 0 and 1`,
+		`redaction.rules: invalid redaction rule bad: error parsing regexp: missing closing ): ` + "`(`",
+	}, got)
+	assert.Contains(t, got[7], "custom_metrics[0].expr: ")
+}
+
+func TestLoadConfig_Rules(t *testing.T) {
+	isolate(t)
+
+	cfg, err := LoadConfig(writeConfig(t, `rules:
+  exclude:
+    - name: bots
+      bot: true
+    - repos: ["archived/*"]
+  include:
+    - authors: ["acme-release[bot]"]
+`), "")
+	require.NoError(t, err)
+	set, err := cfg.Rules.Set()
+	require.NoError(t, err)
+	assert.Equal(t, "bots", set.Excluded(data.Event{Author: "dependabot", AuthorBot: true}))
+
+	_, err = LoadConfig(writeConfig(t, `rules:
+  exclude:
+    - kinds: [gist]
+`), "")
+	require.Error(t, err)
+	assert.Equal(t, []string{`rules.exclude[0]: unknown kind "gist" (want one of pr, issue, note, commit)`}, problems(t, err))
+}
@@ -15,8 +15,16 @@ func Aggregate(dateRange *DateRange, prs []PRWithIssues, reviews []ReviewedPR) *
 		RangeStart:    dateRange.Start,
 		RangeEnd:      dateRange.End,
 		IssueGroups:   make([]IssueGroup, 0),
-		StandalonePRs: make([]data.Event, 0),
+		Epics:         make([]EpicGroup, 0),
+		StandalonePRs: make([]PullRequest, 0),
 		ExtraReviews:  make([]ExtraReview, 0),
+		IssueActivity: make([]IssueActivity, 0),
+
+		InProgress:     make([]PullRequest, 0),
+		ReviewRequests: make([]PullRequest, 0),
+		AssignedIssues: make([]data.Event, 0),
+
+		dateRange: dateRange,
 	}
 
 	// Group PRs by linked issue
@@ -25,18 +33,19 @@ func Aggregate(dateRange *DateRange, prs []PRWithIssues, reviews []ReviewedPR) *
 	var issueOrder []string // preserve order of first appearance
 
 	for _, pr := range prs {
+		entry := PullRequest{Event: pr.Event, Status: pr.Status}
 		if len(pr.LinkedIssues) == 0 {
 			// No linked issues - standalone PR
-			report.StandalonePRs = append(report.StandalonePRs, pr.Event)
+			report.StandalonePRs = append(report.StandalonePRs, entry)
 		} else {
 			// Add PR to each linked issue group
 			for _, issue := range pr.LinkedIssues {
 				if group, exists := issueMap[issue.URL]; exists {
-					group.PRs = append(group.PRs, pr.Event)
+					group.PRs = append(group.PRs, entry)
 				} else {
 					issueMap[issue.URL] = &IssueGroup{
 						Issue: issue,
-						PRs:   []data.Event{pr.Event},
+						PRs:   []PullRequest{entry},
 					}
 					issueOrder = append(issueOrder, issue.URL)
 				}
@@ -49,21 +58,40 @@ func Aggregate(dateRange *DateRange, prs []PRWithIssues, reviews []ReviewedPR) *
 		group := issueMap[url]
 		// Sort PRs within group by activity time descending
 		sort.Slice(group.PRs, func(i, j int) bool {
-			return ActivityTime(group.PRs[i]).After(ActivityTime(group.PRs[j]))
+			return ActivityTime(group.PRs[i].Event).After(ActivityTime(group.PRs[j].Event))
 		})
 		report.IssueGroups = append(report.IssueGroups, *group)
 	}
 
 	// Sort issue groups by most recent PR activity descending
 	sort.Slice(report.IssueGroups, func(i, j int) bool {
-		iTime := ActivityTime(report.IssueGroups[i].PRs[0])
-		jTime := ActivityTime(report.IssueGroups[j].PRs[0])
+		iTime := ActivityTime(report.IssueGroups[i].PRs[0].Event)
+		jTime := ActivityTime(report.IssueGroups[j].PRs[0].Event)
 		return iTime.After(jTime)
 	})
 
+	// Roll issue groups up by epic, keeping the most recently active epic first
+	epicMap := make(map[string]*EpicGroup)
+	var epicOrder []string
+	for _, group := range report.IssueGroups {
+		epic := group.Issue.Epic
+		if epic == nil {
+			continue
+		}
+		if eg, exists := epicMap[epic.URL]; exists {
+			eg.IssueGroups = append(eg.IssueGroups, group)
+			continue
+		}
+		epicMap[epic.URL] = &EpicGroup{Epic: *epic, IssueGroups: []IssueGroup{group}}
+		epicOrder = append(epicOrder, epic.URL)
+	}
+	for _, url := range epicOrder {
+		report.Epics = append(report.Epics, *epicMap[url])
+	}
+
 	// Sort standalone PRs by activity time descending
 	sort.Slice(report.StandalonePRs, func(i, j int) bool {
-		return ActivityTime(report.StandalonePRs[i]).After(ActivityTime(report.StandalonePRs[j]))
+		return ActivityTime(report.StandalonePRs[i].Event).After(ActivityTime(report.StandalonePRs[j].Event))
 	})
 
 	// Convert ReviewedPR to ExtraReview (grouped by PR)
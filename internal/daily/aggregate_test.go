@@ -120,6 +120,42 @@ func TestAggregate_MixedPRs(t *testing.T) {
 	assert.Len(t, report.StandalonePRs, 1)
 }
 
+func TestAggregate_Epics(t *testing.T) {
+	now := time.Now()
+	dateRange := &DateRange{
+		Start: now.Add(-24 * time.Hour),
+		End:   now,
+		Label: "2026-01-07",
+	}
+
+	epic := &Epic{Number: 1, Title: "Auth revamp", URL: "https://github.com/org/repo/issues/1"}
+	login := LinkedIssue{Number: 10, Title: "Login", URL: "https://github.com/org/repo/issues/10", Epic: epic}
+	logout := LinkedIssue{Number: 11, Title: "Logout", URL: "https://github.com/org/repo/issues/11", Epic: epic}
+	docs := LinkedIssue{Number: 12, Title: "Docs", URL: "https://github.com/org/repo/issues/12"}
+
+	prs := []PRWithIssues{
+		{
+			Event:        data.Event{Title: "Fix login", URL: "https://github.com/org/repo/pull/1"},
+			LinkedIssues: []LinkedIssue{login},
+		},
+		{
+			Event:        data.Event{Title: "Fix logout", URL: "https://github.com/org/repo/pull/2"},
+			LinkedIssues: []LinkedIssue{logout},
+		},
+		{
+			Event:        data.Event{Title: "Update docs", URL: "https://github.com/org/repo/pull/3"},
+			LinkedIssues: []LinkedIssue{docs},
+		},
+	}
+
+	report := Aggregate(dateRange, prs, nil)
+
+	assert.Len(t, report.IssueGroups, 3)
+	require.Len(t, report.Epics, 1)
+	assert.Equal(t, "Auth revamp", report.Epics[0].Epic.Title)
+	assert.Len(t, report.Epics[0].IssueGroups, 2)
+}
+
 func TestAggregate_Reviews(t *testing.T) {
 	now := time.Now()
 	dateRange := &DateRange{
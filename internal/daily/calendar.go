@@ -0,0 +1,182 @@
+package daily
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Calendar decides which days count as working days for the default range
+type Calendar struct {
+	WorkDays [7]bool         // Indexed by time.Weekday
+	Holidays map[string]bool // YYYY-MM-DD dates that are never working days
+}
+
+// DefaultCalendar treats Monday through Friday as working days with no holidays
+func DefaultCalendar() *Calendar {
+	c := &Calendar{}
+	for d := time.Monday; d <= time.Friday; d++ {
+		c.WorkDays[d] = true
+	}
+	return c
+}
+
+// NewCalendar builds a calendar from weekday names (e.g. "monday", "Tue").
+// An empty list keeps the Monday to Friday default.
+func NewCalendar(workDays []string, holidays []string) (*Calendar, error) {
+	c := DefaultCalendar()
+	if len(workDays) > 0 {
+		c.WorkDays = [7]bool{}
+		for _, name := range workDays {
+			d, err := ParseWeekday(name)
+			if err != nil {
+				return nil, err
+			}
+			c.WorkDays[d] = true
+		}
+	}
+	if len(holidays) > 0 {
+		c.Holidays = make(map[string]bool, len(holidays))
+		for _, h := range holidays {
+			c.Holidays[h] = true
+		}
+	}
+	return c, nil
+}
+
+// IsWorkday reports whether day is a working day and not a holiday
+func (c *Calendar) IsWorkday(day time.Time) bool {
+	return c.WorkDays[day.Weekday()] && !c.Holidays[day.Format("2006-01-02")]
+}
+
+// previousWorkday returns the latest working day before today, or false if
+// none is found within a year (e.g. no working days configured)
+func (c *Calendar) previousWorkday(today time.Time) (time.Time, bool) {
+	for i := 1; i <= 366; i++ {
+		day := today.AddDate(0, 0, -i)
+		if c.IsWorkday(day) {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// holidayEntry is one item of a YAML holiday list: either a bare date or {date, name}
+type holidayEntry struct {
+	Date string `yaml:"date"`
+	Name string `yaml:"name"`
+}
+
+func (h *holidayEntry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		h.Date = node.Value
+		return nil
+	}
+	type plain holidayEntry
+	return node.Decode((*plain)(h))
+}
+
+// LoadHolidays reads holiday dates (YYYY-MM-DD) from an iCalendar (.ics) file
+// or a YAML list of dates
+func LoadHolidays(path string) ([]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ics", ".ical":
+		return parseICSHolidays(raw)
+	case ".yaml", ".yml":
+		return parseYAMLHolidays(raw)
+	default:
+		return nil, fmt.Errorf("unsupported holiday file %q: use .ics or .yaml", path)
+	}
+}
+
+func parseYAMLHolidays(raw []byte) ([]string, error) {
+	var entries []holidayEntry
+	if err := yaml.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse holidays: %w", err)
+	}
+
+	dates := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if _, err := time.Parse("2006-01-02", e.Date); err != nil {
+			return nil, fmt.Errorf("invalid holiday date %q: %w", e.Date, err)
+		}
+		dates = append(dates, e.Date)
+	}
+	return dates, nil
+}
+
+// parseICSHolidays collects the DTSTART date of every VEVENT.
+// Multi-day events (DTEND after the next day) cover every day up to DTEND, exclusive.
+func parseICSHolidays(raw []byte) ([]string, error) {
+	var dates []string
+	var start, end time.Time
+	inEvent := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Drop parameters such as DTSTART;VALUE=DATE
+		name, _, _ = strings.Cut(name, ";")
+
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				start, end = time.Time{}, time.Time{}
+			}
+		case "DTSTART":
+			if inEvent {
+				start = parseICSDate(value)
+			}
+		case "DTEND":
+			if inEvent {
+				end = parseICSDate(value)
+			}
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				continue
+			}
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+				dates = append(dates, d.Format("2006-01-02"))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read holidays: %w", err)
+	}
+	return dates, nil
+}
+
+// parseICSDate reads the date part of an iCalendar DATE or DATE-TIME value
+func parseICSDate(value string) time.Time {
+	if len(value) < 8 {
+		return time.Time{}
+	}
+	d, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}
+	}
+	return d
+}
@@ -0,0 +1,87 @@
+package daily
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCalendar(t *testing.T) {
+	cal, err := NewCalendar(nil, []string{"2026-12-25"})
+	require.NoError(t, err)
+
+	assert.True(t, cal.IsWorkday(time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, cal.IsWorkday(time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, cal.IsWorkday(time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC)))
+
+	cal, err = NewCalendar([]string{"sunday", "Thu"}, nil)
+	require.NoError(t, err)
+	assert.True(t, cal.WorkDays[time.Sunday])
+	assert.True(t, cal.WorkDays[time.Thursday])
+	assert.False(t, cal.WorkDays[time.Monday])
+
+	_, err = NewCalendar([]string{"someday"}, nil)
+	assert.Error(t, err)
+}
+
+func TestLoadHolidays(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		want        []string
+		errContains string
+	}{
+		{
+			name: "ics all-day and multi-day events",
+			file: "holidays.ics",
+			content: "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20261225\r\nDTEND;VALUE=DATE:20261226\r\nSUMMARY:Christmas\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20261230\r\nDTEND;VALUE=DATE:20270102\r\nSUMMARY:Shutdown\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nDTSTART:20260501T000000Z\r\nSUMMARY:May Day\r\nEND:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []string{"2026-12-25", "2026-12-30", "2026-12-31", "2027-01-01", "2026-05-01"},
+		},
+		{
+			name:    "yaml dates and entries",
+			file:    "holidays.yaml",
+			content: "- 2026-12-25\n- date: 2026-12-26\n  name: Boxing Day\n",
+			want:    []string{"2026-12-25", "2026-12-26"},
+		},
+		{
+			name:        "yaml invalid date",
+			file:        "bad.yml",
+			content:     "- christmas\n",
+			errContains: "invalid holiday date",
+		},
+		{
+			name:        "unsupported extension",
+			file:        "holidays.txt",
+			content:     "2026-12-25\n",
+			errContains: "unsupported holiday file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			got, err := LoadHolidays(path)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,40 +2,87 @@ package daily
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
+// Periods lists the supported --period values
+var Periods = []string{"week", "last-week", "sprint", "month", "quarter"}
+
 // DateRange represents a time range for the daily report
 type DateRange struct {
-	Start time.Time
-	End   time.Time
-	Label string // YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD
+	Start    time.Time
+	End      time.Time
+	Label    string         // YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD
+	Location *time.Location // Timezone used for day boundaries
 }
 
-// ComputeRange calculates the date range based on the provided parameters.
-// If date is provided, it returns that single day's range.
+// RangeOptions selects the report range.
+// Date, From/To, Period and SinceLast are mutually exclusive; with none set the range
+// covers everything since the previous working day.
+type RangeOptions struct {
+	Date      string
+	From      string
+	To        string
+	Period    string    // One of Periods
+	SinceLast bool      // Start where the last report ended
+	LastEnd   time.Time // End of the last generated report (used with SinceLast)
+	TZ        string    // IANA timezone name
+
+	WeekStart    time.Weekday // First day of the week for week periods
+	SprintAnchor string       // YYYY-MM-DD on which any sprint started
+	SprintLength int          // Sprint length in days
+
+	Calendar *Calendar // Working days for the default range; nil uses DefaultCalendar
+
+	Now time.Time // Reference time; zero uses the current time
+}
+
+// ComputeRange calculates the date range based on the provided options.
+// If a date is provided, it returns that single day's range.
 // If from/to are provided, it returns that range.
-// If nothing is provided, it returns yesterday's range.
-func ComputeRange(date, from, to, tzName string) (*DateRange, error) {
-	loc, err := loadLocation(tzName)
+// If a period is provided, it returns that calendar period up to the end of today.
+// If since-last is set, it returns the time since the last report ended.
+// If nothing is provided, it returns the range from the previous working day up to today,
+// so a Monday report covers Friday through Sunday.
+func ComputeRange(opts RangeOptions) (*DateRange, error) {
+	loc, err := loadLocation(opts.TZ)
 	if err != nil {
 		return nil, err
 	}
 
-	now := time.Now().In(loc)
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.In(loc)
 
 	// Single date mode
-	if date != "" {
-		return computeSingleDate(date, loc)
+	if opts.Date != "" {
+		return computeSingleDate(opts.Date, loc)
 	}
 
 	// Range mode
-	if from != "" && to != "" {
-		return computeRangeFromTo(from, to, loc)
+	if opts.From != "" && opts.To != "" {
+		return computeRangeFromTo(opts.From, opts.To, loc)
 	}
 
-	// Default: yesterday
-	return computeYesterday(now, loc)
+	// Period mode
+	if opts.Period != "" {
+		return computePeriod(opts, now, loc)
+	}
+
+	// Since last report
+	if opts.SinceLast && !opts.LastEnd.IsZero() {
+		return computeSinceLast(opts.LastEnd, now, loc)
+	}
+
+	// Default: since the previous working day
+	cal := opts.Calendar
+	if cal == nil {
+		cal = DefaultCalendar()
+	}
+	return computeSinceWorkday(now, cal, loc)
 }
 
 func loadLocation(tzName string) (*time.Location, error) {
@@ -59,9 +106,10 @@ func computeSingleDate(date string, loc *time.Location) (*DateRange, error) {
 	end := start.AddDate(0, 0, 1)
 
 	return &DateRange{
-		Start: start.UTC(),
-		End:   end.UTC(),
-		Label: date,
+		Start:    start.UTC(),
+		End:      end.UTC(),
+		Label:    date,
+		Location: loc,
 	}, nil
 }
 
@@ -83,31 +131,138 @@ func computeRangeFromTo(from, to string, loc *time.Location) (*DateRange, error)
 	start := time.Date(fromDate.Year(), fromDate.Month(), fromDate.Day(), 0, 0, 0, 0, loc)
 	end := time.Date(toDate.Year(), toDate.Month(), toDate.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
 
+	return dayRange(start, end, loc), nil
+}
+
+func computePeriod(opts RangeOptions, now time.Time, loc *time.Location) (*DateRange, error) {
+	today := startOfDay(now, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	switch opts.Period {
+	case "week":
+		return dayRange(startOfWeek(today, opts.WeekStart), tomorrow, loc), nil
+
+	case "last-week":
+		thisWeek := startOfWeek(today, opts.WeekStart)
+		return dayRange(thisWeek.AddDate(0, 0, -7), thisWeek, loc), nil
+
+	case "sprint":
+		if opts.SprintAnchor == "" || opts.SprintLength <= 0 {
+			return nil, fmt.Errorf("--period sprint needs a sprint anchor date and length")
+		}
+		anchor, err := time.ParseInLocation("2006-01-02", opts.SprintAnchor, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sprint anchor %q: %w", opts.SprintAnchor, err)
+		}
+		// Whole days between anchor and today, rounded down to a sprint boundary
+		days := daysBetween(anchor, today)
+		offset := days % opts.SprintLength
+		if offset < 0 {
+			offset += opts.SprintLength
+		}
+		return dayRange(today.AddDate(0, 0, -offset), tomorrow, loc), nil
+
+	case "month":
+		return dayRange(time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, loc), tomorrow, loc), nil
+
+	case "quarter":
+		firstMonth := time.Month((int(today.Month())-1)/3*3 + 1)
+		return dayRange(time.Date(today.Year(), firstMonth, 1, 0, 0, 0, 0, loc), tomorrow, loc), nil
+
+	default:
+		return nil, fmt.Errorf("invalid period %q: must be one of %v", opts.Period, Periods)
+	}
+}
+
+func computeSinceLast(lastEnd, now time.Time, loc *time.Location) (*DateRange, error) {
+	if !lastEnd.Before(now) {
+		return nil, fmt.Errorf("last report ended at %s, which is not in the past", lastEnd.In(loc).Format(time.RFC3339))
+	}
+
+	start := lastEnd.In(loc)
+	label := start.Format("2006-01-02")
+	if to := now.Format("2006-01-02"); to != label {
+		label = fmt.Sprintf("%s..%s", label, to)
+	}
+
+	return &DateRange{
+		Start:    start.UTC(),
+		End:      now.UTC(),
+		Label:    label,
+		Location: loc,
+	}, nil
+}
+
+func computeSinceWorkday(now time.Time, cal *Calendar, loc *time.Location) (*DateRange, error) {
+	today := startOfDay(now, loc)
+
+	start, ok := cal.previousWorkday(today)
+	if !ok {
+		// No working day configured: fall back to plain yesterday
+		start = today.AddDate(0, 0, -1)
+	}
+
+	return dayRange(start, today, loc), nil
+}
+
+// dayRange builds a range from midnight start up to midnight end (exclusive),
+// labelled with the first and last covered day
+func dayRange(start, end time.Time, loc *time.Location) *DateRange {
+	from := start.Format("2006-01-02")
+	to := end.AddDate(0, 0, -1).Format("2006-01-02")
+
 	label := from
 	if from != to {
 		label = fmt.Sprintf("%s..%s", from, to)
 	}
 
 	return &DateRange{
-		Start: start.UTC(),
-		End:   end.UTC(),
-		Label: label,
-	}, nil
+		Start:    start.UTC(),
+		End:      end.UTC(),
+		Label:    label,
+		Location: loc,
+	}
 }
 
-func computeYesterday(now time.Time, loc *time.Location) (*DateRange, error) {
-	yesterday := now.AddDate(0, 0, -1)
-	start := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, loc)
-	end := start.AddDate(0, 0, 1)
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
 
-	return &DateRange{
-		Start: start.UTC(),
-		End:   end.UTC(),
-		Label: yesterday.Format("2006-01-02"),
-	}, nil
+// startOfWeek returns the most recent weekStart day on or before day
+func startOfWeek(day time.Time, weekStart time.Weekday) time.Time {
+	back := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	return day.AddDate(0, 0, -back)
+}
+
+// daysBetween counts calendar days from a to b, ignoring DST shifts
+func daysBetween(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua).Hours() / 24)
+}
+
+// MultiDay reports whether the range covers more than one calendar day
+func (r *DateRange) MultiDay() bool {
+	loc := r.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return startOfDay(r.Start, loc).AddDate(0, 0, 1).Before(r.End)
 }
 
 // FormatStartForGitHub returns just the start date for open-ended queries (>= start)
 func (r *DateRange) FormatStartForGitHub() string {
 	return ">=" + r.Start.Format("2006-01-02T15:04:05Z")
 }
+
+// ParseWeekday parses a weekday name such as "monday" or "Mon"
+func ParseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := d.String()
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
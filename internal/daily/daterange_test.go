@@ -45,7 +45,7 @@ func TestComputeRange_SingleDate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ComputeRange(tt.date, "", "", tt.tz)
+			result, err := ComputeRange(RangeOptions{Date: tt.date, TZ: tt.tz})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -107,7 +107,7 @@ func TestComputeRange_FromTo(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ComputeRange("", tt.from, tt.to, "")
+			result, err := ComputeRange(RangeOptions{From: tt.from, To: tt.to})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -123,12 +123,183 @@ func TestComputeRange_FromTo(t *testing.T) {
 }
 
 func TestComputeRange_Default(t *testing.T) {
-	result, err := ComputeRange("", "", "", "")
+	holidays, err := NewCalendar(nil, []string{"2026-05-08"})
+	require.NoError(t, err)
+	sixDays, err := NewCalendar([]string{"mon", "tue", "wed", "thu", "fri", "sat"}, nil)
+	require.NoError(t, err)
+	none, err := NewCalendar([]string{"sun"}, nil)
+	require.NoError(t, err)
+	none.WorkDays = [7]bool{}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		calendar  *Calendar
+		wantLabel string
+		wantDays  int
+	}{
+		{
+			name:      "tuesday reports monday",
+			now:       time.Date(2026, 5, 12, 9, 0, 0, 0, time.UTC),
+			wantLabel: "2026-05-11",
+			wantDays:  1,
+		},
+		{
+			name:      "monday reports friday through sunday",
+			now:       time.Date(2026, 5, 11, 9, 0, 0, 0, time.UTC),
+			wantLabel: "2026-05-08..2026-05-10",
+			wantDays:  3,
+		},
+		{
+			name:      "holiday friday is skipped",
+			now:       time.Date(2026, 5, 11, 9, 0, 0, 0, time.UTC),
+			calendar:  holidays,
+			wantLabel: "2026-05-07..2026-05-10",
+			wantDays:  4,
+		},
+		{
+			name:      "saturday working day",
+			now:       time.Date(2026, 5, 11, 9, 0, 0, 0, time.UTC),
+			calendar:  sixDays,
+			wantLabel: "2026-05-09..2026-05-10",
+			wantDays:  2,
+		},
+		{
+			name:      "no working days falls back to yesterday",
+			now:       time.Date(2026, 5, 11, 9, 0, 0, 0, time.UTC),
+			calendar:  none,
+			wantLabel: "2026-05-10",
+			wantDays:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ComputeRange(RangeOptions{TZ: "UTC", Now: tt.now, Calendar: tt.calendar})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLabel, result.Label)
+			assert.Equal(t, time.Duration(tt.wantDays)*24*time.Hour, result.End.Sub(result.Start))
+		})
+	}
+}
+
+func TestComputeRange_Period(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 5, 13, 15, 30, 0, 0, time.UTC)
 
+	tests := []struct {
+		name        string
+		opts        RangeOptions
+		wantLabel   string
+		wantDays    int
+		errContains string
+	}{
+		{
+			name:      "week from monday",
+			opts:      RangeOptions{Period: "week", WeekStart: time.Monday},
+			wantLabel: "2026-05-11..2026-05-13",
+			wantDays:  3,
+		},
+		{
+			name:      "week from sunday",
+			opts:      RangeOptions{Period: "week", WeekStart: time.Sunday},
+			wantLabel: "2026-05-10..2026-05-13",
+			wantDays:  4,
+		},
+		{
+			name:      "last week",
+			opts:      RangeOptions{Period: "last-week", WeekStart: time.Monday},
+			wantLabel: "2026-05-04..2026-05-10",
+			wantDays:  7,
+		},
+		{
+			name:      "sprint started by anchor cadence",
+			opts:      RangeOptions{Period: "sprint", SprintAnchor: "2026-01-05", SprintLength: 14},
+			wantLabel: "2026-05-11..2026-05-13",
+			wantDays:  3,
+		},
+		{
+			name:      "sprint anchor in the future",
+			opts:      RangeOptions{Period: "sprint", SprintAnchor: "2026-06-08", SprintLength: 14},
+			wantLabel: "2026-05-11..2026-05-13",
+			wantDays:  3,
+		},
+		{
+			name:      "month",
+			opts:      RangeOptions{Period: "month"},
+			wantLabel: "2026-05-01..2026-05-13",
+			wantDays:  13,
+		},
+		{
+			name:      "quarter",
+			opts:      RangeOptions{Period: "quarter"},
+			wantLabel: "2026-04-01..2026-05-13",
+			wantDays:  43,
+		},
+		{
+			name:        "sprint without anchor",
+			opts:        RangeOptions{Period: "sprint"},
+			errContains: "needs a sprint anchor",
+		},
+		{
+			name:        "unknown period",
+			opts:        RangeOptions{Period: "fortnight"},
+			errContains: "invalid period",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Now = now
+			tt.opts.TZ = "UTC"
+			result, err := ComputeRange(tt.opts)
+
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLabel, result.Label)
+			assert.Equal(t, time.Duration(tt.wantDays)*24*time.Hour, result.End.Sub(result.Start))
+			assert.True(t, result.MultiDay())
+		})
+	}
+}
+
+func TestComputeRange_SinceLast(t *testing.T) {
+	now := time.Date(2026, 5, 13, 9, 0, 0, 0, time.UTC)
+
+	result, err := ComputeRange(RangeOptions{
+		SinceLast: true,
+		LastEnd:   time.Date(2026, 5, 11, 17, 0, 0, 0, time.UTC),
+		TZ:        "UTC",
+		Now:       now,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-05-11..2026-05-13", result.Label)
+	assert.Equal(t, now, result.End)
+
+	// Nothing recorded yet falls back to the previous working day
+	result, err = ComputeRange(RangeOptions{SinceLast: true, TZ: "UTC", Now: now})
 	require.NoError(t, err)
-	assert.NotEmpty(t, result.Label)
-	assert.True(t, result.End.After(result.Start))
-	assert.Equal(t, 24*time.Hour, result.End.Sub(result.Start))
+	assert.Equal(t, "2026-05-12", result.Label)
+	assert.False(t, result.MultiDay())
+}
+
+func TestParseWeekday(t *testing.T) {
+	d, err := ParseWeekday("sunday")
+	require.NoError(t, err)
+	assert.Equal(t, time.Sunday, d)
+
+	d, err = ParseWeekday("Mon")
+	require.NoError(t, err)
+	assert.Equal(t, time.Monday, d)
+
+	_, err = ParseWeekday("funday")
+	assert.Error(t, err)
 }
 
 func TestDateRange_FormatStartForGitHub(t *testing.T) {
@@ -0,0 +1,119 @@
+package daily
+
+import (
+	"sort"
+	"time"
+)
+
+// MultiDay reports whether the report covers more than one calendar day
+func (r *DailyReport) MultiDay() bool {
+	return r.dateRange != nil && r.dateRange.MultiDay()
+}
+
+// PRs returns every PR in the report, issue-linked ones first
+func (r *DailyReport) PRs() []PullRequest {
+	var prs []PullRequest
+	for _, g := range r.IssueGroups {
+		prs = append(prs, g.PRs...)
+	}
+	return append(prs, r.StandalonePRs...)
+}
+
+// Days splits the report by the day activity happened on, in chronological order.
+// PRs are placed by activity time, reviews by submission time, issue activity by action time
+// and notes by their date; days without activity are omitted.
+func (r *DailyReport) Days() []DayActivity {
+	loc := time.UTC
+	if r.dateRange != nil && r.dateRange.Location != nil {
+		loc = r.dateRange.Location
+	}
+
+	days := make(map[string]*DayActivity)
+	day := func(t time.Time) *DayActivity {
+		key := t.In(loc).Format("2006-01-02")
+		if d, ok := days[key]; ok {
+			return d
+		}
+		d := &DayActivity{Date: t.In(loc).Format("Mon 2006-01-02")}
+		days[key] = d
+		return d
+	}
+
+	for _, group := range r.IssueGroups {
+		// Split each issue group into one group per day its PRs were active
+		perDay := make(map[*DayActivity]*IssueGroup)
+		var order []*DayActivity
+		for _, pr := range group.PRs {
+			d := day(ActivityTime(pr.Event))
+			if g, ok := perDay[d]; ok {
+				g.PRs = append(g.PRs, pr)
+				continue
+			}
+			perDay[d] = &IssueGroup{Issue: group.Issue, PRs: []PullRequest{pr}}
+			order = append(order, d)
+		}
+		for _, d := range order {
+			d.IssueGroups = append(d.IssueGroups, *perDay[d])
+		}
+	}
+
+	for _, pr := range r.StandalonePRs {
+		d := day(ActivityTime(pr.Event))
+		d.StandalonePRs = append(d.StandalonePRs, pr)
+	}
+
+	for _, review := range r.ExtraReviews {
+		perDay := make(map[*DayActivity]*ExtraReview)
+		var order []*DayActivity
+		for _, info := range review.Reviews {
+			d := day(info.SubmittedAt)
+			if er, ok := perDay[d]; ok {
+				er.Reviews = append(er.Reviews, info)
+				continue
+			}
+			er := review
+			er.Reviews = []ReviewInfo{info}
+			perDay[d] = &er
+			order = append(order, d)
+		}
+		for _, d := range order {
+			d.ExtraReviews = append(d.ExtraReviews, *perDay[d])
+		}
+	}
+
+	for _, activity := range r.IssueActivity {
+		perDay := make(map[*DayActivity]*IssueActivity)
+		var order []*DayActivity
+		for _, act := range activity.Actions {
+			d := day(act.At)
+			if ia, ok := perDay[d]; ok {
+				ia.Actions = append(ia.Actions, act)
+				continue
+			}
+			ia := activity
+			ia.Actions = []IssueAction{act}
+			perDay[d] = &ia
+			order = append(order, d)
+		}
+		for _, d := range order {
+			d.IssueActivity = append(d.IssueActivity, *perDay[d])
+		}
+	}
+
+	for _, n := range r.Notes {
+		d := day(noteDay(n, loc))
+		d.Notes = append(d.Notes, n)
+	}
+
+	keys := make([]string, 0, len(days))
+	for k := range days {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]DayActivity, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, *days[k])
+	}
+	return result
+}
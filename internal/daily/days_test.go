@@ -0,0 +1,84 @@
+package daily
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDays(t *testing.T) {
+	mon := time.Date(2026, 5, 11, 10, 0, 0, 0, time.UTC)
+	tue := mon.AddDate(0, 0, 1)
+	dateRange := &DateRange{
+		Start:    time.Date(2026, 5, 11, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC),
+		Label:    "2026-05-11..2026-05-12",
+		Location: time.UTC,
+	}
+
+	issue := LinkedIssue{Number: 1, Title: "Epic bug", URL: "https://github.com/org/repo/issues/1"}
+	prs := []PRWithIssues{
+		{Event: data.Event{Title: "Part 1", URL: "u1", Timestamps: data.Timestamps{UpdatedAt: mon}}, LinkedIssues: []LinkedIssue{issue}},
+		{Event: data.Event{Title: "Part 2", URL: "u2", Timestamps: data.Timestamps{UpdatedAt: tue}}, LinkedIssues: []LinkedIssue{issue}},
+		{Event: data.Event{Title: "Standalone", URL: "u3", Timestamps: data.Timestamps{UpdatedAt: tue}}},
+	}
+	reviews := []ReviewedPR{
+		{
+			Event: data.Event{Title: "Someone's PR", URL: "u4", Repo: "org/repo"},
+			Reviews: []ReviewInfo{
+				{State: "COMMENTED", SubmittedAt: mon},
+				{State: "APPROVED", SubmittedAt: tue},
+			},
+		},
+	}
+
+	report := Aggregate(dateRange, prs, reviews)
+	report.IssueActivity = []IssueActivity{
+		{
+			Event:   data.Event{Title: "Triage me", URL: "u5"},
+			Actions: []IssueAction{{Kind: "labeled", Detail: "bug", At: mon}, {Kind: "closed", At: tue}},
+		},
+	}
+	report.Notes = []data.Event{
+		{ID: "note:1", Kind: "note", Title: "Gave a talk", Timestamps: data.Timestamps{CreatedAt: time.Date(2026, 5, 12, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60))}},
+	}
+	require.True(t, report.MultiDay())
+
+	days := report.Days()
+	require.Len(t, days, 2)
+
+	assert.Equal(t, "Mon 2026-05-11", days[0].Date)
+	require.Len(t, days[0].IssueGroups, 1)
+	assert.Equal(t, "Part 1", days[0].IssueGroups[0].PRs[0].Title)
+	assert.Empty(t, days[0].StandalonePRs)
+	require.Len(t, days[0].ExtraReviews, 1)
+	assert.Equal(t, "COMMENTED", days[0].ExtraReviews[0].Reviews[0].State)
+
+	assert.Equal(t, "Tue 2026-05-12", days[1].Date)
+	require.Len(t, days[1].IssueGroups, 1)
+	assert.Equal(t, "Part 2", days[1].IssueGroups[0].PRs[0].Title)
+	assert.Len(t, days[1].StandalonePRs, 1)
+	require.Len(t, days[1].ExtraReviews, 1)
+	assert.Equal(t, "APPROVED", days[1].ExtraReviews[0].Reviews[0].State)
+
+	require.Len(t, days[0].IssueActivity, 1)
+	assert.Equal(t, "labeled bug", days[0].IssueActivity[0].ActionSummary())
+	require.Len(t, days[1].IssueActivity, 1)
+	assert.Equal(t, "closed", days[1].IssueActivity[0].ActionSummary())
+
+	assert.Empty(t, days[0].Notes)
+	require.Len(t, days[1].Notes, 1, "notes are placed by their date, not converted to the report's zone")
+	assert.Equal(t, "Gave a talk", days[1].Notes[0].Title)
+
+	out, err := RenderPlain(report)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Report (2026-05-11..2026-05-12)")
+	assert.Contains(t, out, "Mon 2026-05-11\n• Issue: Epic bug")
+	assert.Contains(t, out, "Mon 2026-05-11\n• COMMENTED")
+	assert.Contains(t, out, "Tue 2026-05-12\n• APPROVED")
+	assert.Contains(t, out, "Issue Activity\nMon 2026-05-11\n• Triage me (labeled bug)")
+	assert.Contains(t, out, "Notes\nTue 2026-05-12\n• Gave a talk")
+}
@@ -2,20 +2,22 @@ package daily
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/jackchuka/gh-brag/internal/data"
 	"github.com/jackchuka/gh-brag/internal/github"
+	"github.com/jackchuka/gh-brag/internal/scope"
 )
 
 // FetchAuthoredPRs fetches PRs authored by the current user with linked issues
 // Uses open-ended query (updated >= start) and filters locally by activity time
-func FetchAuthoredPRs(dateRange *DateRange, includeLinkedIssues bool, orgs []string) ([]PRWithIssues, error) {
+func FetchAuthoredPRs(dateRange *DateRange, includeLinkedIssues bool, sc scope.Scope) ([]PRWithIssues, error) {
 	// Open-ended query: fetch all PRs updated since start, filter end locally
 	baseQuery := fmt.Sprintf("author:@me is:pr updated:%s", dateRange.FormatStartForGitHub())
 
-	// Build list of queries (one per org, or single query if no orgs)
-	queries := buildQueries(baseQuery, orgs)
+	// Build list of queries (one per org or repo in scope)
+	queries := sc.Queries(baseQuery)
 
 	var results []PRWithIssues
 	seen := make(map[string]bool)
@@ -45,15 +47,20 @@ func FetchAuthoredPRs(dateRange *DateRange, includeLinkedIssues bool, orgs []str
 			}
 
 			evt := data.Event{
-				ID:     fmt.Sprintf("pr:%s:%s", n.URL, action),
-				Action: action,
-				Kind:   "pr",
-				URL:    n.URL,
-				Repo:   n.Repository.NameWithOwner,
-				Number: n.Number,
-				Title:  n.Title,
-				Body:   n.Body,
-				Author: n.Author.Login,
+				ID:        fmt.Sprintf("pr:%s:%s", n.URL, action),
+				Action:    action,
+				Kind:      "pr",
+				URL:       n.URL,
+				Repo:      n.Repository.NameWithOwner,
+				Private:   n.Repository.IsPrivate,
+				Fork:      n.Repository.IsFork,
+				Archived:  n.Repository.IsArchived,
+				Number:    n.Number,
+				Title:     n.Title,
+				Body:      n.Body,
+				Author:    n.Author.Login,
+				AuthorBot: n.Author.Typename == "Bot",
+				Branch:    n.HeadRef,
 				Timestamps: data.Timestamps{
 					CreatedAt: n.CreatedAt,
 					UpdatedAt: n.UpdatedAt,
@@ -72,28 +79,20 @@ func FetchAuthoredPRs(dateRange *DateRange, includeLinkedIssues bool, orgs []str
 				continue
 			}
 
-			// Extract labels
-			labels := make([]string, 0, len(n.Labels.Nodes))
-			for _, l := range n.Labels.Nodes {
-				labels = append(labels, l.Name)
-			}
-			evt.Labels = labels
+			evt.Labels = labelNames(n)
 
 			// Extract linked issues
 			var linkedIssues []LinkedIssue
 			if includeLinkedIssues {
 				for _, issue := range n.ClosingIssuesReferences.Nodes {
-					linkedIssues = append(linkedIssues, LinkedIssue{
-						Number: issue.Number,
-						Title:  issue.Title,
-						URL:    issue.URL,
-					})
+					linkedIssues = append(linkedIssues, linkedIssue(issue))
 				}
 			}
 
 			results = append(results, PRWithIssues{
 				Event:        evt,
 				LinkedIssues: linkedIssues,
+				Status:       prStatus(n),
 			})
 		}
 	}
@@ -103,12 +102,12 @@ func FetchAuthoredPRs(dateRange *DateRange, includeLinkedIssues bool, orgs []str
 
 // FetchReviewedPRs fetches PRs reviewed by the current user with review details
 // Uses open-ended query (updated >= start) and filters locally by review submittedAt
-func FetchReviewedPRs(dateRange *DateRange, currentUser string, orgs []string) ([]ReviewedPR, error) {
+func FetchReviewedPRs(dateRange *DateRange, currentUser string, sc scope.Scope) ([]ReviewedPR, error) {
 	// Open-ended query: fetch all reviewed PRs since start, filter end locally by submittedAt
 	baseQuery := fmt.Sprintf("is:pr reviewed-by:@me updated:%s -author:@me", dateRange.FormatStartForGitHub())
 
-	// Build list of queries (one per org, or single query if no orgs)
-	queries := buildQueries(baseQuery, orgs)
+	// Build list of queries (one per org or repo in scope)
+	queries := sc.Queries(baseQuery)
 
 	var results []ReviewedPR
 	seen := make(map[string]bool)
@@ -131,71 +130,297 @@ func FetchReviewedPRs(dateRange *DateRange, currentUser string, orgs []string) (
 			}
 			seen[n.URL] = true
 
-			evt := data.Event{
-				ID:     fmt.Sprintf("pr:%s:reviewed", n.URL),
-				Action: data.EventActionReviewed,
-				Kind:   "pr",
-				URL:    n.URL,
-				Repo:   n.Repository.NameWithOwner,
-				Number: n.Number,
-				Title:  n.Title,
-				Author: n.Author.Login,
-				Timestamps: data.Timestamps{
-					CreatedAt: n.CreatedAt,
-					UpdatedAt: n.UpdatedAt,
-					ClosedAt:  n.ClosedAt,
-				},
-				Source: data.Source{
-					Tool:      "gh api graphql",
-					Query:     query,
-					FetchedAt: fetchedAt,
-				},
+			if pr, ok := reviewedPR(n, query, currentUser, dateRange, fetchedAt); ok {
+				results = append(results, pr)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// reviewedPR builds the reviewed event for a PR with the current user's reviews
+// submitted in the range; ok is false if there are none
+func reviewedPR(n github.SearchNode, query, currentUser string, dateRange *DateRange, fetchedAt time.Time) (ReviewedPR, bool) {
+	evt := data.Event{
+		ID:        fmt.Sprintf("pr:%s:reviewed", n.URL),
+		Action:    data.EventActionReviewed,
+		Kind:      "pr",
+		URL:       n.URL,
+		Repo:      n.Repository.NameWithOwner,
+		Private:   n.Repository.IsPrivate,
+		Fork:      n.Repository.IsFork,
+		Archived:  n.Repository.IsArchived,
+		Number:    n.Number,
+		Title:     n.Title,
+		Author:    n.Author.Login,
+		AuthorBot: n.Author.Typename == "Bot",
+		Labels:    labelNames(n),
+		Timestamps: data.Timestamps{
+			CreatedAt: n.CreatedAt,
+			UpdatedAt: n.UpdatedAt,
+			ClosedAt:  n.ClosedAt,
+		},
+		Source: data.Source{
+			Tool:      "gh api graphql",
+			Query:     query,
+			FetchedAt: fetchedAt,
+		},
+	}
+
+	// Extract reviews by the current user within the date range
+	var reviews []ReviewInfo
+	for _, r := range n.Reviews.Nodes {
+		// Filter to only include reviews by the current user
+		if r.Author.Login == currentUser || currentUser == "" {
+			// Skip PENDING reviews
+			if r.State == "PENDING" {
+				continue
+			}
+			// Filter by date range
+			if r.SubmittedAt.Before(dateRange.Start) || !r.SubmittedAt.Before(dateRange.End) {
+				continue
 			}
+			reviews = append(reviews, ReviewInfo{
+				State:       r.State,
+				SubmittedAt: r.SubmittedAt,
+				URL:         r.URL,
+			})
+		}
+	}
 
-			// Extract reviews by the current user within the date range
-			var reviews []ReviewInfo
-			for _, r := range n.Reviews.Nodes {
-				// Filter to only include reviews by the current user
-				if r.Author.Login == currentUser || currentUser == "" {
-					// Skip PENDING reviews
-					if r.State == "PENDING" {
-						continue
-					}
-					// Filter by date range
-					if r.SubmittedAt.Before(dateRange.Start) || !r.SubmittedAt.Before(dateRange.End) {
-						continue
-					}
-					reviews = append(reviews, ReviewInfo{
-						State:       r.State,
-						SubmittedAt: r.SubmittedAt,
-						URL:         r.URL,
-					})
-				}
+	// Only include if there are reviews
+	if len(reviews) == 0 {
+		return ReviewedPR{}, false
+	}
+	return ReviewedPR{Event: evt, Reviews: reviews}, true
+}
+
+// labelNames returns the names of a node's labels, as collect records them
+func labelNames(n github.SearchNode) []string {
+	labels := make([]string, 0, len(n.Labels.Nodes))
+	for _, l := range n.Labels.Nodes {
+		labels = append(labels, l.Name)
+	}
+	return labels
+}
+
+// FetchIssueActivity fetches issues the current user opened, commented on, closed,
+// reopened, labelled or assigned within the range.
+// Search can only find issues that involve the user (author, assignee, commenter or mention),
+// so labelling or assigning an unrelated issue without commenting is not picked up.
+func FetchIssueActivity(dateRange *DateRange, currentUser string, sc scope.Scope) ([]IssueActivity, error) {
+	baseQuery := fmt.Sprintf("is:issue involves:@me updated:%s", dateRange.FormatStartForGitHub())
+
+	var results []IssueActivity
+	seen := make(map[string]bool)
+	fetchedAt := time.Now()
+
+	for _, query := range sc.Queries(baseQuery) {
+		nodes, err := github.RunSearch(query, github.QueryIssueActivity)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range nodes {
+			if n.Typename != "Issue" || seen[n.URL] {
+				continue
+			}
+			seen[n.URL] = true
+
+			actions := issueActions(n, currentUser, dateRange)
+			if len(actions) == 0 {
+				continue
+			}
+
+			evt := openEvent("issue", "", n, query, fetchedAt)
+			evt.ID = fmt.Sprintf("issue:%s:activity", n.URL)
+			evt.Timestamps.ClosedAt = n.ClosedAt
+			results = append(results, IssueActivity{Event: evt, Actions: actions})
+		}
+	}
+
+	// Most recent activity first
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Actions[len(results[i].Actions)-1].At.After(results[j].Actions[len(results[j].Actions)-1].At)
+	})
+	return results, nil
+}
+
+// issueActions extracts the user's actions on an issue within the range, in chronological order
+func issueActions(n github.SearchNode, currentUser string, dateRange *DateRange) []IssueAction {
+	inRange := func(t time.Time) bool {
+		return !t.Before(dateRange.Start) && t.Before(dateRange.End)
+	}
+
+	var actions []IssueAction
+	if n.Author.Login == currentUser && inRange(n.CreatedAt) {
+		actions = append(actions, IssueAction{Kind: "opened", At: n.CreatedAt})
+	}
+
+	for _, item := range n.TimelineItems.Nodes {
+		if !inRange(item.CreatedAt) {
+			continue
+		}
+
+		var action IssueAction
+		switch item.Typename {
+		case "IssueComment":
+			if item.Author.Login != currentUser {
+				continue
 			}
+			action = IssueAction{Kind: "commented", URL: item.URL}
+		case "ClosedEvent":
+			action = IssueAction{Kind: "closed"}
+		case "ReopenedEvent":
+			action = IssueAction{Kind: "reopened"}
+		case "LabeledEvent":
+			action = IssueAction{Kind: "labeled", Detail: item.Label.Name}
+		case "AssignedEvent":
+			action = IssueAction{Kind: "assigned", Detail: item.Assignee.Login}
+		default:
+			continue
+		}
+		if item.Typename != "IssueComment" && item.Actor.Login != currentUser {
+			continue
+		}
+
+		action.At = item.CreatedAt
+		actions = append(actions, action)
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].At.Before(actions[j].At)
+	})
+	return actions
+}
 
-			// Only include if there are reviews
-			if len(reviews) > 0 {
-				results = append(results, ReviewedPR{
-					Event:   evt,
-					Reviews: reviews,
-				})
+// FetchOpenPRs fetches the current user's open PRs with their review status
+func FetchOpenPRs(sc scope.Scope) ([]PullRequest, error) {
+	return fetchOpenPRs("is:pr is:open author:@me", data.EventActionAuthored, sc)
+}
+
+// FetchReviewRequests fetches open PRs waiting on the current user's review
+func FetchReviewRequests(sc scope.Scope) ([]PullRequest, error) {
+	return fetchOpenPRs("is:pr is:open review-requested:@me", "", sc)
+}
+
+// fetchOpenPRs runs an open-PR search, most recently updated first.
+// action is empty for PRs the current user didn't author.
+func fetchOpenPRs(baseQuery string, action data.EventAction, sc scope.Scope) ([]PullRequest, error) {
+	var results []PullRequest
+	seen := make(map[string]bool)
+	fetchedAt := time.Now()
+
+	for _, query := range sc.Queries(baseQuery) {
+		nodes, err := github.RunSearch(query, github.QueryOpenPRs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range nodes {
+			if n.Typename != "PullRequest" || seen[n.URL] {
+				continue
 			}
+			seen[n.URL] = true
+
+			evt := openEvent("pr", action, n, query, fetchedAt)
+			results = append(results, PullRequest{
+				Event:  evt,
+				Status: prStatus(n),
+			})
 		}
 	}
 
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Timestamps.UpdatedAt.After(results[j].Timestamps.UpdatedAt)
+	})
 	return results, nil
 }
 
-// buildQueries returns a list of queries to execute.
-// If orgs is empty, returns the base query as-is.
-// Otherwise, returns one query per org with the org filter appended.
-func buildQueries(baseQuery string, orgs []string) []string {
-	if len(orgs) == 0 {
-		return []string{baseQuery}
+// FetchAssignedIssues fetches open issues assigned to the current user, most recently updated first
+func FetchAssignedIssues(sc scope.Scope) ([]data.Event, error) {
+	var results []data.Event
+	seen := make(map[string]bool)
+	fetchedAt := time.Now()
+
+	for _, query := range sc.Queries("is:issue is:open assignee:@me") {
+		nodes, err := github.RunSearch(query, github.QueryAssignedIssues)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range nodes {
+			if n.Typename != "Issue" || seen[n.URL] {
+				continue
+			}
+			seen[n.URL] = true
+			results = append(results, openEvent("issue", "", n, query, fetchedAt))
+		}
 	}
-	queries := make([]string, len(orgs))
-	for i, org := range orgs {
-		queries[i] = baseQuery + fmt.Sprintf(" org:%s", org)
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Timestamps.UpdatedAt.After(results[j].Timestamps.UpdatedAt)
+	})
+	return results, nil
+}
+
+// linkedIssue converts a closing issue reference, including its epic
+func linkedIssue(n github.LinkedIssueNode) LinkedIssue {
+	issue := LinkedIssue{
+		Number: n.Number,
+		Title:  n.Title,
+		URL:    n.URL,
+		Body:   n.Body,
+	}
+	for _, l := range n.Labels.Nodes {
+		issue.Labels = append(issue.Labels, l.Name)
+	}
+	if epic := n.Epic(); epic != nil {
+		issue.Epic = &Epic{
+			Number: epic.Number,
+			Title:  epic.Title,
+			URL:    epic.URL,
+			Body:   epic.Body,
+		}
+	}
+	return issue
+}
+
+// prStatus extracts the review and CI state of a PR search node
+func prStatus(n github.SearchNode) PRStatus {
+	return PRStatus{
+		IsDraft:        n.IsDraft,
+		ReviewDecision: n.ReviewDecision,
+		Mergeable:      n.Mergeable,
+		CIState:        n.CheckState(),
+	}
+}
+
+// openEvent converts an open PR or issue search node into an event
+func openEvent(kind string, action data.EventAction, n github.SearchNode, query string, fetchedAt time.Time) data.Event {
+	return data.Event{
+		ID:        fmt.Sprintf("%s:%s:open", kind, n.URL),
+		Action:    action,
+		Kind:      kind,
+		URL:       n.URL,
+		Repo:      n.Repository.NameWithOwner,
+		Private:   n.Repository.IsPrivate,
+		Fork:      n.Repository.IsFork,
+		Archived:  n.Repository.IsArchived,
+		Number:    n.Number,
+		Title:     n.Title,
+		Author:    n.Author.Login,
+		AuthorBot: n.Author.Typename == "Bot",
+		Labels:    labelNames(n),
+		Timestamps: data.Timestamps{
+			CreatedAt: n.CreatedAt,
+			UpdatedAt: n.UpdatedAt,
+		},
+		Source: data.Source{
+			Tool:      "gh api graphql",
+			Query:     query,
+			FetchedAt: fetchedAt,
+		},
 	}
-	return queries
 }
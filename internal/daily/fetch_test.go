@@ -0,0 +1,123 @@
+package daily
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/github"
+	"github.com/jackchuka/gh-brag/internal/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueActions(t *testing.T) {
+	raw := `{
+		"__typename": "Issue",
+		"url": "https://github.com/org/app/issues/9",
+		"author": {"login": "me"},
+		"createdAt": "2026-05-12T08:00:00Z",
+		"timelineItems": {"nodes": [
+			{"__typename": "LabeledEvent", "createdAt": "2026-05-12T09:00:00Z", "actor": {"login": "me"}, "label": {"name": "bug"}},
+			{"__typename": "LabeledEvent", "createdAt": "2026-05-12T09:01:00Z", "actor": {"login": "bot"}, "label": {"name": "triage"}},
+			{"__typename": "IssueComment", "createdAt": "2026-05-12T10:00:00Z", "url": "https://github.com/org/app/issues/9#c1", "author": {"login": "me"}},
+			{"__typename": "IssueComment", "createdAt": "2026-05-12T10:30:00Z", "author": {"login": "alice"}},
+			{"__typename": "AssignedEvent", "createdAt": "2026-05-12T11:00:00Z", "actor": {"login": "me"}, "assignee": {"login": "alice"}},
+			{"__typename": "ClosedEvent", "createdAt": "2026-05-13T09:00:00Z", "actor": {"login": "me"}},
+			{"__typename": "ReopenedEvent", "createdAt": "2026-05-11T09:00:00Z", "actor": {"login": "me"}}
+		]}
+	}`
+	var n github.SearchNode
+	require.NoError(t, json.Unmarshal([]byte(raw), &n))
+
+	dateRange := &DateRange{
+		Start: time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC),
+	}
+
+	actions := issueActions(n, "me", dateRange)
+	require.Len(t, actions, 4)
+	assert.Equal(t, "opened", actions[0].Kind)
+	assert.Equal(t, IssueAction{Kind: "labeled", Detail: "bug", At: time.Date(2026, 5, 12, 9, 0, 0, 0, time.UTC)}, actions[1])
+	assert.Equal(t, "commented", actions[2].Kind)
+	assert.Equal(t, "https://github.com/org/app/issues/9#c1", actions[2].URL)
+	assert.Equal(t, IssueAction{Kind: "assigned", Detail: "alice", At: time.Date(2026, 5, 12, 11, 0, 0, 0, time.UTC)}, actions[3])
+
+	// Someone else's view of the same issue
+	assert.Empty(t, issueActions(n, "bob", dateRange))
+}
+
+func TestLinkedIssue(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		wantEpic *Epic
+	}{
+		{
+			name: "sub-issue parent",
+			raw: `{"number": 5, "title": "Login", "url": "u5", "body": "b",
+				"labels": {"nodes": [{"name": "bug"}]},
+				"parent": {"number": 1, "title": "Auth", "url": "u1", "body": "epic"},
+				"trackedInIssues": {"nodes": [{"number": 2, "title": "Other", "url": "u2"}]}}`,
+			wantEpic: &Epic{Number: 1, Title: "Auth", URL: "u1", Body: "epic"},
+		},
+		{
+			name:     "tracked in issue",
+			raw:      `{"number": 5, "title": "Login", "url": "u5", "trackedInIssues": {"nodes": [{"number": 2, "title": "Other", "url": "u2"}]}}`,
+			wantEpic: &Epic{Number: 2, Title: "Other", URL: "u2"},
+		},
+		{
+			name: "no epic",
+			raw:  `{"number": 5, "title": "Login", "url": "u5"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n github.LinkedIssueNode
+			require.NoError(t, json.Unmarshal([]byte(tt.raw), &n))
+
+			issue := linkedIssue(n)
+			assert.Equal(t, 5, issue.Number)
+			assert.Equal(t, tt.wantEpic, issue.Epic)
+		})
+	}
+}
+
+func TestReviewedPR_LabelRule(t *testing.T) {
+	raw := `{
+		"__typename": "PullRequest",
+		"url": "https://github.com/org/app/pull/7",
+		"repository": {"nameWithOwner": "org/app"},
+		"number": 7,
+		"title": "Bump lodash from 4.17.20 to 4.17.21",
+		"author": {"__typename": "Bot", "login": "dependabot"},
+		"labels": {"nodes": [{"name": "dependencies"}]},
+		"reviews": {"nodes": [
+			{"state": "APPROVED", "submittedAt": "2026-05-12T09:00:00Z", "author": {"login": "me"}},
+			{"state": "PENDING", "submittedAt": "2026-05-12T10:00:00Z", "author": {"login": "me"}},
+			{"state": "COMMENTED", "submittedAt": "2026-05-12T11:00:00Z", "author": {"login": "alice"}}
+		]}
+	}`
+	var n github.SearchNode
+	require.NoError(t, json.Unmarshal([]byte(raw), &n))
+
+	dateRange := &DateRange{
+		Start: time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC),
+	}
+
+	pr, ok := reviewedPR(n, "q", "me", dateRange, time.Time{})
+	require.True(t, ok)
+	assert.Equal(t, []string{"dependencies"}, pr.Event.Labels)
+	assert.Len(t, pr.Reviews, 1, "only my submitted reviews")
+
+	// A labels rule drops the review in daily as it does in analyze
+	set, err := rules.New([]rules.Rule{{Name: "deps", Labels: []string{"dependencies"}}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "deps", set.Excluded(pr.Event))
+
+	// No reviews of mine in the range
+	_, ok = reviewedPR(n, "q", "alice", &DateRange{Start: dateRange.End, End: dateRange.End.AddDate(0, 0, 1)}, time.Time{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,157 @@
+package daily
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// RepoGroup is a set of PRs or reviews in one repository (see groupByRepo)
+type RepoGroup struct {
+	Repo    string        // owner/repo
+	PRs     []PullRequest // Set when grouping PRs
+	Reviews []ExtraReview // Set when grouping reviews
+}
+
+// templateFuncs returns the helpers available to report templates.
+// now is the reference time for relTime.
+func templateFuncs(now time.Time) template.FuncMap {
+	return template.FuncMap{
+		"repoShort":   repoShort,
+		"relTime":     func(t time.Time) string { return relTime(t, now) },
+		"reviewEmoji": reviewEmoji,
+		"truncate":    truncate,
+		"groupByRepo": groupByRepo,
+		"mdEscape":    mdEscape,
+		"slackEscape": slackEscape,
+		"join":        strings.Join,
+	}
+}
+
+// repoShort returns the repository name without its owner
+func repoShort(repo string) string {
+	if i := strings.LastIndex(repo, "/"); i != -1 {
+		return repo[i+1:]
+	}
+	return repo
+}
+
+// relTime describes t relative to now, e.g. "3h ago" or "in 2d"
+func relTime(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	d := now.Sub(t)
+	suffix := " ago"
+	if d < 0 {
+		d = -d
+		suffix = ""
+	}
+
+	var s string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		s = fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		s = fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		s = fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		return t.Format("2006-01-02")
+	}
+
+	if suffix == "" {
+		return "in " + s
+	}
+	return s + suffix
+}
+
+// reviewEmoji maps a review state to an emoji
+func reviewEmoji(state string) string {
+	switch state {
+	case "APPROVED":
+		return "✅"
+	case "CHANGES_REQUESTED":
+		return "❌"
+	case "COMMENTED":
+		return "💬"
+	case "DISMISSED":
+		return "🚫"
+	default:
+		return "•"
+	}
+}
+
+// truncate shortens s to at most n characters, ending with "…" when cut.
+// The length comes first so it can be used in pipelines: {{.Title | truncate 50}}
+func truncate(n int, s string) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// groupByRepo groups PRs ([]PullRequest or []data.Event) or reviews ([]ExtraReview) by repository,
+// sorted by repository name
+func groupByRepo(items any) ([]RepoGroup, error) {
+	groups := make(map[string]*RepoGroup)
+	group := func(repo string) *RepoGroup {
+		if g, ok := groups[repo]; ok {
+			return g
+		}
+		g := &RepoGroup{Repo: repo}
+		groups[repo] = g
+		return g
+	}
+
+	switch v := items.(type) {
+	case []PullRequest:
+		for _, pr := range v {
+			g := group(pr.Repo)
+			g.PRs = append(g.PRs, pr)
+		}
+	case []data.Event:
+		for _, e := range v {
+			g := group(e.Repo)
+			g.PRs = append(g.PRs, PullRequest{Event: e})
+		}
+	case []ExtraReview:
+		for _, r := range v {
+			repo := r.Repo
+			if r.Owner != "" {
+				repo = r.Owner + "/" + r.Repo
+			}
+			g := group(repo)
+			g.Reviews = append(g.Reviews, r)
+		}
+	default:
+		return nil, fmt.Errorf("groupByRepo: unsupported type %T", items)
+	}
+
+	result := make([]RepoGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Repo < result[j].Repo
+	})
+	return result, nil
+}
+
+// mdEscape escapes characters that would break a Markdown link text
+func mdEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`, "`", "\\`", "<", `\<`).Replace(s)
+}
+
+// slackEscape escapes the characters Slack mrkdwn treats as control characters
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
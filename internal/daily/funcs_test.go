@@ -0,0 +1,72 @@
+package daily
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoShort(t *testing.T) {
+	assert.Equal(t, "repo", repoShort("org/repo"))
+	assert.Equal(t, "repo", repoShort("repo"))
+}
+
+func TestRelTime(t *testing.T) {
+	now := time.Date(2026, 5, 13, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Time{}, ""},
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-3 * time.Hour), "3h ago"},
+		{now.Add(-50 * time.Hour), "2d ago"},
+		{now.Add(2 * time.Hour), "in 2h"},
+		{now.AddDate(0, -3, 0), "2026-02-13"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, relTime(tt.t, now))
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate(10, "short"))
+	assert.Equal(t, "abcd…", truncate(5, "abcdefgh"))
+	assert.Equal(t, "日本…", truncate(3, "日本語テキスト"))
+	assert.Equal(t, "unchanged", truncate(0, "unchanged"))
+}
+
+func TestReviewEmoji(t *testing.T) {
+	assert.Equal(t, "✅", reviewEmoji("APPROVED"))
+	assert.Equal(t, "❌", reviewEmoji("CHANGES_REQUESTED"))
+	assert.Equal(t, "💬", reviewEmoji("COMMENTED"))
+	assert.Equal(t, "•", reviewEmoji("PENDING"))
+}
+
+func TestGroupByRepo(t *testing.T) {
+	groups, err := groupByRepo([]data.Event{
+		{Title: "b1", Repo: "org/b"},
+		{Title: "a1", Repo: "org/a"},
+		{Title: "b2", Repo: "org/b"},
+	})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "org/a", groups[0].Repo)
+	assert.Equal(t, "org/b", groups[1].Repo)
+	assert.Len(t, groups[1].PRs, 2)
+
+	groups, err = groupByRepo([]ExtraReview{{Owner: "org", Repo: "a", PRTitle: "x"}})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "org/a", groups[0].Repo)
+	assert.Len(t, groups[0].Reviews, 1)
+
+	_, err = groupByRepo("nope")
+	assert.Error(t, err)
+}
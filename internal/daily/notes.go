@@ -0,0 +1,43 @@
+package daily
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/note"
+)
+
+// NotesInRange returns the notes (gh brag note) dated on a day the range
+// touches, oldest first. Notes are dated by day, so a day counts when any part
+// of it, in the range's timezone, falls within the range.
+func NotesInRange(events []data.Event, dateRange *DateRange) []data.Event {
+	loc := time.UTC
+	if dateRange.Location != nil {
+		loc = dateRange.Location
+	}
+
+	var notes []data.Event
+	for _, e := range events {
+		if !note.Is(e) {
+			continue
+		}
+		start := noteDay(e, loc)
+		if start.Before(dateRange.End) && start.AddDate(0, 0, 1).After(dateRange.Start) {
+			notes = append(notes, e)
+		}
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return note.Day(notes[i]) < note.Day(notes[j])
+	})
+	return notes
+}
+
+// noteDay returns the start of the note's day in loc
+func noteDay(e data.Event, loc *time.Location) time.Time {
+	day, err := time.ParseInLocation(note.DateLayout, note.Day(e), loc)
+	if err != nil {
+		return e.Timestamps.CreatedAt
+	}
+	return day
+}
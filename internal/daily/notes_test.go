@@ -0,0 +1,46 @@
+package daily
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotesInRange(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone data unavailable")
+	}
+	notes := func(days ...string) []data.Event {
+		var events []data.Event
+		for _, d := range days {
+			// Written in Tokyo, where the day starts before it does in New York
+			at, _ := time.ParseInLocation("2006-01-02", d, time.FixedZone("JST", 9*60*60))
+			events = append(events, data.Event{ID: "note:" + d, Kind: "note", Timestamps: data.Timestamps{CreatedAt: at}})
+		}
+		return events
+	}
+	events := append(notes("2026-05-13", "2026-05-11", "2026-05-12", "2026-05-14"),
+		data.Event{ID: "pr", Kind: "prs", Timestamps: data.Timestamps{CreatedAt: time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC)}})
+
+	dateRange := &DateRange{
+		Start:    time.Date(2026, 5, 12, 0, 0, 0, 0, ny),
+		End:      time.Date(2026, 5, 14, 0, 0, 0, 0, ny),
+		Location: ny,
+	}
+	var ids []string
+	for _, e := range NotesInRange(events, dateRange) {
+		ids = append(ids, e.ID)
+	}
+	assert.Equal(t, []string{"note:2026-05-12", "note:2026-05-13"}, ids)
+
+	// A range ending mid-day, as --since-last produces, still includes that day's notes
+	dateRange.End = time.Date(2026, 5, 12, 9, 0, 0, 0, ny)
+	ids = nil
+	for _, e := range NotesInRange(events, dateRange) {
+		ids = append(ids, e.ID)
+	}
+	assert.Equal(t, []string{"note:2026-05-12"}, ids)
+}
@@ -2,25 +2,76 @@ package daily
 
 import (
 	"bytes"
-	_ "embed"
+	"embed"
 	"encoding/json"
 	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
 	"text/template"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-//go:embed plain.tmpl
-var plainTemplate string
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// TemplateFormats lists the built-in template formats
+var TemplateFormats = []string{"plain", "markdown", "slack-mrkdwn", "html"}
+
+// Formats lists every format accepted by Render
+var Formats = []string{"plain", "markdown", "slack-mrkdwn", "html", "json", "yaml"}
 
 // RenderPlain renders the report as plain text using the embedded template
 func RenderPlain(report *DailyReport) (string, error) {
-	tmpl, err := template.New("plain").Parse(plainTemplate)
+	return renderBuiltin(report, "plain")
+}
+
+// renderBuiltin renders the report with one of the embedded TemplateFormats
+func renderBuiltin(report *DailyReport, format string) (string, error) {
+	text, err := templateFS.ReadFile("templates/" + format + ".tmpl")
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+		return "", fmt.Errorf("unsupported format: %s", format)
 	}
+	return RenderTemplate(report, format, string(text), format == "html")
+}
+
+// RenderTemplateFile renders the report with a user-supplied template file.
+// Files ending in .html or .html.tmpl are rendered with HTML escaping.
+func RenderTemplateFile(report *DailyReport, path string) (string, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+
+	base := strings.TrimSuffix(strings.ToLower(filepath.Base(path)), ".tmpl")
+	asHTML := strings.HasSuffix(base, ".html") || strings.HasSuffix(base, ".htm")
+	return RenderTemplate(report, filepath.Base(path), string(text), asHTML)
+}
+
+// RenderTemplate executes a Go template against the report.
+// The template receives the *DailyReport and can use the helpers from templateFuncs.
+func RenderTemplate(report *DailyReport, name, text string, asHTML bool) (string, error) {
+	funcs := templateFuncs(time.Now())
 
 	var buf bytes.Buffer
+	if asHTML {
+		tmpl, err := htmltemplate.New(name).Funcs(htmltemplate.FuncMap(funcs)).Parse(text)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse template: %w", err)
+		}
+		if err := tmpl.Execute(&buf, report); err != nil {
+			return "", fmt.Errorf("failed to execute template: %w", err)
+		}
+		return buf.String(), nil
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
 	if err := tmpl.Execute(&buf, report); err != nil {
 		return "", fmt.Errorf("failed to execute template: %w", err)
 	}
@@ -49,8 +100,8 @@ func RenderYAML(report *DailyReport) ([]byte, error) {
 // Render renders the report in the specified format
 func Render(report *DailyReport, format string) (string, error) {
 	switch format {
-	case "plain":
-		return RenderPlain(report)
+	case "plain", "markdown", "slack-mrkdwn", "html":
+		return renderBuiltin(report, format)
 	case "json":
 		data, err := RenderJSON(report)
 		if err != nil {
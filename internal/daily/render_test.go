@@ -0,0 +1,233 @@
+package daily
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReport() *DailyReport {
+	day := time.Date(2026, 5, 12, 10, 0, 0, 0, time.UTC)
+	dateRange := &DateRange{
+		Start:    time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC),
+		Label:    "2026-05-12",
+		Location: time.UTC,
+	}
+	prs := []PRWithIssues{
+		{
+			Event:        data.Event{Title: "Fix <login> bug", URL: "https://github.com/org/app/pull/2", Repo: "org/app", Timestamps: data.Timestamps{UpdatedAt: day}},
+			LinkedIssues: []LinkedIssue{{Number: 1, Title: "Login broken", URL: "https://github.com/org/app/issues/1"}},
+			Status:       PRStatus{ReviewDecision: "APPROVED", CIState: "FAILURE"},
+		},
+		{
+			Event:  data.Event{Title: "Bump [deps]", URL: "https://github.com/org/lib/pull/3", Repo: "org/lib", Action: data.EventActionMerged, Timestamps: data.Timestamps{UpdatedAt: day}},
+			Status: PRStatus{ReviewDecision: "APPROVED", CIState: "SUCCESS"},
+		},
+	}
+	reviews := []ReviewedPR{
+		{
+			Event:   data.Event{Title: "Add cache", URL: "https://github.com/org/app/pull/4", Repo: "org/app", Number: 4},
+			Reviews: []ReviewInfo{{State: "APPROVED", SubmittedAt: day}},
+		},
+	}
+	report := Aggregate(dateRange, prs, reviews)
+	report.InProgress = []PullRequest{
+		{Event: data.Event{Title: "Refactor cache", URL: "https://github.com/org/app/pull/5", Repo: "org/app"}, Status: PRStatus{IsDraft: true}},
+	}
+	report.ReviewRequests = []PullRequest{
+		{Event: data.Event{Title: "Add tracing", URL: "https://github.com/org/lib/pull/6", Repo: "org/lib", Author: "alice"}},
+	}
+	report.IssueActivity = []IssueActivity{
+		{
+			Event:   data.Event{Title: "Crash on start", URL: "https://github.com/org/app/issues/8", Repo: "org/app", Kind: "issue"},
+			Actions: []IssueAction{{Kind: "labeled", Detail: "bug", At: day}, {Kind: "assigned", Detail: "bob", At: day}},
+		},
+	}
+	report.Notes = []data.Event{
+		{ID: "note:20260512-1", Kind: "note", Title: "Ran the <release> retro", Theme: "Maintenance", URL: "https://docs.example.com/retro"},
+		{ID: "note:20260512-2", Kind: "note", Title: "Mentored a new hire"},
+	}
+	report.AssignedIssues = []data.Event{
+		{Title: "Flaky test", URL: "https://github.com/org/app/issues/7", Repo: "org/app", Kind: "issue"},
+	}
+	return report
+}
+
+func TestRender_BuiltinFormats(t *testing.T) {
+	report := testReport()
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{
+			format: "plain",
+			want: []string{
+				"Daily report (2026-05-12)",
+				"• Issue: Login broken",
+				"    - PR: Fix <login> bug [approved, CI failing]",
+				"• PR: Bump [deps] [merged]",
+				"• APPROVED - Add cache",
+				"Issue Activity\n• Crash on start (labeled bug, assigned bob)\n    https://github.com/org/app/issues/8",
+				"Notes\n• Ran the <release> retro (Maintenance)\n    https://docs.example.com/retro\n• Mentored a new hire\n\nIn Progress",
+				"In Progress\n• Refactor cache [draft]\n    https://github.com/org/app/pull/5",
+				"Next Up\n• Review: Add tracing (@alice)",
+				"• Issue: Flaky test\n    https://github.com/org/app/issues/7",
+			},
+		},
+		{
+			format: "markdown",
+			want: []string{
+				"## Daily report (2026-05-12)",
+				"- **Issue:** [Login broken](https://github.com/org/app/issues/1)",
+				"  - [Fix \\<login> bug](https://github.com/org/app/pull/2) · app `approved` `CI failing`",
+				"- [Bump \\[deps\\]](https://github.com/org/lib/pull/3) · lib `merged`",
+				"- ✅ [Add cache](https://github.com/org/app/pull/4) · app",
+				"### Issue Activity\n\n- [Crash on start](https://github.com/org/app/issues/8) · app · labeled bug, assigned bob",
+				"### Notes\n\n- [Ran the \\<release> retro](https://docs.example.com/retro) · Maintenance\n- Mentored a new hire",
+				"### In Progress\n- [Refactor cache](https://github.com/org/app/pull/5) · app `draft`",
+				"### Next Up\n- **Review:** [Add tracing](https://github.com/org/lib/pull/6) by @alice · lib",
+				"- **Issue:** [Flaky test](https://github.com/org/app/issues/7) · app",
+			},
+		},
+		{
+			format: "slack-mrkdwn",
+			want: []string{
+				"*Daily report (2026-05-12)*",
+				"• Issue: <https://github.com/org/app/issues/1|Login broken>",
+				"◦ <https://github.com/org/app/pull/2|Fix &lt;login&gt; bug> (app) _approved · CI failing_",
+				"• ✅ <https://github.com/org/app/pull/4|Add cache> (app)",
+				"*Issue Activity*\n• <https://github.com/org/app/issues/8|Crash on start> (app) _labeled bug, assigned bob_",
+				"*Notes*\n• <https://docs.example.com/retro|Ran the &lt;release&gt; retro> _Maintenance_\n• Mentored a new hire",
+				"*In Progress*\n• <https://github.com/org/app/pull/5|Refactor cache> (app) _draft_",
+				"*Next Up*\n• Review: <https://github.com/org/lib/pull/6|Add tracing> by alice (lib)",
+			},
+		},
+		{
+			format: "html",
+			want: []string{
+				"<h2>Daily report (2026-05-12)</h2>",
+				`<a href="https://github.com/org/app/pull/2">Fix &lt;login&gt; bug</a>`,
+				`<span title="APPROVED">✅</span>`,
+				"<h3>Issue Activity</h3>",
+				"<h3>Notes</h3>",
+				`<li><a href="https://docs.example.com/retro">Ran the &lt;release&gt; retro</a> <small>Maintenance</small></li>`,
+				"<li>Mentored a new hire</li>",
+				"<h3>In Progress</h3>",
+				"<small>app</small> <mark>approved</mark> <mark>CI failing</mark>",
+				"<mark>draft</mark>",
+				"<h3>Next Up</h3>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out, err := Render(report, tt.format)
+			require.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, out, want)
+			}
+		})
+	}
+
+	_, err := Render(report, "pdf")
+	assert.Error(t, err)
+}
+
+func TestRender_TicketKeys(t *testing.T) {
+	day := time.Date(2026, 5, 12, 10, 0, 0, 0, time.UTC)
+	dateRange := &DateRange{Start: day.Add(-10 * time.Hour), End: day.Add(14 * time.Hour), Label: "2026-05-12", Location: time.UTC}
+	report := Aggregate(dateRange, []PRWithIssues{{
+		Event: data.Event{Title: "Speed up search", URL: "https://github.com/org/app/pull/9", Repo: "org/app", Timestamps: data.Timestamps{UpdatedAt: day}},
+		LinkedIssues: []LinkedIssue{{
+			Key: "ENG-42", Title: "Slow search", URL: "https://linear.app/acme/issue/ENG-42",
+			Epic: &Epic{Key: "ENG-40", Title: "Search", URL: "https://linear.app/acme/issue/ENG-40"},
+		}},
+	}}, nil)
+
+	tests := map[string]string{
+		"plain":        "• Issue: ENG-42 Slow search (epic: ENG-40 Search)",
+		"markdown":     "- **Issue:** [ENG-42 Slow search](https://linear.app/acme/issue/ENG-42) · epic: [ENG-40 Search](https://linear.app/acme/issue/ENG-40)",
+		"slack-mrkdwn": "• Issue: <https://linear.app/acme/issue/ENG-42|ENG-42 Slow search> (epic: <https://linear.app/acme/issue/ENG-40|ENG-40 Search>)",
+		"html":         `<a href="https://linear.app/acme/issue/ENG-42">ENG-42 Slow search</a> <small>epic: <a href="https://linear.app/acme/issue/ENG-40">ENG-40 Search</a></small>`,
+	}
+	for format, want := range tests {
+		t.Run(format, func(t *testing.T) {
+			out, err := Render(report, format)
+			require.NoError(t, err)
+			assert.Contains(t, out, want)
+		})
+	}
+}
+
+func TestRender_OpenWorkData(t *testing.T) {
+	report := testReport()
+
+	// The embedded event's fields are flattened next to the status
+	out, err := Render(report, "json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"title": "Refactor cache"`)
+	assert.Contains(t, out, `"isDraft": true`)
+
+	out, err = Render(report, "yaml")
+	require.NoError(t, err)
+	assert.Contains(t, out, "inprogress:\n    - id: \"\"")
+	assert.Contains(t, out, "      title: Refactor cache")
+	assert.Contains(t, out, "        isdraft: true")
+}
+
+func TestRender_SummaryThemes(t *testing.T) {
+	report := testReport()
+	report.Summary = "ignored"
+	report.SummaryThemes = []SummaryTheme{
+		{Title: "Auth", Bullets: []SummaryBullet{{Text: "Fixed login", Sources: []string{"https://github.com/org/app/pull/2"}}}},
+	}
+
+	tests := map[string]string{
+		"plain":        "Summary\n• Auth\n    - Fixed login\n",
+		"markdown":     "### Summary\n\n- **Auth**\n  - Fixed login [↗](https://github.com/org/app/pull/2)\n",
+		"slack-mrkdwn": "*Summary*\n• *Auth*\n    ◦ Fixed login <https://github.com/org/app/pull/2|↗>\n",
+		"html":         `<li>Fixed login <a href="https://github.com/org/app/pull/2">↗</a></li>`,
+	}
+	for format, want := range tests {
+		t.Run(format, func(t *testing.T) {
+			out, err := Render(report, format)
+			require.NoError(t, err)
+			assert.Contains(t, out, want)
+			assert.NotContains(t, out, "ignored")
+		})
+	}
+}
+
+func TestRenderTemplateFile(t *testing.T) {
+	report := testReport()
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "standup.tmpl")
+	text := `{{range groupByRepo .PRs}}{{repoShort .Repo}}:{{range .PRs}} {{.Title | truncate 8}}{{end}}
+{{end}}`
+	require.NoError(t, os.WriteFile(path, []byte(text), 0o644))
+
+	out, err := RenderTemplateFile(report, path)
+	require.NoError(t, err)
+	assert.Equal(t, "app: Fix <lo…\nlib: Bump [d…\n", out)
+
+	// .html templates are escaped
+	htmlPath := filepath.Join(dir, "standup.html.tmpl")
+	require.NoError(t, os.WriteFile(htmlPath, []byte(`{{range .PRs}}{{.Title}};{{end}}`), 0o644))
+	out, err = RenderTemplateFile(report, htmlPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix &lt;login&gt; bug;Bump [deps];", out)
+
+	badPath := filepath.Join(dir, "bad.tmpl")
+	require.NoError(t, os.WriteFile(badPath, []byte(`{{.Nope}}`), 0o644))
+	_, err = RenderTemplateFile(report, badPath)
+	assert.Error(t, err)
+}
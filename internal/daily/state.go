@@ -0,0 +1,70 @@
+package daily
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State remembers what previous reports covered (for --since-last)
+type State struct {
+	LastEnd time.Time `json:"lastEnd"`
+}
+
+// DefaultStatePath returns $XDG_STATE_HOME/gh-brag/daily.json,
+// falling back to ~/.local/state/gh-brag/daily.json
+func DefaultStatePath() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(".gh-brag", "daily.json")
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "gh-brag", "daily.json")
+}
+
+// LoadState reads the state file. A missing file yields an empty state.
+func LoadState(path string) (State, error) {
+	var s State
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// RecordReport stores the end of a generated report if it is later than
+// the one already recorded, so reports of past ranges don't rewind it.
+// Ends in the future (e.g. the rest of today) are capped at the current time.
+func RecordReport(path string, end time.Time) error {
+	if now := time.Now(); end.After(now) {
+		end = now
+	}
+
+	s, err := LoadState(path)
+	if err != nil {
+		return err
+	}
+	if !end.After(s.LastEnd) {
+		return nil
+	}
+	s.LastEnd = end
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0644)
+}
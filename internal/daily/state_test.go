@@ -0,0 +1,33 @@
+package daily
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "daily.json")
+
+	state, err := LoadState(path)
+	require.NoError(t, err)
+	assert.True(t, state.LastEnd.IsZero())
+
+	first := time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, RecordReport(path, first))
+
+	// An older report doesn't rewind the state
+	require.NoError(t, RecordReport(path, first.AddDate(0, 0, -7)))
+	state, err = LoadState(path)
+	require.NoError(t, err)
+	assert.True(t, first.Equal(state.LastEnd))
+
+	// A report ending in the future is capped at now
+	require.NoError(t, RecordReport(path, time.Now().Add(24*time.Hour)))
+	state, err = LoadState(path)
+	require.NoError(t, err)
+	assert.False(t, state.LastEnd.After(time.Now()))
+}
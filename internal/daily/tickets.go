@@ -0,0 +1,33 @@
+package daily
+
+import (
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/ticket"
+)
+
+// LinkTickets adds the imported Jira and Linear tickets whose keys appear in each
+// PR's title, branch or body to its linked issues, so Aggregate groups the PR
+// under them. A ticket's parent becomes its epic when it was imported too.
+func LinkTickets(prs []PRWithIssues, idx ticket.Index) []PRWithIssues {
+	for i := range prs {
+		for _, t := range idx.Linked(prs[i].Event) {
+			issue := ticketIssue(t)
+			if p, ok := idx.Parent(t); ok {
+				issue.Epic = &Epic{Key: p.Key, Title: p.Title, URL: p.URL, Body: p.Body}
+			}
+			prs[i].LinkedIssues = append(prs[i].LinkedIssues, issue)
+		}
+	}
+	return prs
+}
+
+// ticketIssue converts an imported ticket into a linked issue
+func ticketIssue(t data.Event) LinkedIssue {
+	return LinkedIssue{
+		Key:    t.Key,
+		Title:  t.Title,
+		URL:    t.URL,
+		Body:   t.Body,
+		Labels: t.Labels,
+	}
+}
@@ -0,0 +1,47 @@
+package daily
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/ticket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkTickets(t *testing.T) {
+	now := time.Now()
+	dateRange := &DateRange{Start: now.Add(-24 * time.Hour), End: now, Label: "2026-03-04"}
+
+	idx := ticket.NewIndex([]data.Event{
+		{ID: "jira:ABC-1", Kind: "issue", Key: "ABC-1", Title: "Checkout revamp", URL: "https://acme.atlassian.net/browse/ABC-1"},
+		{ID: "jira:ABC-123", Kind: "issue", Key: "ABC-123", Title: "Fix login", URL: "https://acme.atlassian.net/browse/ABC-123", Parent: "ABC-1"},
+		{ID: "linear:ENG-7", Kind: "issue", Key: "ENG-7", Title: "Rate limits", URL: "https://linear.app/acme/issue/ENG-7"},
+	})
+	github := LinkedIssue{Number: 5, Title: "Login broken", URL: "https://github.com/org/app/issues/5"}
+	prs := []PRWithIssues{
+		{Event: data.Event{Title: "ABC-123: handle expired sessions", URL: "https://github.com/org/app/pull/1", Timestamps: data.Timestamps{UpdatedAt: now}}},
+		{Event: data.Event{Title: "Refresh tokens", Branch: "abc-123-refresh", URL: "https://github.com/org/app/pull/2", Timestamps: data.Timestamps{UpdatedAt: now}}, LinkedIssues: []LinkedIssue{github}},
+		{Event: data.Event{Title: "Bump deps", Body: "Needed for ENG-7.", URL: "https://github.com/org/app/pull/3", Timestamps: data.Timestamps{UpdatedAt: now}}},
+		{Event: data.Event{Title: "Fix typo", URL: "https://github.com/org/app/pull/4", Timestamps: data.Timestamps{UpdatedAt: now}}},
+	}
+
+	prs = LinkTickets(prs, idx)
+	require.Len(t, prs[0].LinkedIssues, 1)
+	assert.Equal(t, "ABC-123", prs[0].LinkedIssues[0].Key)
+	require.NotNil(t, prs[0].LinkedIssues[0].Epic)
+	assert.Equal(t, "Checkout revamp", prs[0].LinkedIssues[0].Epic.Title)
+	assert.Equal(t, []LinkedIssue{github, prs[0].LinkedIssues[0]}, prs[1].LinkedIssues, "GitHub issues stay linked")
+	assert.Nil(t, prs[2].LinkedIssues[0].Epic)
+
+	report := Aggregate(dateRange, prs, nil)
+	groups := make(map[string]int)
+	for _, g := range report.IssueGroups {
+		groups[g.Issue.Title] = len(g.PRs)
+	}
+	assert.Equal(t, map[string]int{"Fix login": 2, "Login broken": 1, "Rate limits": 1}, groups)
+	assert.Len(t, report.StandalonePRs, 1)
+	require.Len(t, report.Epics, 1)
+	assert.Equal(t, "ABC-1", report.Epics[0].Epic.Key)
+}
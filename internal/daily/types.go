@@ -1,16 +1,40 @@
 package daily
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jackchuka/gh-brag/internal/data"
 )
 
-// LinkedIssue represents an issue linked to a PR via closingIssuesReferences
+// LinkedIssue represents an issue linked to a PR via closingIssuesReferences,
+// or an imported Jira or Linear ticket whose key the PR mentions
 type LinkedIssue struct {
+	Key    string   `json:"key,omitempty"` // Ticket key, e.g. ABC-123; empty for GitHub issues
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	URL    string   `json:"url"`
+	Body   string   `json:"body,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+	Epic   *Epic    `json:"epic,omitempty"` // Parent issue or tracking issue
+}
+
+// Epic is the parent of a linked issue: its sub-issue parent, the issue tracking it,
+// or the parent of an imported ticket
+type Epic struct {
+	Key    string `json:"key,omitempty"` // Ticket key of an imported parent ticket
 	Number int    `json:"number"`
 	Title  string `json:"title"`
 	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// EpicGroup collects the issue groups that belong to one epic
+type EpicGroup struct {
+	Epic        Epic         `json:"epic"`
+	IssueGroups []IssueGroup `json:"issueGroups"`
 }
 
 // ReviewInfo represents a review you submitted on a PR
@@ -22,8 +46,8 @@ type ReviewInfo struct {
 
 // IssueGroup represents an issue with all PRs that link to it
 type IssueGroup struct {
-	Issue LinkedIssue  `json:"issue"`
-	PRs   []data.Event `json:"prs"`
+	Issue LinkedIssue   `json:"issue"`
+	PRs   []PullRequest `json:"prs"`
 }
 
 // ExtraReview represents reviews you submitted on someone else's PR (grouped by PR)
@@ -36,21 +60,157 @@ type ExtraReview struct {
 	Reviews  []ReviewInfo `json:"reviews"`
 }
 
+// PRStatus is the review and CI state of a pull request
+type PRStatus struct {
+	IsDraft        bool   `json:"isDraft"`
+	ReviewDecision string `json:"reviewDecision,omitempty"` // APPROVED | CHANGES_REQUESTED | REVIEW_REQUIRED
+	Mergeable      string `json:"mergeable,omitempty"`      // MERGEABLE | CONFLICTING | UNKNOWN
+	CIState        string `json:"ciState,omitempty"`        // SUCCESS | FAILURE | ERROR | PENDING | EXPECTED
+}
+
+// Label returns a short human-readable status, e.g. "draft" or "approved"
+func (s PRStatus) Label() string {
+	if s.IsDraft {
+		return "draft"
+	}
+	switch s.ReviewDecision {
+	case "APPROVED":
+		return "approved"
+	case "CHANGES_REQUESTED":
+		return "changes requested"
+	case "REVIEW_REQUIRED":
+		return "review required"
+	}
+	return ""
+}
+
+// Badges returns compact status badges, e.g. ["approved", "CI failing"]
+func (s PRStatus) Badges() []string {
+	var badges []string
+	if label := s.Label(); label != "" {
+		badges = append(badges, label)
+	}
+	switch s.CIState {
+	case "SUCCESS":
+		badges = append(badges, "CI passing")
+	case "FAILURE", "ERROR":
+		badges = append(badges, "CI failing")
+	case "PENDING", "EXPECTED":
+		badges = append(badges, "CI pending")
+	}
+	if s.Mergeable == "CONFLICTING" {
+		badges = append(badges, "conflicts")
+	}
+	return badges
+}
+
+// PullRequest is a PR with its review and CI status
+type PullRequest struct {
+	data.Event `yaml:",inline"`
+	Status     PRStatus `json:"status"`
+}
+
+// Badges returns the PR's status badges. Merged PRs only show "merged",
+// since review and CI state no longer matter.
+func (p PullRequest) Badges() []string {
+	if p.Action == data.EventActionMerged {
+		return []string{"merged"}
+	}
+	return p.Status.Badges()
+}
+
+// IssueAction is one thing you did on an issue
+type IssueAction struct {
+	Kind   string    `json:"kind"` // opened | commented | closed | reopened | labeled | assigned
+	At     time.Time `json:"at"`
+	Detail string    `json:"detail,omitempty"` // Label name or assignee login
+	URL    string    `json:"url,omitempty"`    // Comment URL
+}
+
+// IssueActivity is an issue you opened, commented on, closed or triaged in the range
+type IssueActivity struct {
+	data.Event `yaml:",inline"`
+	Actions    []IssueAction `json:"actions"` // Chronological
+}
+
+// ActionSummary describes the actions compactly, e.g. "opened, commented ×2, labeled bug, p1"
+func (a IssueActivity) ActionSummary() string {
+	var order []string
+	count := make(map[string]int)
+	details := make(map[string][]string)
+	for _, act := range a.Actions {
+		if count[act.Kind] == 0 {
+			order = append(order, act.Kind)
+		}
+		count[act.Kind]++
+		if act.Detail != "" && !slices.Contains(details[act.Kind], act.Detail) {
+			details[act.Kind] = append(details[act.Kind], act.Detail)
+		}
+	}
+
+	parts := make([]string, 0, len(order))
+	for _, kind := range order {
+		switch {
+		case len(details[kind]) > 0:
+			parts = append(parts, kind+" "+strings.Join(details[kind], ", "))
+		case count[kind] > 1:
+			parts = append(parts, fmt.Sprintf("%s ×%d", kind, count[kind]))
+		default:
+			parts = append(parts, kind)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SummaryTheme is a theme of a structured LLM summary
+type SummaryTheme struct {
+	Title   string          `json:"title"`
+	Bullets []SummaryBullet `json:"bullets"`
+}
+
+// SummaryBullet is one summarized item with the URLs it is based on
+type SummaryBullet struct {
+	Text    string   `json:"text"`
+	Sources []string `json:"sources"`
+}
+
 // DailyReport is the top-level output structure
 type DailyReport struct {
-	Summary       string        `json:"summary,omitempty"` // LLM-generated summary
-	DateLabel     string        `json:"dateLabel"`         // YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD
-	RangeStart    time.Time     `json:"rangeStart"`
-	RangeEnd      time.Time     `json:"rangeEnd"`
-	IssueGroups   []IssueGroup  `json:"issueGroups"`   // PRs grouped by linked issue
-	StandalonePRs []data.Event  `json:"standalonePrs"` // PRs without linked issues
-	ExtraReviews  []ExtraReview `json:"extraReviews"`
+	Summary       string          `json:"summary,omitempty"`       // LLM-generated summary
+	SummaryThemes []SummaryTheme  `json:"summaryThemes,omitempty"` // Structured LLM summary (replaces Summary)
+	DateLabel     string          `json:"dateLabel"`               // YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD
+	RangeStart    time.Time       `json:"rangeStart"`
+	RangeEnd      time.Time       `json:"rangeEnd"`
+	IssueGroups   []IssueGroup    `json:"issueGroups"`   // PRs grouped by linked issue
+	Epics         []EpicGroup     `json:"epics"`         // Issue groups rolled up by parent epic
+	StandalonePRs []PullRequest   `json:"standalonePrs"` // PRs without linked issues
+	ExtraReviews  []ExtraReview   `json:"extraReviews"`
+	IssueActivity []IssueActivity `json:"issueActivity"` // Issues opened, commented on, closed or triaged
+	Notes         []data.Event    `json:"notes"`         // Manual entries (gh brag note) dated within the range
+
+	// Current state rather than activity in the range
+	InProgress     []PullRequest `json:"inProgress"`     // My open PRs
+	ReviewRequests []PullRequest `json:"reviewRequests"` // Open PRs waiting on my review
+	AssignedIssues []data.Event  `json:"assignedIssues"` // Open issues assigned to me
+
+	dateRange *DateRange // Range the report covers, for day grouping in templates
+}
+
+// DayActivity is the part of a report that happened on a single day
+type DayActivity struct {
+	Date          string // Mon 2006-01-02
+	IssueGroups   []IssueGroup
+	StandalonePRs []PullRequest
+	ExtraReviews  []ExtraReview
+	IssueActivity []IssueActivity
+	Notes         []data.Event
 }
 
-// PRWithIssues wraps a PR event with its linked issues (intermediate fetch type)
+// PRWithIssues wraps a PR event with its linked issues and status (intermediate fetch type)
 type PRWithIssues struct {
 	Event        data.Event
 	LinkedIssues []LinkedIssue
+	Status       PRStatus
 }
 
 // ReviewedPR wraps a reviewed PR with your review details (intermediate fetch type)
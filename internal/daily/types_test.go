@@ -83,3 +83,78 @@ func TestActivityTime(t *testing.T) {
 		})
 	}
 }
+
+func TestPRStatus_Label(t *testing.T) {
+	tests := []struct {
+		status PRStatus
+		want   string
+	}{
+		{PRStatus{}, ""},
+		{PRStatus{IsDraft: true, ReviewDecision: "APPROVED"}, "draft"},
+		{PRStatus{ReviewDecision: "APPROVED"}, "approved"},
+		{PRStatus{ReviewDecision: "CHANGES_REQUESTED"}, "changes requested"},
+		{PRStatus{ReviewDecision: "REVIEW_REQUIRED"}, "review required"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.status.Label())
+	}
+}
+
+func TestPullRequest_Badges(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   PullRequest
+		want []string
+	}{
+		{
+			name: "no status",
+			pr:   PullRequest{},
+			want: nil,
+		},
+		{
+			name: "approved and passing",
+			pr:   PullRequest{Status: PRStatus{ReviewDecision: "APPROVED", CIState: "SUCCESS", Mergeable: "MERGEABLE"}},
+			want: []string{"approved", "CI passing"},
+		},
+		{
+			name: "draft with failing CI and conflicts",
+			pr:   PullRequest{Status: PRStatus{IsDraft: true, CIState: "ERROR", Mergeable: "CONFLICTING"}},
+			want: []string{"draft", "CI failing", "conflicts"},
+		},
+		{
+			name: "pending checks",
+			pr:   PullRequest{Status: PRStatus{ReviewDecision: "REVIEW_REQUIRED", CIState: "PENDING"}},
+			want: []string{"review required", "CI pending"},
+		},
+		{
+			name: "merged hides status",
+			pr: PullRequest{
+				Event:  data.Event{Action: data.EventActionMerged},
+				Status: PRStatus{ReviewDecision: "APPROVED", CIState: "FAILURE"},
+			},
+			want: []string{"merged"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.pr.Badges())
+		})
+	}
+}
+
+func TestIssueActivity_ActionSummary(t *testing.T) {
+	activity := IssueActivity{Actions: []IssueAction{
+		{Kind: "opened"},
+		{Kind: "labeled", Detail: "bug"},
+		{Kind: "commented"},
+		{Kind: "labeled", Detail: "p1"},
+		{Kind: "commented"},
+		{Kind: "labeled", Detail: "bug"},
+		{Kind: "closed"},
+	}}
+
+	assert.Equal(t, "opened, labeled bug, p1, commented ×2, closed", activity.ActionSummary())
+	assert.Equal(t, "", IssueActivity{}.ActionSummary())
+}
@@ -5,27 +5,49 @@ import "time"
 type EventAction string
 
 const (
-	EventActionMerged   EventAction = "merged"
-	EventActionReviewed EventAction = "reviewed"
-	EventActionAuthored EventAction = "authored"
+	EventActionMerged    EventAction = "merged"
+	EventActionReviewed  EventAction = "reviewed"
+	EventActionAuthored  EventAction = "authored"
+	EventActionNoted     EventAction = "noted"     // Manual note added with gh brag note
+	EventActionCommitted EventAction = "committed" // Commit imported from a local git repository
 )
 
 type Event struct {
 	ID     string      `json:"id"`     // Unique ID: kind:url:action
-	Action EventAction `json:"action"` // "merged", "reviewed", "authored"
-	Kind   string      `json:"kind"`   // "pr", "issue"
+	Action EventAction `json:"action"` // "merged", "reviewed", "authored", "noted", "committed"
+	Kind   string      `json:"kind"`   // "pr", "issue", "note", "commit"
 
 	URL       string   `json:"url"`
 	Repo      string   `json:"repo"`
+	Private   bool     `json:"private,omitempty"`  // Repository is private
+	Fork      bool     `json:"fork,omitempty"`     // Repository is a fork
+	Archived  bool     `json:"archived,omitempty"` // Repository is archived
 	Number    int      `json:"number"`
 	Title     string   `json:"title"`
 	Body      string   `json:"body,omitempty"`
 	Author    string   `json:"author"`
+	AuthorBot bool     `json:"authorBot,omitempty"` // Author is a bot account (GitHub App)
 	Labels    []string `json:"labels,omitempty"`
-	Reviewers []string `json:"reviewers"` // List of reviewer logins
+	Reviewers []string `json:"reviewers"`         // List of reviewer logins
+	Subject   string   `json:"subject,omitempty"` // Login whose activity this is (team mode)
+	Branch    string   `json:"branch,omitempty"`  // Head branch of a PR
+
+	Key    string `json:"key,omitempty"`    // Ticket key of an imported Jira or Linear issue, e.g. ABC-123
+	Parent string `json:"parent,omitempty"` // Key of the ticket's parent (epic or parent issue)
+
+	Theme  string  `json:"theme,omitempty"`  // Theme set by hand (notes); wins over keywords
+	Impact float64 `json:"impact,omitempty"` // Weight set by hand (notes); replaces the action weight
+
+	Commits      []string `json:"commits,omitempty"`      // Commit SHAs of a PR, including its merge commit
+	Additions    int      `json:"additions,omitempty"`    // Lines added (imported commits)
+	Deletions    int      `json:"deletions,omitempty"`    // Lines deleted (imported commits)
+	ChangedFiles int      `json:"changedFiles,omitempty"` // Files changed (imported commits)
 
 	Timestamps Timestamps `json:"timestamps"`
 	Source     Source     `json:"source"`
+
+	// Classification caches an LLM-assigned theme for events keyword matching could not place
+	Classification *Classification `json:"classification,omitempty"`
 }
 
 type Timestamps struct {
@@ -39,3 +61,10 @@ type Source struct {
 	Query     string    `json:"query"`
 	FetchedAt time.Time `json:"fetchedAt"`
 }
+
+type Classification struct {
+	Theme        string    `json:"theme"`
+	Confidence   float64   `json:"confidence"` // 0.0 - 1.0 as reported by the model
+	Model        string    `json:"model"`
+	ClassifiedAt time.Time `json:"classifiedAt"`
+}
@@ -0,0 +1,71 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// eventColumns are the columns of the events table with how to read each from an event
+var eventColumns = []struct {
+	Column
+	value func(e data.Event, s analyze.EventImpact) any
+}{
+	{Column{"id", String}, func(e data.Event, _ analyze.EventImpact) any { return e.ID }},
+	{Column{"action", String}, func(e data.Event, _ analyze.EventImpact) any { return string(e.Action) }},
+	{Column{"kind", String}, func(e data.Event, _ analyze.EventImpact) any { return e.Kind }},
+	{Column{"repo", String}, func(e data.Event, _ analyze.EventImpact) any { return e.Repo }},
+	{Column{"owner", String}, func(e data.Event, _ analyze.EventImpact) any {
+		owner, _, _ := strings.Cut(e.Repo, "/")
+		return owner
+	}},
+	{Column{"number", Int}, func(e data.Event, _ analyze.EventImpact) any { return int64(e.Number) }},
+	{Column{"title", String}, func(e data.Event, _ analyze.EventImpact) any { return e.Title }},
+	{Column{"body", String}, func(e data.Event, _ analyze.EventImpact) any { return e.Body }},
+	{Column{"url", String}, func(e data.Event, _ analyze.EventImpact) any { return e.URL }},
+	{Column{"author", String}, func(e data.Event, _ analyze.EventImpact) any { return e.Author }},
+	{Column{"authorBot", Bool}, func(e data.Event, _ analyze.EventImpact) any { return e.AuthorBot }},
+	{Column{"labels", List}, func(e data.Event, _ analyze.EventImpact) any { return e.Labels }},
+	{Column{"reviewers", List}, func(e data.Event, _ analyze.EventImpact) any { return e.Reviewers }},
+	{Column{"subject", String}, func(e data.Event, _ analyze.EventImpact) any { return e.Subject }},
+	{Column{"branch", String}, func(e data.Event, _ analyze.EventImpact) any { return e.Branch }},
+	{Column{"key", String}, func(e data.Event, _ analyze.EventImpact) any { return e.Key }},
+	{Column{"parent", String}, func(e data.Event, _ analyze.EventImpact) any { return e.Parent }},
+	{Column{"private", Bool}, func(e data.Event, _ analyze.EventImpact) any { return e.Private }},
+	{Column{"fork", Bool}, func(e data.Event, _ analyze.EventImpact) any { return e.Fork }},
+	{Column{"archived", Bool}, func(e data.Event, _ analyze.EventImpact) any { return e.Archived }},
+	{Column{"theme", String}, func(_ data.Event, s analyze.EventImpact) any { return s.Theme }},
+	{Column{"impact", Float}, func(_ data.Event, s analyze.EventImpact) any { return s.Score }},
+	{Column{"commits", List}, func(e data.Event, _ analyze.EventImpact) any { return e.Commits }},
+	{Column{"additions", Int}, func(e data.Event, _ analyze.EventImpact) any { return int64(e.Additions) }},
+	{Column{"deletions", Int}, func(e data.Event, _ analyze.EventImpact) any { return int64(e.Deletions) }},
+	{Column{"changedFiles", Int}, func(e data.Event, _ analyze.EventImpact) any { return int64(e.ChangedFiles) }},
+	{Column{"createdAt", Time}, func(e data.Event, _ analyze.EventImpact) any { return e.Timestamps.CreatedAt }},
+	{Column{"updatedAt", Time}, func(e data.Event, _ analyze.EventImpact) any { return e.Timestamps.UpdatedAt }},
+	{Column{"closedAt", Time}, func(e data.Event, _ analyze.EventImpact) any { return e.Timestamps.ClosedAt }},
+}
+
+// DefaultEventColumns are the events table columns exported when none are selected
+var DefaultEventColumns = []string{
+	"id", "action", "kind", "repo", "number", "title", "url", "author",
+	"labels", "reviewers", "theme", "impact", "createdAt", "updatedAt", "closedAt",
+}
+
+// Events returns one row per event with every event column. Theme and impact
+// are resolved by the analyzer, as in analyze.
+func Events(events []data.Event, a *analyze.Analyzer) Table {
+	t := Table{Columns: make([]Column, len(eventColumns)), Rows: make([][]any, len(events))}
+	for i, c := range eventColumns {
+		t.Columns[i] = c.Column
+	}
+	for r, e := range events {
+		score := a.Score(e)
+		row := make([]any, len(eventColumns))
+		for i, c := range eventColumns {
+			row[i] = c.value(e, score)
+		}
+		t.Rows[r] = row
+	}
+	return t
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"sort"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+)
+
+// Tables that can be exported
+const (
+	TableEvents = "events" // One row per event
+	TableWeekly = "weekly" // Events per ISO week
+	TableRepos  = "repos"  // Repository stats and impact
+	TableThemes = "themes" // Theme mix and impact
+)
+
+// Tables lists the exportable tables
+var Tables = []string{TableEvents, TableWeekly, TableRepos, TableThemes}
+
+// Weekly returns the weekly trend: the Monday each week starts on and its event count
+func Weekly(m analyze.Metrics) Table {
+	t := Table{Columns: []Column{{"week", String}, {"events", Int}}}
+	for _, p := range m.WeeklyTrend {
+		t.Rows = append(t.Rows, []any{p.Date, int64(p.Count)})
+	}
+	return t
+}
+
+// Repos returns the repository stats with each repository's share of the impact score
+func Repos(m analyze.Metrics) Table {
+	impact := shares(m.Impact.ByRepo)
+	t := Table{Columns: []Column{
+		{"repo", String}, {"merged", Int}, {"issues", Int}, {"reviewed", Int}, {"commits", Int},
+		{"impact", Float}, {"impactPercent", Float},
+	}}
+	for _, r := range m.RepoStats.Summary {
+		s := impact[r.Name]
+		t.Rows = append(t.Rows, []any{
+			r.Name, int64(r.Merged), int64(r.Issues), int64(r.Reviewed), int64(r.Commits),
+			s.Score, s.Percent,
+		})
+	}
+	return t
+}
+
+// Themes returns the theme mix, largest first, with each theme's share of the impact score
+func Themes(m analyze.Metrics) Table {
+	impact := shares(m.Impact.ByTheme)
+	themes := append([]analyze.Theme(nil), m.Theme...)
+	sort.SliceStable(themes, func(i, j int) bool {
+		if themes[i].Count != themes[j].Count {
+			return themes[i].Count > themes[j].Count
+		}
+		return themes[i].Name < themes[j].Name
+	})
+
+	t := Table{Columns: []Column{
+		{"theme", String}, {"events", Int}, {"percent", Float}, {"impact", Float}, {"impactPercent", Float},
+	}}
+	for _, th := range themes {
+		s := impact[th.Name]
+		t.Rows = append(t.Rows, []any{th.Name, int64(th.Count), m.ContributionMix[th.Name], s.Score, s.Percent})
+	}
+	return t
+}
+
+func shares(list []analyze.ImpactShare) map[string]analyze.ImpactShare {
+	m := make(map[string]analyze.ImpactShare, len(list))
+	for _, s := range list {
+		m[s.Name] = s
+	}
+	return m
+}
@@ -0,0 +1,335 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// A Parquet file (https://parquet.apache.org/docs/file-format/) is written as
+// "PAR1", one column chunk per column holding a single uncompressed, PLAIN
+// encoded data page, the Thrift compact encoded FileMetaData, its length and
+// "PAR1" again. That is all a small table needs. Field IDs and enum values
+// follow parquet.thrift; TestWriteParquet_Reference reads the output back with
+// DuckDB, which CI installs, or pyarrow.
+
+const parquetMagic = "PAR1"
+
+// Parquet enum values, from parquet.thrift
+const (
+	physicalBoolean   = 0
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecUncompressed = 0
+	pageTypeData      = 0
+)
+
+// parquetType returns the physical type, converted type (-1 for none) and
+// repetition of a column type. Only times are nullable.
+func parquetType(t Type) (physical, converted, repetition int32) {
+	switch t {
+	case Int:
+		return physicalInt64, -1, repetitionRequired
+	case Float:
+		return physicalDouble, -1, repetitionRequired
+	case Bool:
+		return physicalBoolean, -1, repetitionRequired
+	case Time:
+		return physicalInt64, convertedTimestampMillis, repetitionOptional
+	}
+	return physicalByteArray, convertedUTF8, repetitionRequired
+}
+
+// columnChunk locates a written column chunk
+type columnChunk struct {
+	offset int64 // Of the page header
+	size   int64 // Page header and page
+}
+
+// WriteParquet writes the table as a Parquet file with a single row group.
+// Times are UTC millisecond timestamps and lists are joined with ListSep.
+func WriteParquet(w io.Writer, t Table) error {
+	var file bytes.Buffer
+	file.WriteString(parquetMagic)
+
+	var chunks []columnChunk
+	if len(t.Rows) > 0 {
+		for i, c := range t.Columns {
+			page, err := encodeColumn(t, i)
+			if err != nil {
+				return fmt.Errorf("column %s: %w", c.Name, err)
+			}
+			header := pageHeader(len(page), len(t.Rows))
+			chunks = append(chunks, columnChunk{offset: int64(file.Len()), size: int64(len(header) + len(page))})
+			file.Write(header)
+			file.Write(page)
+		}
+	}
+
+	meta := fileMetaData(t, chunks)
+	file.Write(meta)
+	_ = binary.Write(&file, binary.LittleEndian, uint32(len(meta)))
+	file.WriteString(parquetMagic)
+
+	_, err := w.Write(file.Bytes())
+	return err
+}
+
+// encodeColumn returns the data page of column i: definition levels for
+// nullable columns, then the PLAIN encoded non-null values
+func encodeColumn(t Table, i int) ([]byte, error) {
+	var values bytes.Buffer
+	var levels []bool
+	var bits []bool
+	for _, row := range t.Rows {
+		switch v := row[i].(type) {
+		case string:
+			writeByteArray(&values, v)
+		case []string:
+			writeByteArray(&values, strings.Join(v, ListSep))
+		case int64:
+			_ = binary.Write(&values, binary.LittleEndian, v)
+		case float64:
+			_ = binary.Write(&values, binary.LittleEndian, math.Float64bits(v))
+		case bool:
+			bits = append(bits, v)
+		case time.Time:
+			levels = append(levels, !v.IsZero())
+			if !v.IsZero() {
+				_ = binary.Write(&values, binary.LittleEndian, v.UnixMilli())
+			}
+		default:
+			return nil, fmt.Errorf("unsupported value %T", v)
+		}
+	}
+	// PLAIN booleans are bit-packed, least significant bit first
+	if len(bits) > 0 {
+		packed := make([]byte, (len(bits)+7)/8)
+		for j, b := range bits {
+			if b {
+				packed[j/8] |= 1 << (j % 8)
+			}
+		}
+		values.Write(packed)
+	}
+
+	if _, _, repetition := parquetType(t.Columns[i].Type); repetition == repetitionRequired {
+		return values.Bytes(), nil
+	}
+	rle := rleLevels(levels)
+	var page bytes.Buffer
+	_ = binary.Write(&page, binary.LittleEndian, uint32(len(rle)))
+	page.Write(rle)
+	page.Write(values.Bytes())
+	return page.Bytes(), nil
+}
+
+func writeByteArray(buf *bytes.Buffer, s string) {
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(s)))
+	buf.WriteString(s)
+}
+
+// rleLevels encodes 1-bit definition levels as RLE runs of the
+// RLE/bit-packed hybrid encoding: a varint of the run length shifted left
+// by one, then the repeated value in one byte
+func rleLevels(levels []bool) []byte {
+	var buf []byte
+	for start := 0; start < len(levels); {
+		end := start
+		for end < len(levels) && levels[end] == levels[start] {
+			end++
+		}
+		buf = binary.AppendUvarint(buf, uint64(end-start)<<1)
+		if levels[start] {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+		start = end
+	}
+	return buf
+}
+
+// pageHeader encodes the PageHeader of an uncompressed data page
+func pageHeader(size, numValues int) []byte {
+	var t thriftWriter
+	t.begin()
+	t.i32(1, pageTypeData)
+	t.i32(2, int32(size)) // Uncompressed size
+	t.i32(3, int32(size)) // Compressed size
+	t.structField(5)      // DataPageHeader
+	t.i32(1, int32(numValues))
+	t.i32(2, encodingPlain)
+	t.i32(3, encodingRLE) // Definition levels
+	t.i32(4, encodingRLE) // Repetition levels
+	t.end()
+	t.end()
+	return t.buf.Bytes()
+}
+
+// fileMetaData encodes the FileMetaData footer
+func fileMetaData(t Table, chunks []columnChunk) []byte {
+	var w thriftWriter
+	w.begin()
+	w.i32(1, 1) // Version
+
+	w.list(2, thriftStruct, len(t.Columns)+1) // Schema: the root, then one leaf per column
+	w.begin()
+	w.binary(4, "schema")
+	w.i32(5, int32(len(t.Columns)))
+	w.end()
+	for _, c := range t.Columns {
+		physical, converted, repetition := parquetType(c.Type)
+		w.begin()
+		w.i32(1, physical)
+		w.i32(3, repetition)
+		w.binary(4, c.Name)
+		if converted >= 0 {
+			w.i32(6, converted)
+		}
+		w.end()
+	}
+
+	w.i64(3, int64(len(t.Rows)))
+
+	// Row groups: one, or none for an empty table
+	if len(chunks) == 0 {
+		w.list(4, thriftStruct, 0)
+	} else {
+		w.list(4, thriftStruct, 1)
+		var total int64
+		w.begin()
+		w.list(1, thriftStruct, len(chunks))
+		for i, chunk := range chunks {
+			physical, _, _ := parquetType(t.Columns[i].Type)
+			w.begin()
+			w.i64(2, chunk.offset) // File offset
+			w.structField(3)       // ColumnMetaData
+			w.i32(1, physical)
+			w.list(2, thriftI32, 2)
+			w.listI32(encodingPlain)
+			w.listI32(encodingRLE)
+			w.list(3, thriftBinary, 1)
+			w.listBinary(t.Columns[i].Name)
+			w.i32(4, codecUncompressed)
+			w.i64(5, int64(len(t.Rows)))
+			w.i64(6, chunk.size) // Uncompressed size
+			w.i64(7, chunk.size) // Compressed size
+			w.i64(9, chunk.offset)
+			w.end()
+			w.end()
+			total += chunk.size
+		}
+		w.i64(2, total)
+		w.i64(3, int64(len(t.Rows)))
+		w.end()
+	}
+
+	w.binary(6, "gh-brag") // Created by
+	w.end()
+	return w.buf.Bytes()
+}
+
+// Thrift compact protocol types
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter writes the subset of the Thrift compact protocol the Parquet
+// metadata needs. Field IDs are delta encoded against the previous field of
+// the same struct, so it tracks the last ID of each open struct.
+type thriftWriter struct {
+	buf  bytes.Buffer
+	last []int16
+}
+
+// begin opens a struct: the top-level one, or an element of a struct list
+func (t *thriftWriter) begin() {
+	t.last = append(t.last, 0)
+}
+
+// end closes the innermost struct
+func (t *thriftWriter) end() {
+	t.buf.WriteByte(0)
+	t.last = t.last[:len(t.last)-1]
+}
+
+// structField opens a struct-valued field; close it with end
+func (t *thriftWriter) structField(id int16) {
+	t.field(id, thriftStruct)
+	t.begin()
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	last := &t.last[len(t.last)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.zigzag(int64(id))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.zigzag(int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.zigzag(v)
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.listBinary(s)
+}
+
+// list starts a list field of n elements, which follow with listI32,
+// listBinary or begin/end
+func (t *thriftWriter) list(id int16, elem byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.buf.WriteByte(byte(n)<<4 | elem)
+		return
+	}
+	t.buf.WriteByte(0xf0 | elem)
+	t.uvarint(uint64(n))
+}
+
+func (t *thriftWriter) listI32(v int32) {
+	t.zigzag(int64(v))
+}
+
+func (t *thriftWriter) listBinary(s string) {
+	t.uvarint(uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+func (t *thriftWriter) zigzag(v int64) {
+	t.uvarint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+func (t *thriftWriter) uvarint(v uint64) {
+	t.buf.Write(binary.AppendUvarint(nil, v))
+}
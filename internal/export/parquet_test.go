@@ -0,0 +1,276 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// thriftReader decodes Thrift compact structs into maps of field ID to value
+type thriftReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case 1, 2:
+		return typ == 1
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		n := int(r.uvarint())
+		r.pos += n
+		return string(r.buf[r.pos-n : r.pos])
+	case thriftList:
+		head := r.buf[r.pos]
+		r.pos++
+		n, elem := int(head>>4), head&0x0f
+		if n == 15 {
+			n = int(r.uvarint())
+		}
+		list := make([]any, n)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case thriftStruct:
+		return r.structure()
+	}
+	panic("unexpected thrift type")
+}
+
+func (r *thriftReader) structure() map[int16]any {
+	fields := make(map[int16]any)
+	var last int16
+	for {
+		head := r.buf[r.pos]
+		r.pos++
+		if head == 0 {
+			return fields
+		}
+		id := last + int16(head>>4)
+		if head>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		fields[id] = r.value(head & 0x0f)
+		last = id
+	}
+}
+
+// readParquet decodes a file written by WriteParquet into its footer and column values
+func readParquet(t *testing.T, file []byte) (map[int16]any, [][]any) {
+	t.Helper()
+	require.Equal(t, parquetMagic, string(file[:4]))
+	require.Equal(t, parquetMagic, string(file[len(file)-4:]))
+	size := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := (&thriftReader{buf: file[len(file)-8-size : len(file)-8]}).structure()
+
+	schema := footer[2].([]any)[1:]
+	var columns [][]any
+	for _, group := range footer[4].([]any) {
+		for i, chunk := range group.(map[int16]any)[1].([]any) {
+			meta := chunk.(map[int16]any)[3].(map[int16]any)
+			leaf := schema[i].(map[int16]any)
+			r := &thriftReader{buf: file, pos: int(meta[9].(int64))}
+			header := r.structure()
+			page := file[r.pos : r.pos+int(header[2].(int64))]
+			numValues := int(header[5].(map[int16]any)[1].(int64))
+
+			defined := make([]bool, numValues)
+			if leaf[3].(int64) == repetitionOptional {
+				n := int(binary.LittleEndian.Uint32(page))
+				lr := &thriftReader{buf: page[4 : 4+n]}
+				for j := 0; j < numValues; {
+					run := int(lr.uvarint() >> 1)
+					v := lr.buf[lr.pos] == 1
+					lr.pos++
+					for k := 0; k < run; k++ {
+						defined[j] = v
+						j++
+					}
+				}
+				page = page[4+n:]
+			} else {
+				for j := range defined {
+					defined[j] = true
+				}
+			}
+
+			var values []any
+			for j := 0; j < numValues; j++ {
+				if !defined[j] {
+					values = append(values, nil)
+					continue
+				}
+				switch leaf[1].(int64) {
+				case physicalByteArray:
+					n := int(binary.LittleEndian.Uint32(page))
+					values = append(values, string(page[4:4+n]))
+					page = page[4+n:]
+				case physicalInt64:
+					values = append(values, int64(binary.LittleEndian.Uint64(page)))
+					page = page[8:]
+				case physicalDouble:
+					values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(page)))
+					page = page[8:]
+				case physicalBoolean:
+					values = append(values, page[j/8]&(1<<(j%8)) != 0)
+				}
+			}
+			columns = append(columns, values)
+		}
+	}
+	return footer, columns
+}
+
+func TestWriteParquet(t *testing.T) {
+	at := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	table := Table{
+		Columns: []Column{{"id", String}, {"number", Int}, {"impact", Float}, {"private", Bool}, {"closedAt", Time}, {"labels", List}},
+		Rows: [][]any{
+			{"pr:1", int64(1), 12.5, true, at, []string{"bug", "p1"}},
+			{"pr:2", int64(2), 0.0, false, time.Time{}, []string(nil)},
+			{"pr:3", int64(3), 1.0, true, time.Time{}, []string{"ui"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteParquet(&buf, table))
+	footer, columns := readParquet(t, buf.Bytes())
+
+	assert.Equal(t, int64(3), footer[3], "num_rows")
+	schema := footer[2].([]any)
+	require.Len(t, schema, 7)
+	assert.Equal(t, "schema", schema[0].(map[int16]any)[4])
+	closedAt := schema[5].(map[int16]any)
+	assert.Equal(t, "closedAt", closedAt[4])
+	assert.Equal(t, int64(convertedTimestampMillis), closedAt[6])
+
+	assert.Equal(t, [][]any{
+		{"pr:1", "pr:2", "pr:3"},
+		{int64(1), int64(2), int64(3)},
+		{12.5, 0.0, 1.0},
+		{true, false, true},
+		{at.UnixMilli(), nil, nil},
+		{"bug;p1", "", "ui"},
+	}, columns)
+
+	// An empty table still has a schema, and no row groups
+	buf.Reset()
+	require.NoError(t, WriteParquet(&buf, Table{Columns: table.Columns}))
+	footer, columns = readParquet(t, buf.Bytes())
+	assert.Equal(t, int64(0), footer[3])
+	assert.Len(t, footer[2], 7)
+	assert.Empty(t, columns)
+
+	assert.Error(t, WriteParquet(&buf, Table{Columns: []Column{{"x", Int}}, Rows: [][]any{{1}}}), "int is not int64")
+}
+
+func TestThriftWriterLongFieldDeltas(t *testing.T) {
+	var w thriftWriter
+	w.begin()
+	w.i32(1, -3)
+	w.i64(20, 1<<40) // Delta over 15 needs the long form
+	w.list(3, thriftBinary, 16)
+	for range 16 {
+		w.listBinary("x")
+	}
+	w.end()
+
+	got := (&thriftReader{buf: w.buf.Bytes()}).structure()
+	assert.Equal(t, int64(-3), got[1])
+	assert.Equal(t, int64(1<<40), got[20])
+	assert.Len(t, got[3], 16)
+}
+
+// referenceReaders read a Parquet file with an independent implementation and
+// print its rows as a JSON array of objects, timestamps as "YYYY-MM-DD hh:mm:ss"
+var referenceReaders = []struct {
+	name      string
+	installed []string // A command that succeeds when the reader is installed
+	args      func(path string) []string
+}{
+	{"duckdb", []string{"duckdb", "-version"}, func(path string) []string {
+		return []string{"duckdb", "-json", "-c", fmt.Sprintf("SELECT * FROM read_parquet('%s')", path)}
+	}},
+	{"pyarrow", []string{"python3", "-c", "import pyarrow.parquet"}, func(path string) []string {
+		return []string{"python3", "-c", `import json, sys, pyarrow.parquet as pq
+print(json.dumps(pq.read_table(sys.argv[1]).to_pylist(), default=lambda v: v.strftime("%Y-%m-%d %H:%M:%S")))`, path}
+	}},
+}
+
+// TestWriteParquet_Reference checks the writer against the format spec as other
+// readers implement it, rather than against readParquet above. It is skipped
+// when neither DuckDB nor pyarrow is installed, unless
+// GH_BRAG_REQUIRE_PARQUET_READER is set, as it is in CI.
+func TestWriteParquet_Reference(t *testing.T) {
+	at := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	table := Table{
+		Columns: []Column{{"id", String}, {"number", Int}, {"impact", Float}, {"private", Bool}, {"closedAt", Time}, {"labels", List}},
+		Rows: [][]any{
+			{"pr:1", int64(1), 12.5, true, at, []string{"bug", "p1"}},
+			{"pr:2", int64(2), 0.0, false, time.Time{}, []string(nil)},
+			{"pr:3", int64(3), 1.0, true, time.Time{}, []string{"ui"}},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "events.parquet")
+	var buf bytes.Buffer
+	require.NoError(t, WriteParquet(&buf, table))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+	want := []map[string]any{
+		{"id": "pr:1", "number": 1.0, "impact": 12.5, "private": true, "closedAt": "2026-03-04 10:00:00", "labels": "bug;p1"},
+		{"id": "pr:2", "number": 2.0, "impact": 0.0, "private": false, "closedAt": nil, "labels": ""},
+		{"id": "pr:3", "number": 3.0, "impact": 1.0, "private": true, "closedAt": nil, "labels": "ui"},
+	}
+
+	ran := false
+	for _, r := range referenceReaders {
+		if exec.Command(r.installed[0], r.installed[1:]...).Run() != nil {
+			continue
+		}
+		ran = true
+		t.Run(r.name, func(t *testing.T) {
+			args := r.args(path)
+			out, err := exec.Command(args[0], args[1:]...).Output()
+			require.NoError(t, err, "%s could not read the file", r.name)
+			var rows []map[string]any
+			require.NoError(t, json.Unmarshal(out, &rows), string(out))
+			for i := range rows {
+				if s, ok := rows[i]["closedAt"].(string); ok {
+					rows[i]["closedAt"] = strings.TrimSuffix(s, ".000") // DuckDB prints milliseconds
+				}
+			}
+			assert.Equal(t, want, rows)
+		})
+	}
+	if !ran && os.Getenv("GH_BRAG_REQUIRE_PARQUET_READER") != "" {
+		t.Fatal("no reference Parquet reader (duckdb, or python3 with pyarrow) installed")
+	}
+	if !ran {
+		t.Skip("no reference Parquet reader (duckdb, or python3 with pyarrow) installed")
+	}
+}
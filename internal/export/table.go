@@ -0,0 +1,93 @@
+// Package export writes events and metrics as flat tables in CSV, NDJSON or
+// Parquet, for spreadsheets and tools such as DuckDB.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Type is the type of a column's values
+type Type int
+
+const (
+	String Type = iota // string
+	Int                // int64
+	Float              // float64
+	Bool               // bool
+	Time               // time.Time; the zero time is null
+	List               // []string; joined with ListSep except in NDJSON
+)
+
+// ListSep joins list values, such as labels, in CSV and Parquet
+const ListSep = ";"
+
+// Column is a named, typed column of a table
+type Column struct {
+	Name string
+	Type Type
+}
+
+// Table is a list of rows; each row holds one value per column, of the column's type
+type Table struct {
+	Columns []Column
+	Rows    [][]any
+}
+
+// Names returns the column names
+func (t Table) Names() []string {
+	names := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Select returns a table with only the named columns, in the given order
+func (t Table) Select(names []string) (Table, error) {
+	index := make(map[string]int, len(t.Columns))
+	for i, c := range t.Columns {
+		index[c.Name] = i
+	}
+	picked := make([]int, 0, len(names))
+	out := Table{Columns: make([]Column, 0, len(names)), Rows: make([][]any, len(t.Rows))}
+	for _, name := range names {
+		i, ok := index[name]
+		if !ok {
+			return Table{}, fmt.Errorf("unknown column %q (want one of %s)", name, strings.Join(t.Names(), ", "))
+		}
+		picked = append(picked, i)
+		out.Columns = append(out.Columns, t.Columns[i])
+	}
+	for r, row := range t.Rows {
+		out.Rows[r] = make([]any, len(picked))
+		for j, i := range picked {
+			out.Rows[r][j] = row[i]
+		}
+	}
+	return out, nil
+}
+
+// Export formats
+const (
+	FormatCSV     = "csv"
+	FormatNDJSON  = "ndjson"
+	FormatParquet = "parquet"
+)
+
+// Formats lists the supported formats
+var Formats = []string{FormatCSV, FormatNDJSON, FormatParquet}
+
+// Write writes the table to w in format
+func Write(w io.Writer, t Table, format string) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, t)
+	case FormatNDJSON:
+		return WriteNDJSON(w, t)
+	case FormatParquet:
+		return WriteParquet(w, t)
+	}
+	return fmt.Errorf("unknown format %q (want one of %s)", format, strings.Join(Formats, ", "))
+}
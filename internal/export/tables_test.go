@@ -0,0 +1,66 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAnalyzer(t *testing.T) *analyze.Analyzer {
+	t.Helper()
+	a, err := analyze.New(&config.Config{
+		Themes: []config.Theme{{Name: "Feature", Keywords: []string{"feat"}}},
+		Metrics: config.Metrics{
+			ActionWeights: map[data.EventAction]float64{data.EventActionMerged: 10, data.EventActionReviewed: 3},
+			ThemeWeights:  map[string]float64{"Feature": 2},
+		},
+	})
+	require.NoError(t, err)
+	return a
+}
+
+func testEvents() []data.Event {
+	at := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	return []data.Event{
+		{ID: "pr:1:merged", Action: data.EventActionMerged, Kind: "pr", Repo: "acme/api", Number: 1, Title: "feat: cache", Labels: []string{"perf", "api"}, Reviewers: []string{"bob"}, Timestamps: data.Timestamps{CreatedAt: at, UpdatedAt: at, ClosedAt: at}},
+		{ID: "pr:2:reviewed", Action: data.EventActionReviewed, Kind: "pr", Repo: "acme/web", Number: 2, Title: "Fix layout", Reviewers: []string{}, Timestamps: data.Timestamps{UpdatedAt: at.AddDate(0, 0, 7)}},
+		{ID: "jira:ABC-1", Kind: "issue", Key: "ABC-1", Title: "feat: checkout"},
+	}
+}
+
+func TestEvents(t *testing.T) {
+	table := Events(testEvents(), testAnalyzer(t))
+	require.Len(t, table.Rows, 3)
+
+	got, err := table.Select([]string{"id", "owner", "labels", "theme", "impact", "key", "closedAt"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"pr:1:merged", "acme", []string{"perf", "api"}, "Feature", 20.0, "", time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)}, got.Rows[0])
+	assert.Equal(t, []any{"pr:2:reviewed", "acme", []string(nil), "Other", 3.0, "", time.Time{}}, got.Rows[1])
+	assert.Equal(t, []any{"jira:ABC-1", "", []string(nil), "", 0.0, "ABC-1", time.Time{}}, got.Rows[2], "tickets score nothing")
+
+	_, err = table.Select(DefaultEventColumns)
+	assert.NoError(t, err)
+}
+
+func TestMetricsTables(t *testing.T) {
+	m := testAnalyzer(t).Analyze(testEvents())
+
+	weekly := Weekly(m)
+	assert.Equal(t, []string{"week", "events"}, weekly.Names())
+	assert.Equal(t, [][]any{{"2026-03-02", int64(1)}, {"2026-03-09", int64(1)}}, weekly.Rows)
+
+	repos := Repos(m)
+	require.Len(t, repos.Rows, 2)
+	assert.Equal(t, []any{"acme/api", int64(1), int64(0), int64(0), int64(0), 20.0, 20.0 / 23 * 100}, repos.Rows[0])
+
+	themes := Themes(m)
+	assert.Equal(t, [][]any{
+		{"Feature", int64(1), 50.0, 20.0, 20.0 / 23 * 100},
+		{"Other", int64(1), 50.0, 3.0, 3.0 / 23 * 100},
+	}, themes.Rows)
+}
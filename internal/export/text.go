@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteCSV writes the table with a header row. Times are RFC 3339 (empty when
+// null) and lists are joined with ListSep.
+func WriteCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Names()); err != nil {
+		return err
+	}
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i, v := range row {
+			record[i] = text(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteNDJSON writes one JSON object per row, with keys in column order.
+// Null times are null and lists stay arrays.
+func WriteNDJSON(w io.Writer, t Table) error {
+	bw := bufio.NewWriter(w)
+	keys := make([][]byte, len(t.Columns))
+	for i, c := range t.Columns {
+		key, err := json.Marshal(c.Name)
+		if err != nil {
+			return err
+		}
+		keys[i] = key
+	}
+
+	for _, row := range t.Rows {
+		_ = bw.WriteByte('{')
+		for i, v := range row {
+			if i > 0 {
+				_ = bw.WriteByte(',')
+			}
+			_, _ = bw.Write(keys[i])
+			_ = bw.WriteByte(':')
+			if tm, ok := v.(time.Time); ok && tm.IsZero() {
+				v = nil
+			}
+			if l, ok := v.([]string); ok && l == nil {
+				v = []string{}
+			}
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			_, _ = bw.Write(value)
+		}
+		_, _ = bw.WriteString("}\n")
+	}
+	return bw.Flush()
+}
+
+// text formats a value for CSV
+func text(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ListSep)
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTable() Table {
+	return Table{
+		Columns: []Column{{"id", String}, {"number", Int}, {"impact", Float}, {"private", Bool}, {"closedAt", Time}, {"labels", List}},
+		Rows: [][]any{
+			{"pr:1", int64(1), 12.5, true, time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC), []string{"bug", "p1"}},
+			{"pr:\"2\", quoted", int64(2), 0.0, false, time.Time{}, []string(nil)},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, testTable()))
+	assert.Equal(t, "id,number,impact,private,closedAt,labels\n"+
+		"pr:1,1,12.5,true,2026-03-04T10:00:00Z,bug;p1\n"+
+		"\"pr:\"\"2\"\", quoted\",2,0,false,,\n", buf.String())
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteNDJSON(&buf, testTable()))
+	assert.Equal(t, `{"id":"pr:1","number":1,"impact":12.5,"private":true,"closedAt":"2026-03-04T10:00:00Z","labels":["bug","p1"]}`+"\n"+
+		`{"id":"pr:\"2\", quoted","number":2,"impact":0,"private":false,"closedAt":null,"labels":[]}`+"\n", buf.String())
+}
+
+func TestSelect(t *testing.T) {
+	got, err := testTable().Select([]string{"labels", "id"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"labels", "id"}, got.Names())
+	assert.Equal(t, []any{[]string{"bug", "p1"}, "pr:1"}, got.Rows[0])
+
+	_, err = testTable().Select([]string{"nope"})
+	assert.ErrorContains(t, err, `unknown column "nope" (want one of id, number, impact, private, closedAt, labels)`)
+}
+
+func TestWrite(t *testing.T) {
+	for _, format := range Formats {
+		var buf bytes.Buffer
+		assert.NoError(t, Write(&buf, testTable(), format), format)
+		assert.NotEmpty(t, buf.Bytes(), format)
+	}
+	assert.ErrorContains(t, Write(&bytes.Buffer{}, testTable(), "xlsx"), `unknown format "xlsx"`)
+}
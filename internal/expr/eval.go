@@ -0,0 +1,561 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Value is a runtime value: float64, string, bool, time.Time, []string, []Value or nil.
+type Value any
+
+// Record maps field names to values for a single event.
+type Record map[string]Value
+
+// aggregates are evaluated over all records; their first argument is evaluated per record
+var aggregates = map[string]struct{ minArgs, maxArgs int }{
+	"count":  {0, 1}, // count([predicate])
+	"sum":    {1, 2}, // sum(value[, predicate])
+	"avg":    {1, 2},
+	"median": {1, 2},
+	"min":    {1, 2},
+	"max":    {1, 2},
+}
+
+// scalars are evaluated per record
+var scalars = map[string]int{
+	"lower": 1, // lower(string)
+	"len":   1, // len(string | list)
+	"days":  1, // days(hours) converts a duration in hours to days
+}
+
+// Program is a compiled metric expression.
+type Program struct {
+	src  string
+	root node
+}
+
+// Compile parses src and checks it only uses known fields and functions.
+// Fields must appear inside an aggregate such as count() or sum().
+func Compile(src string) (*Program, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := check(root, false); err != nil {
+		return nil, err
+	}
+	return &Program{src: src, root: root}, nil
+}
+
+// String returns the expression source
+func (p *Program) String() string {
+	return p.src
+}
+
+func check(n node, inAggregate bool) error {
+	switch n := n.(type) {
+	case *literal:
+		return nil
+	case *ident:
+		if !IsField(n.name) {
+			return &Error{Pos: n.at, Msg: fmt.Sprintf("unknown field %q (known fields: %s)", n.name, strings.Join(EventFields, ", "))}
+		}
+		if !inAggregate {
+			return &Error{Pos: n.at, Msg: fmt.Sprintf("field %q must be used inside an aggregate such as count() or sum()", n.name)}
+		}
+		return nil
+	case *list:
+		for _, item := range n.items {
+			if err := check(item, inAggregate); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *unary:
+		return check(n.operand, inAggregate)
+	case *binary:
+		if err := check(n.left, inAggregate); err != nil {
+			return err
+		}
+		return check(n.right, inAggregate)
+	case *call:
+		if arity, ok := aggregates[n.name]; ok {
+			if inAggregate {
+				return &Error{Pos: n.at, Msg: fmt.Sprintf("aggregate %s() cannot be nested", n.name)}
+			}
+			if len(n.args) < arity.minArgs || len(n.args) > arity.maxArgs {
+				return &Error{Pos: n.at, Msg: fmt.Sprintf("%s() takes %d to %d arguments, got %d", n.name, arity.minArgs, arity.maxArgs, len(n.args))}
+			}
+			for _, arg := range n.args {
+				if err := check(arg, true); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if arity, ok := scalars[n.name]; ok {
+			if len(n.args) != arity {
+				return &Error{Pos: n.at, Msg: fmt.Sprintf("%s() takes %d argument(s), got %d", n.name, arity, len(n.args))}
+			}
+			for _, arg := range n.args {
+				if err := check(arg, inAggregate); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return &Error{Pos: n.at, Msg: fmt.Sprintf("unknown function %s()", n.name)}
+	}
+	return nil
+}
+
+// Eval evaluates the program over records.
+// ok is false when the result is null, e.g. the median of no values.
+func (p *Program) Eval(records []Record) (result float64, ok bool, err error) {
+	v, err := eval(p.root, nil, records)
+	if err != nil {
+		return 0, false, err
+	}
+	switch v := v.(type) {
+	case nil:
+		return 0, false, nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, false, nil
+		}
+		return v, true, nil
+	case bool:
+		if v {
+			return 1, true, nil
+		}
+		return 0, true, nil
+	default:
+		return 0, false, fmt.Errorf("expression %q must produce a number, got %s", p.src, typeName(v))
+	}
+}
+
+func eval(n node, rec Record, records []Record) (Value, error) {
+	switch n := n.(type) {
+	case *literal:
+		return n.value, nil
+
+	case *ident:
+		return rec[n.name], nil
+
+	case *list:
+		items := make([]Value, len(n.items))
+		for i, item := range n.items {
+			v, err := eval(item, rec, records)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = v
+		}
+		return items, nil
+
+	case *unary:
+		v, err := eval(n.operand, rec, records)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == "!" {
+			return !truthy(v), nil
+		}
+		if v == nil {
+			return nil, nil
+		}
+		f, ok := v.(float64)
+		if !ok {
+			return nil, &Error{Pos: n.at, Msg: fmt.Sprintf("cannot negate %s", typeName(v))}
+		}
+		return -f, nil
+
+	case *binary:
+		return evalBinary(n, rec, records)
+
+	case *call:
+		if _, ok := aggregates[n.name]; ok {
+			return evalAggregate(n, records)
+		}
+		return evalScalar(n, rec, records)
+	}
+	return nil, nil
+}
+
+func evalBinary(n *binary, rec Record, records []Record) (Value, error) {
+	left, err := eval(n.left, rec, records)
+	if err != nil {
+		return nil, err
+	}
+
+	// Short-circuit logical operators
+	switch n.op {
+	case "&&":
+		if !truthy(left) {
+			return false, nil
+		}
+		right, err := eval(n.right, rec, records)
+		if err != nil {
+			return nil, err
+		}
+		return truthy(right), nil
+	case "||":
+		if truthy(left) {
+			return true, nil
+		}
+		right, err := eval(n.right, rec, records)
+		if err != nil {
+			return nil, err
+		}
+		return truthy(right), nil
+	}
+
+	right, err := eval(n.right, rec, records)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "=~", "!~":
+		matched, err := match(n, left, right)
+		if err != nil {
+			return nil, err
+		}
+		return matched == (n.op == "=~"), nil
+	case "in":
+		return contains(right, left), nil
+	case "<", "<=", ">", ">=":
+		if left == nil || right == nil {
+			return false, nil
+		}
+		c, err := compare(n, left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	}
+
+	return arithmetic(n, left, right)
+}
+
+func arithmetic(n *binary, left, right Value) (Value, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	// time - time yields hours
+	if lt, ok := left.(time.Time); ok {
+		if rt, ok := right.(time.Time); ok && n.op == "-" {
+			return lt.Sub(rt).Hours(), nil
+		}
+	}
+	if ls, ok := left.(string); ok {
+		if rs, ok := right.(string); ok && n.op == "+" {
+			return ls + rs, nil
+		}
+	}
+
+	lf, lok := left.(float64)
+	rf, rok := right.(float64)
+	if !lok || !rok {
+		return nil, &Error{Pos: n.at, Msg: fmt.Sprintf("cannot apply %s to %s and %s", n.op, typeName(left), typeName(right))}
+	}
+	switch n.op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	default:
+		if rf == 0 {
+			return nil, nil
+		}
+		return lf / rf, nil
+	}
+}
+
+func evalAggregate(n *call, records []Record) (Value, error) {
+	if n.name == "count" {
+		count := 0
+		for _, rec := range records {
+			if len(n.args) == 0 {
+				count++
+				continue
+			}
+			v, err := eval(n.args[0], rec, records)
+			if err != nil {
+				return nil, err
+			}
+			if truthy(v) {
+				count++
+			}
+		}
+		return float64(count), nil
+	}
+
+	var values []float64
+	for _, rec := range records {
+		if len(n.args) == 2 {
+			pred, err := eval(n.args[1], rec, records)
+			if err != nil {
+				return nil, err
+			}
+			if !truthy(pred) {
+				continue
+			}
+		}
+		v, err := eval(n.args[0], rec, records)
+		if err != nil {
+			return nil, err
+		}
+		switch v := v.(type) {
+		case nil:
+			continue // Nulls are skipped
+		case float64:
+			values = append(values, v)
+		case bool:
+			if v {
+				values = append(values, 1)
+			} else {
+				values = append(values, 0)
+			}
+		default:
+			return nil, &Error{Pos: n.at, Msg: fmt.Sprintf("%s() needs numbers, got %s", n.name, typeName(v))}
+		}
+	}
+
+	if n.name == "sum" {
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		return total, nil
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	switch n.name {
+	case "avg":
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		return total / float64(len(values)), nil
+	case "median":
+		sort.Float64s(values)
+		mid := len(values) / 2
+		if len(values)%2 == 0 {
+			return (values[mid-1] + values[mid]) / 2, nil
+		}
+		return values[mid], nil
+	case "min":
+		m := values[0]
+		for _, v := range values[1:] {
+			m = math.Min(m, v)
+		}
+		return m, nil
+	default: // max
+		m := values[0]
+		for _, v := range values[1:] {
+			m = math.Max(m, v)
+		}
+		return m, nil
+	}
+}
+
+func evalScalar(n *call, rec Record, records []Record) (Value, error) {
+	v, err := eval(n.args[0], rec, records)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	switch n.name {
+	case "lower":
+		s, ok := v.(string)
+		if !ok {
+			return nil, &Error{Pos: n.at, Msg: fmt.Sprintf("lower() needs a string, got %s", typeName(v))}
+		}
+		return strings.ToLower(s), nil
+	case "len":
+		switch v := v.(type) {
+		case string:
+			return float64(len([]rune(v))), nil
+		case []string:
+			return float64(len(v)), nil
+		case []Value:
+			return float64(len(v)), nil
+		}
+		return nil, &Error{Pos: n.at, Msg: fmt.Sprintf("len() needs a string or list, got %s", typeName(v))}
+	default: // days
+		f, ok := v.(float64)
+		if !ok {
+			return nil, &Error{Pos: n.at, Msg: fmt.Sprintf("days() needs a number of hours, got %s", typeName(v))}
+		}
+		return f / 24, nil
+	}
+}
+
+func match(n *binary, left, right Value) (bool, error) {
+	re := n.re
+	if re == nil {
+		pattern, ok := right.(string)
+		if !ok {
+			return false, &Error{Pos: n.right.pos(), Msg: "regular expression must be a string"}
+		}
+		var err error
+		re, err = regexp.Compile(pattern)
+		if err != nil {
+			return false, &Error{Pos: n.right.pos(), Msg: fmt.Sprintf("invalid regular expression: %v", err)}
+		}
+	}
+
+	switch l := left.(type) {
+	case string:
+		return re.MatchString(l), nil
+	case []string:
+		// Lists match if any element matches
+		for _, s := range l {
+			if re.MatchString(s) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case nil:
+		return false, nil
+	}
+	return false, &Error{Pos: n.at, Msg: fmt.Sprintf("cannot match %s against a regular expression", typeName(left))}
+}
+
+func contains(container, item Value) bool {
+	switch c := container.(type) {
+	case []string:
+		s, ok := item.(string)
+		if !ok {
+			return false
+		}
+		for _, v := range c {
+			if v == s {
+				return true
+			}
+		}
+	case []Value:
+		for _, v := range c {
+			if equal(v, item) {
+				return true
+			}
+		}
+	case string:
+		s, ok := item.(string)
+		return ok && strings.Contains(c, s)
+	}
+	return false
+}
+
+func compare(n *binary, left, right Value) (int, error) {
+	switch l := left.(type) {
+	case float64:
+		if r, ok := right.(float64); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	case time.Time:
+		if r, ok := right.(time.Time); ok {
+			return l.Compare(r), nil
+		}
+		// Allow comparing against "YYYY-MM-DD" literals
+		if r, ok := right.(string); ok {
+			t, err := time.Parse("2006-01-02", r)
+			if err != nil {
+				return 0, &Error{Pos: n.right.pos(), Msg: fmt.Sprintf("invalid date %q (want YYYY-MM-DD)", r)}
+			}
+			return l.Compare(t), nil
+		}
+	}
+	return 0, &Error{Pos: n.at, Msg: fmt.Sprintf("cannot compare %s and %s", typeName(left), typeName(right))}
+}
+
+func equal(left, right Value) bool {
+	switch l := left.(type) {
+	case nil:
+		return right == nil
+	case time.Time:
+		r, ok := right.(time.Time)
+		return ok && l.Equal(r)
+	case []string, []Value:
+		return false
+	}
+	switch right.(type) {
+	case []string, []Value:
+		return false
+	}
+	return left == right
+}
+
+func truthy(v Value) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case time.Time:
+		return !v.IsZero()
+	case []string:
+		return len(v) > 0
+	case []Value:
+		return len(v) > 0
+	}
+	return true
+}
+
+func typeName(v Value) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case time.Time:
+		return "time"
+	case []string, []Value:
+		return "list"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,115 @@
+package expr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecords() []Record {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []Record{
+		EventRecord(data.Event{
+			Action: data.EventActionMerged, Repo: "platform/api", Title: "Add cache",
+			Labels:     []string{"perf"},
+			Timestamps: data.Timestamps{CreatedAt: created, ClosedAt: created.Add(24 * time.Hour)},
+		}, "Feature"),
+		EventRecord(data.Event{
+			Action: data.EventActionMerged, Repo: "platform/web", Title: "Fix login",
+			Labels:     []string{"bug", "urgent"},
+			Timestamps: data.Timestamps{CreatedAt: created, ClosedAt: created.Add(72 * time.Hour)},
+		}, "Bug Fix"),
+		EventRecord(data.Event{
+			Action: data.EventActionReviewed, Repo: "tools/cli", Title: "Bump deps",
+			Timestamps: data.Timestamps{CreatedAt: created},
+		}, "Maintenance"),
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		want   float64
+		wantOk bool
+	}{
+		{name: "count all", src: "count()", want: 3, wantOk: true},
+		{name: "count with regex", src: `count(action == "merged" && repo =~ "platform/.*")`, want: 2, wantOk: true},
+		{name: "negated regex", src: `count(repo !~ "^platform/")`, want: 1, wantOk: true},
+		{name: "membership in list field", src: `count("bug" in labels)`, want: 1, wantOk: true},
+		{name: "membership in list literal", src: `count(theme in ["Feature", "Bug Fix"])`, want: 2, wantOk: true},
+		{name: "regex over list field", src: `count(labels =~ "^urg")`, want: 1, wantOk: true},
+		{name: "median duration skips nulls", src: "median(closedAt - createdAt)", want: 48, wantOk: true},
+		{name: "duration in days", src: "days(max(closedAt - createdAt))", want: 3, wantOk: true},
+		{name: "aggregate with predicate", src: `avg(closedAt - createdAt, repo == "platform/api")`, want: 24, wantOk: true},
+		{name: "arithmetic over aggregates", src: `count(action == "merged") / count() * 100`, want: 200.0 / 3, wantOk: true},
+		{name: "operator precedence", src: "1 + 2 * 3", want: 7, wantOk: true},
+		{name: "unary operators", src: `count(!(action == "merged")) - -1`, want: 2, wantOk: true},
+		{name: "time compared with date literal", src: `count(createdAt >= "2026-01-01")`, want: 3, wantOk: true},
+		{name: "string functions", src: `count(lower(title) =~ "fix") + sum(len(labels))`, want: 4, wantOk: true},
+		{name: "no data is null", src: `median(closedAt - createdAt, action == "authored")`, wantOk: false},
+		{name: "sum of nothing is zero", src: `sum(number, action == "authored")`, want: 0, wantOk: true},
+		{name: "division by zero is null", src: `count() / count(action == "authored")`, wantOk: false},
+	}
+
+	records := testRecords()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Compile(tt.src)
+			require.NoError(t, err)
+
+			got, ok, err := p.Eval(records)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		errContains string
+	}{
+		{name: "unknown field", src: "count(colour == 1)", errContains: `unknown field "colour"`},
+		{name: "field outside aggregate", src: `action == "merged"`, errContains: "must be used inside an aggregate"},
+		{name: "nested aggregate", src: "count(count() > 1)", errContains: "cannot be nested"},
+		{name: "unknown function", src: "mode(number)", errContains: "unknown function mode()"},
+		{name: "wrong arity", src: "sum()", errContains: "sum() takes 1 to 2 arguments"},
+		{name: "invalid regex", src: `count(repo =~ "(")`, errContains: "invalid regular expression"},
+		{name: "unterminated string", src: `count(repo == "x)`, errContains: "unterminated string"},
+		{name: "missing paren", src: "count(", errContains: "unexpected end of expression"},
+		{name: "trailing tokens", src: "count() count()", errContains: `unexpected "count"`},
+		{name: "bad character", src: "count() & 1", errContains: "unexpected character"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.src)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestEvalTypeError(t *testing.T) {
+	p, err := Compile(`sum(title)`)
+	require.NoError(t, err)
+
+	_, _, err = p.Eval(testRecords())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sum() needs numbers, got string")
+}
+
+func TestGroupKeys(t *testing.T) {
+	rec := testRecords()[1]
+
+	assert.Equal(t, []string{"platform/web"}, GroupKeys(rec, "repo"))
+	assert.Equal(t, []string{"bug", "urgent"}, GroupKeys(rec, "labels"))
+	assert.Equal(t, []string{"2026-01"}, GroupKeys(rec, "createdAt"))
+	assert.Nil(t, GroupKeys(testRecords()[2], "closedAt"))
+}
@@ -0,0 +1,257 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Error is a syntax or semantic error at a position in the expression source.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("at offset %d: %s", e.Pos, e.Msg)
+}
+
+type node interface {
+	pos() int
+}
+
+type (
+	literal struct {
+		at    int
+		value Value
+	}
+	ident struct {
+		at   int
+		name string
+	}
+	list struct {
+		at    int
+		items []node
+	}
+	unary struct {
+		at      int
+		op      string
+		operand node
+	}
+	binary struct {
+		at          int
+		op          string
+		left, right node
+		re          *regexp.Regexp // Precompiled for =~ / !~ with a literal pattern
+	}
+	call struct {
+		at   int
+		name string
+		args []node
+	}
+)
+
+func (n *literal) pos() int { return n.at }
+func (n *ident) pos() int   { return n.at }
+func (n *list) pos() int    { return n.at }
+func (n *unary) pos() int   { return n.at }
+func (n *binary) pos() int  { return n.at }
+func (n *call) pos() int    { return n.at }
+
+// binaryPrecedence orders binary operators from loosest to tightest binding
+var binaryPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "=~", "!~", "<", "<=", ">", ">=", "in"},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+type parser struct {
+	tokens []token
+	i      int
+}
+
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+// isOp reports whether t is the operator (or the "in" keyword) op
+func isOp(t token, op string) bool {
+	if op == "in" {
+		return t.kind == tokIdent && t.text == "in"
+	}
+	return t.kind == tokOp && t.text == op
+}
+
+func (p *parser) expect(op string) error {
+	t := p.next()
+	if !isOp(t, op) {
+		if t.kind == tokEOF {
+			return &Error{Pos: t.pos, Msg: fmt.Sprintf("expected %q, got end of expression", op)}
+		}
+		return &Error{Pos: t.pos, Msg: fmt.Sprintf("expected %q, got %q", op, t.text)}
+	}
+	return nil
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		op := ""
+		for _, candidate := range binaryPrecedence[level] {
+			if isOp(t, candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		b := &binary{at: t.pos, op: op, left: left, right: right}
+		if op == "=~" || op == "!~" {
+			if lit, ok := right.(*literal); ok {
+				pattern, ok := lit.value.(string)
+				if !ok {
+					return nil, &Error{Pos: right.pos(), Msg: "regular expression must be a string"}
+				}
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return nil, &Error{Pos: right.pos(), Msg: fmt.Sprintf("invalid regular expression: %v", err)}
+				}
+				b.re = re
+			}
+		}
+		left = b
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if isOp(t, "!") || isOp(t, "-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unary{at: t.pos, op: t.text, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &literal{at: t.pos, value: t.num}, nil
+
+	case tokString:
+		return &literal{at: t.pos, value: t.text}, nil
+
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return &literal{at: t.pos, value: true}, nil
+		case "false":
+			return &literal{at: t.pos, value: false}, nil
+		case "null":
+			return &literal{at: t.pos, value: nil}, nil
+		}
+		if isOp(p.peek(), "(") {
+			p.next()
+			args, err := p.parseArgs(")")
+			if err != nil {
+				return nil, err
+			}
+			return &call{at: t.pos, name: strings.ToLower(t.text), args: args}, nil
+		}
+		return &ident{at: t.pos, name: t.text}, nil
+
+	case tokOp:
+		switch t.text {
+		case "(":
+			n, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "[":
+			items, err := p.parseArgs("]")
+			if err != nil {
+				return nil, err
+			}
+			return &list{at: t.pos, items: items}, nil
+		}
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+
+	default:
+		return nil, &Error{Pos: t.pos, Msg: "unexpected end of expression"}
+	}
+}
+
+// parseArgs parses a comma-separated list up to the closing operator
+func (p *parser) parseArgs(closing string) ([]node, error) {
+	var args []node
+	if isOp(p.peek(), closing) {
+		p.next()
+		return args, nil
+	}
+	for {
+		n, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, n)
+		if isOp(p.peek(), ",") {
+			p.next()
+			continue
+		}
+		if err := p.expect(closing); err != nil {
+			return nil, err
+		}
+		return args, nil
+	}
+}
@@ -0,0 +1,87 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// EventFields lists the fields available to expressions.
+// Time fields are null when unset; subtracting two times yields hours.
+var EventFields = []string{
+	"id", "action", "kind", "repo", "owner", "number", "title", "body", "author",
+	"labels", "reviewers", "subject", "theme", "createdAt", "updatedAt", "closedAt",
+	"additions", "deletions", "changedFiles",
+}
+
+// IsField reports whether name is a known event field
+func IsField(name string) bool {
+	for _, f := range EventFields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// EventRecord converts an event and its resolved theme into a Record
+func EventRecord(e data.Event, theme string) Record {
+	owner, _, _ := strings.Cut(e.Repo, "/")
+	return Record{
+		"id":        e.ID,
+		"action":    string(e.Action),
+		"kind":      e.Kind,
+		"repo":      e.Repo,
+		"owner":     owner,
+		"number":    float64(e.Number),
+		"title":     e.Title,
+		"body":      e.Body,
+		"author":    e.Author,
+		"labels":    stringList(e.Labels),
+		"reviewers": stringList(e.Reviewers),
+		"subject":   e.Subject,
+		"theme":     theme,
+		"createdAt": timeValue(e.Timestamps.CreatedAt),
+		"updatedAt": timeValue(e.Timestamps.UpdatedAt),
+		"closedAt":  timeValue(e.Timestamps.ClosedAt),
+
+		"additions":    float64(e.Additions),
+		"deletions":    float64(e.Deletions),
+		"changedFiles": float64(e.ChangedFiles),
+	}
+}
+
+// GroupKeys returns the group-by keys of a record for field.
+// List fields put the record in one group per element, time fields group by month (YYYY-MM),
+// and null values yield no group.
+func GroupKeys(rec Record, field string) []string {
+	switch v := rec[field].(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case time.Time:
+		return []string{v.Format("2006-01")}
+	}
+	return nil
+}
+
+func stringList(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func timeValue(t time.Time) Value {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
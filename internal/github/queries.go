@@ -4,15 +4,24 @@ package github
 type QueryType int
 
 const (
-	// QueryBasic fetches PR/Issue with labels and reviewer logins (for collect command)
+	// QueryBasic fetches PR/Issue with labels, reviewer logins, head branch and merge commit (for collect command)
 	QueryBasic QueryType = iota
-	// QueryWithLinkedIssues fetches PR with closingIssuesReferences and mergedAt (for daily authored PRs)
+	// QueryWithCommits is QueryBasic plus the SHAs of each PR's commits (for collect --commits)
+	QueryWithCommits
+	// QueryWithLinkedIssues fetches PR with closingIssuesReferences, mergedAt and review/CI status (for daily authored PRs)
 	QueryWithLinkedIssues
-	// QueryWithReviews fetches PR with review details including state and submittedAt (for daily reviews)
+	// QueryWithReviews fetches PR with labels and review details including state and submittedAt (for daily reviews)
 	QueryWithReviews
+	// QueryOpenPRs fetches open PRs with review/CI status (for daily in-progress and review requests)
+	QueryOpenPRs
+	// QueryAssignedIssues fetches issues without bodies (for daily assigned issues)
+	QueryAssignedIssues
+	// QueryIssueActivity fetches issues with recent comments, closes, labels and assignments (for daily issue activity)
+	QueryIssueActivity
 )
 
-// queryBasic is for the collect command - includes labels and reviewer logins
+// queryBasic is for the collect command - includes labels, reviewer logins, the head branch and merge commit
+// (so imported tickets can be matched to their PR)
 const queryBasic = `
 query($q: String!, $endCursor: String) {
 	search(query: $q, type: ISSUE, first: 100, after: $endCursor) {
@@ -24,7 +33,7 @@ query($q: String!, $endCursor: String) {
 			__typename
 			... on PullRequest {
 				url
-				repository { nameWithOwner }
+				repository { nameWithOwner isPrivate isFork isArchived }
 				number
 				title
 				body
@@ -32,13 +41,15 @@ query($q: String!, $endCursor: String) {
 				createdAt
 				updatedAt
 				closedAt
-				author { login }
+				author { __typename login }
 				labels(first: 10) { nodes { name } }
 				reviews(first: 10) { nodes { author { login } } }
+				headRefName
+				mergeCommit { oid }
 			}
 			... on Issue {
 				url
-				repository { nameWithOwner }
+				repository { nameWithOwner isPrivate isFork isArchived }
 				number
 				title
 				body
@@ -46,14 +57,60 @@ query($q: String!, $endCursor: String) {
 				createdAt
 				updatedAt
 				closedAt
-				author { login }
+				author { __typename login }
 				labels(first: 10) { nodes { name } }
 			}
 		}
 	}
 }`
 
-// queryWithLinkedIssues is for daily authored PRs - includes closingIssuesReferences and mergedAt
+// queryWithCommits is for collect --commits - queryBasic plus commit SHAs, so commits imported with
+// import git can be matched to their PR. Up to 100 commits per PR make each result large, so pages are smaller.
+const queryWithCommits = `
+query($q: String!, $endCursor: String) {
+	search(query: $q, type: ISSUE, first: 25, after: $endCursor) {
+		pageInfo {
+			hasNextPage
+			endCursor
+		}
+		nodes {
+			__typename
+			... on PullRequest {
+				url
+				repository { nameWithOwner isPrivate isFork isArchived }
+				number
+				title
+				body
+				state
+				createdAt
+				updatedAt
+				closedAt
+				author { __typename login }
+				labels(first: 10) { nodes { name } }
+				reviews(first: 10) { nodes { author { login } } }
+				headRefName
+				mergeCommit { oid }
+				commits(last: 100) { nodes { commit { oid } } }
+			}
+			... on Issue {
+				url
+				repository { nameWithOwner isPrivate isFork isArchived }
+				number
+				title
+				body
+				state
+				createdAt
+				updatedAt
+				closedAt
+				author { __typename login }
+				labels(first: 10) { nodes { name } }
+			}
+		}
+	}
+}`
+
+// queryWithLinkedIssues is for daily authored PRs - includes closingIssuesReferences (with their parent epic),
+// mergedAt, the head branch (for ticket keys) and review/CI status
 const queryWithLinkedIssues = `
 query($q: String!, $endCursor: String) {
 	search(query: $q, type: ISSUE, first: 100, after: $endCursor) {
@@ -65,7 +122,7 @@ query($q: String!, $endCursor: String) {
 			__typename
 			... on PullRequest {
 				url
-				repository { nameWithOwner }
+				repository { nameWithOwner isPrivate isFork isArchived }
 				number
 				title
 				body
@@ -74,21 +131,30 @@ query($q: String!, $endCursor: String) {
 				updatedAt
 				closedAt
 				mergedAt
-				author { login }
+				headRefName
+				author { __typename login }
 				labels(first: 10) { nodes { name } }
 				closingIssuesReferences(first: 10) {
 					nodes {
 						number
 						title
 						url
+						body
+						labels(first: 10) { nodes { name } }
+						parent { number title url body }
+						trackedInIssues(first: 1) { nodes { number title url body } }
 					}
 				}
+				isDraft
+				reviewDecision
+				mergeable
+				commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
 			}
 		}
 	}
 }`
 
-// queryWithReviews is for daily reviewed PRs - includes review details
+// queryWithReviews is for daily reviewed PRs - includes review details and labels (for exclusion rules)
 const queryWithReviews = `
 query($q: String!, $endCursor: String) {
 	search(query: $q, type: ISSUE, first: 100, after: $endCursor) {
@@ -100,13 +166,14 @@ query($q: String!, $endCursor: String) {
 			__typename
 			... on PullRequest {
 				url
-				repository { nameWithOwner }
+				repository { nameWithOwner isPrivate isFork isArchived }
 				number
 				title
 				createdAt
 				updatedAt
 				closedAt
-				author { login }
+				author { __typename login }
+				labels(first: 10) { nodes { name } }
 				reviews(first: 100) {
 					nodes {
 						state
@@ -120,13 +187,111 @@ query($q: String!, $endCursor: String) {
 	}
 }`
 
+// queryOpenPRs is for daily in-progress and review-requested PRs - includes review/CI status
+const queryOpenPRs = `
+query($q: String!, $endCursor: String) {
+	search(query: $q, type: ISSUE, first: 100, after: $endCursor) {
+		pageInfo {
+			hasNextPage
+			endCursor
+		}
+		nodes {
+			__typename
+			... on PullRequest {
+				url
+				repository { nameWithOwner isPrivate isFork isArchived }
+				number
+				title
+				state
+				createdAt
+				updatedAt
+				author { __typename login }
+				labels(first: 10) { nodes { name } }
+				isDraft
+				reviewDecision
+				mergeable
+				commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+			}
+		}
+	}
+}`
+
+// queryAssignedIssues is for daily assigned issues - skips bodies to keep the response small
+const queryAssignedIssues = `
+query($q: String!, $endCursor: String) {
+	search(query: $q, type: ISSUE, first: 100, after: $endCursor) {
+		pageInfo {
+			hasNextPage
+			endCursor
+		}
+		nodes {
+			__typename
+			... on Issue {
+				url
+				repository { nameWithOwner isPrivate isFork isArchived }
+				number
+				title
+				state
+				createdAt
+				updatedAt
+				author { __typename login }
+				labels(first: 10) { nodes { name } }
+			}
+		}
+	}
+}`
+
+// queryIssueActivity is for daily issue activity - includes the timeline events a user can leave on an issue
+const queryIssueActivity = `
+query($q: String!, $endCursor: String) {
+	search(query: $q, type: ISSUE, first: 50, after: $endCursor) {
+		pageInfo {
+			hasNextPage
+			endCursor
+		}
+		nodes {
+			__typename
+			... on Issue {
+				url
+				repository { nameWithOwner isPrivate isFork isArchived }
+				number
+				title
+				state
+				createdAt
+				updatedAt
+				closedAt
+				author { __typename login }
+				labels(first: 10) { nodes { name } }
+				timelineItems(last: 100, itemTypes: [ISSUE_COMMENT, CLOSED_EVENT, REOPENED_EVENT, LABELED_EVENT, ASSIGNED_EVENT]) {
+					nodes {
+						__typename
+						... on IssueComment { createdAt url author { login } }
+						... on ClosedEvent { createdAt actor { login } }
+						... on ReopenedEvent { createdAt actor { login } }
+						... on LabeledEvent { createdAt actor { login } label { name } }
+						... on AssignedEvent { createdAt actor { login } assignee { ... on User { login } } }
+					}
+				}
+			}
+		}
+	}
+}`
+
 // GetQuery returns the GraphQL query string for the given query type
 func GetQuery(qt QueryType) string {
 	switch qt {
+	case QueryWithCommits:
+		return queryWithCommits
 	case QueryWithLinkedIssues:
 		return queryWithLinkedIssues
 	case QueryWithReviews:
 		return queryWithReviews
+	case QueryOpenPRs:
+		return queryOpenPRs
+	case QueryAssignedIssues:
+		return queryAssignedIssues
+	case QueryIssueActivity:
+		return queryIssueActivity
 	default:
 		return queryBasic
 	}
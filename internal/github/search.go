@@ -1,26 +1,70 @@
 package github
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/cli/go-gh/v2"
 )
 
-// RunSearch executes a paginated GitHub GraphQL search and returns all matching nodes
+// hosts are the GitHub hosts API calls go to; empty uses gh's default host
+var hosts []string
+
+// SetHosts directs API calls to the given hosts (github.com or GitHub Enterprise
+// Server hostnames). Searches run on every host; other calls use the first.
+func SetHosts(h []string) {
+	hosts = h
+}
+
+// api runs `gh api` with args against host ("" for gh's default host)
+func api(host string, args ...string) (bytes.Buffer, error) {
+	if host != "" {
+		args = append([]string{"--hostname", host}, args...)
+	}
+	stdOut, _, err := gh.Exec(append([]string{"api"}, args...)...)
+	return stdOut, err
+}
+
+// primaryHost is the host for calls that aren't repeated per host
+func primaryHost() string {
+	if len(hosts) == 0 {
+		return ""
+	}
+	return hosts[0]
+}
+
+// RunSearch executes a paginated GitHub GraphQL search on every configured host
+// and returns all matching nodes
 func RunSearch(query string, queryType QueryType) ([]SearchNode, error) {
+	if len(hosts) == 0 {
+		return runSearch("", query, queryType)
+	}
+	var results []SearchNode
+	for _, host := range hosts {
+		nodes, err := runSearch(host, query, queryType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", host, err)
+		}
+		results = append(results, nodes...)
+	}
+	return results, nil
+}
+
+func runSearch(host, query string, queryType QueryType) ([]SearchNode, error) {
 	var results []SearchNode
 	cursor := ""
 	graphqlQuery := GetQuery(queryType)
 
 	for {
-		args := []string{"api", "graphql", "-f", fmt.Sprintf("q=%s", query)}
+		args := []string{"graphql", "-f", fmt.Sprintf("q=%s", query)}
 		if cursor != "" {
 			args = append(args, "-f", fmt.Sprintf("endCursor=%s", cursor))
 		}
 		args = append(args, "-f", fmt.Sprintf("query=%s", graphqlQuery))
 
-		stdOut, _, err := gh.Exec(args...)
+		stdOut, err := api(host, args...)
 		if err != nil {
 			return nil, fmt.Errorf("gh api failed: %w", err)
 		}
@@ -43,7 +87,7 @@ func RunSearch(query string, queryType QueryType) ([]SearchNode, error) {
 
 // GetCurrentUser returns the authenticated GitHub username
 func GetCurrentUser() (string, error) {
-	stdOut, _, err := gh.Exec("api", "user", "-q", ".login")
+	stdOut, err := api(primaryHost(), "user", "-q", ".login")
 	if err != nil {
 		return "", fmt.Errorf("failed to get current user: %w", err)
 	}
@@ -54,3 +98,12 @@ func GetCurrentUser() (string, error) {
 	}
 	return login, nil
 }
+
+// GetTeamMembers returns the logins of a GitHub team's members
+func GetTeamMembers(org, slug string) ([]string, error) {
+	stdOut, err := api(primaryHost(), "--paginate", fmt.Sprintf("orgs/%s/teams/%s/members", org, slug), "-q", ".[].login")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members of %s/%s: %w", org, slug, err)
+	}
+	return strings.Fields(stdOut.String()), nil
+}
@@ -9,6 +9,9 @@ type SearchNode struct {
 	URL        string `json:"url"`
 	Repository struct {
 		NameWithOwner string `json:"nameWithOwner"`
+		IsPrivate     bool   `json:"isPrivate"`
+		IsFork        bool   `json:"isFork"`
+		IsArchived    bool   `json:"isArchived"`
 	} `json:"repository"`
 	Number    int       `json:"number"`
 	Title     string    `json:"title"`
@@ -18,8 +21,10 @@ type SearchNode struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 	ClosedAt  time.Time `json:"closedAt"`
 	MergedAt  time.Time `json:"mergedAt"`
+	HeadRef   string    `json:"headRefName"`
 	Author    struct {
-		Login string `json:"login"`
+		Typename string `json:"__typename"` // User, Bot, Mannequin, ...
+		Login    string `json:"login"`
 	} `json:"author"`
 	Labels struct {
 		Nodes []LabelNode `json:"nodes"`
@@ -30,6 +35,55 @@ type SearchNode struct {
 	ClosingIssuesReferences struct {
 		Nodes []LinkedIssueNode `json:"nodes"`
 	} `json:"closingIssuesReferences"`
+	IsDraft        bool   `json:"isDraft"`
+	ReviewDecision string `json:"reviewDecision"` // APPROVED | CHANGES_REQUESTED | REVIEW_REQUIRED, empty if not required
+	Mergeable      string `json:"mergeable"`      // MERGEABLE | CONFLICTING | UNKNOWN
+	Commits        struct {
+		Nodes []CommitNode `json:"nodes"`
+	} `json:"commits"`
+	MergeCommit *struct {
+		OID string `json:"oid"`
+	} `json:"mergeCommit"`
+	TimelineItems struct {
+		Nodes []TimelineItemNode `json:"nodes"`
+	} `json:"timelineItems"`
+}
+
+// CheckState returns the combined CI state of the PR's latest commit
+// (SUCCESS, FAILURE, ERROR, PENDING or EXPECTED), or "" when it has no checks
+func (n SearchNode) CheckState() string {
+	if len(n.Commits.Nodes) == 0 {
+		return ""
+	}
+	rollup := n.Commits.Nodes[len(n.Commits.Nodes)-1].Commit.StatusCheckRollup
+	if rollup == nil {
+		return ""
+	}
+	return rollup.State
+}
+
+// CommitSHAs returns the SHAs of the PR's commits and its merge commit
+func (n SearchNode) CommitSHAs() []string {
+	var shas []string
+	for _, c := range n.Commits.Nodes {
+		if c.Commit.OID != "" {
+			shas = append(shas, c.Commit.OID)
+		}
+	}
+	if n.MergeCommit != nil && n.MergeCommit.OID != "" {
+		shas = append(shas, n.MergeCommit.OID)
+	}
+	return shas
+}
+
+// CommitNode represents a commit on a PR
+type CommitNode struct {
+	Commit struct {
+		OID               string `json:"oid"`
+		StatusCheckRollup *struct {
+			State string `json:"state"`
+		} `json:"statusCheckRollup"`
+	} `json:"commit"`
 }
 
 // LabelNode represents a label on a PR/Issue
@@ -47,11 +101,57 @@ type ReviewNode struct {
 	} `json:"author"`
 }
 
+// TimelineItemNode represents a comment or event on an issue timeline
+type TimelineItemNode struct {
+	Typename  string    `json:"__typename"` // IssueComment | ClosedEvent | ReopenedEvent | LabeledEvent | AssignedEvent
+	CreatedAt time.Time `json:"createdAt"`
+	URL       string    `json:"url"` // IssueComment only
+	Author    struct {
+		Login string `json:"login"`
+	} `json:"author"` // IssueComment only
+	Actor struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+	Label struct {
+		Name string `json:"name"`
+	} `json:"label"` // LabeledEvent only
+	Assignee struct {
+		Login string `json:"login"`
+	} `json:"assignee"` // AssignedEvent only
+}
+
 // LinkedIssueNode represents an issue linked via closingIssuesReferences
 type LinkedIssueNode struct {
 	Number int    `json:"number"`
 	Title  string `json:"title"`
 	URL    string `json:"url"`
+	Body   string `json:"body"`
+	Labels struct {
+		Nodes []LabelNode `json:"nodes"`
+	} `json:"labels"`
+	Parent          *IssueRefNode `json:"parent"` // Sub-issue parent
+	TrackedInIssues struct {
+		Nodes []IssueRefNode `json:"nodes"`
+	} `json:"trackedInIssues"` // Tasklist tracking issues
+}
+
+// Epic returns the issue's parent, falling back to the first issue tracking it
+func (n LinkedIssueNode) Epic() *IssueRefNode {
+	if n.Parent != nil {
+		return n.Parent
+	}
+	if len(n.TrackedInIssues.Nodes) > 0 {
+		return &n.TrackedInIssues.Nodes[0]
+	}
+	return nil
+}
+
+// IssueRefNode represents a related issue such as a parent epic
+type IssueRefNode struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Body   string `json:"body"`
 }
 
 // searchResponse is the internal response structure for GraphQL search
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{"dot", "mermaid", "json"}
+
+// Render renders the graph in the specified format
+func Render(g analyze.CollabGraph, format string) (string, error) {
+	switch format {
+	case "dot":
+		return RenderDOT(g), nil
+	case "mermaid":
+		return RenderMermaid(g), nil
+	case "json":
+		data, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return string(data), nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// RenderDOT renders the graph for Graphviz. Edge width scales with interaction count.
+func RenderDOT(g analyze.CollabGraph) string {
+	var sb strings.Builder
+	sb.WriteString("digraph collaboration {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, n := range g.Nodes {
+		sb.WriteString(fmt.Sprintf("  %s [tooltip=%s];\n",
+			dotQuote(n.Login),
+			dotQuote(fmt.Sprintf("gave %d reviews, received %d", n.Given, n.Received))))
+	}
+
+	maxCount := 1
+	for _, e := range g.Edges {
+		maxCount = max(maxCount, e.Count)
+	}
+	for _, e := range g.Edges {
+		penwidth := 1 + 4*float64(e.Count)/float64(maxCount)
+		sb.WriteString(fmt.Sprintf("  %s -> %s [label=%q, penwidth=%.1f, tooltip=%s];\n",
+			dotQuote(e.From), dotQuote(e.To), fmt.Sprint(e.Count), penwidth, dotQuote(edgeDetail(e))))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// RenderMermaid renders the graph as a Mermaid flowchart for Markdown docs
+func RenderMermaid(g analyze.CollabGraph) string {
+	ids := make(map[string]string, len(g.Nodes))
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	for i, n := range g.Nodes {
+		ids[n.Login] = fmt.Sprintf("n%d", i)
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", ids[n.Login], mermaidEscape(n.Login)))
+	}
+	for _, e := range g.Edges {
+		sb.WriteString(fmt.Sprintf("  %s -->|%d| %s\n", ids[e.From], e.Count, ids[e.To]))
+	}
+	return sb.String()
+}
+
+// edgeDetail summarizes repos and the interaction period of an edge
+func edgeDetail(e analyze.GraphEdge) string {
+	return fmt.Sprintf("%s (%s..%s)",
+		strings.Join(e.Repos, ", "),
+		e.First.Format("2006-01-02"),
+		e.Last.Format("2006-01-02"))
+}
+
+// dotQuote quotes an identifier for DOT
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// mermaidEscape escapes characters Mermaid treats specially inside quoted labels
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
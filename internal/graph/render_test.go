@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph() analyze.CollabGraph {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	return analyze.CollabGraph{
+		Nodes: []analyze.GraphNode{
+			{Login: "alice", Given: 3},
+			{Login: "bob", Received: 3},
+		},
+		Edges: []analyze.GraphEdge{
+			{From: "alice", To: "bob", Count: 3, Repos: []string{"org/a", "org/b"}, First: day, Last: day.AddDate(0, 0, 5)},
+		},
+	}
+}
+
+func TestRenderDOT(t *testing.T) {
+	out := RenderDOT(testGraph())
+
+	assert.Contains(t, out, "digraph collaboration {")
+	assert.Contains(t, out, `"alice" [tooltip="gave 3 reviews, received 0"];`)
+	assert.Contains(t, out, `"alice" -> "bob" [label="3", penwidth=5.0, tooltip="org/a, org/b (2026-03-01..2026-03-06)"];`)
+}
+
+func TestRenderMermaid(t *testing.T) {
+	out := RenderMermaid(testGraph())
+
+	assert.Equal(t, "graph LR\n  n0[\"alice\"]\n  n1[\"bob\"]\n  n0 -->|3| n1\n", out)
+}
+
+func TestRender(t *testing.T) {
+	out, err := Render(testGraph(), "json")
+	require.NoError(t, err)
+
+	var g analyze.CollabGraph
+	require.NoError(t, json.Unmarshal([]byte(out), &g))
+	assert.Equal(t, testGraph().Edges[0].Repos, g.Edges[0].Repos)
+
+	_, err = Render(testGraph(), "svg")
+	assert.ErrorContains(t, err, "unsupported format")
+}
@@ -0,0 +1,55 @@
+package importer
+
+import "github.com/jackchuka/gh-brag/internal/data"
+
+// Dedupe returns the imported events that aren't in existing yet, and how many
+// were skipped. An event is already there when its ID is, or when one of its
+// commit SHAs belongs to an existing event, such as the PR a commit was merged in.
+func Dedupe(imported, existing []data.Event) ([]data.Event, int) {
+	ids := make(map[string]bool, len(existing))
+	shas := make(map[string]bool)
+	for _, e := range existing {
+		ids[e.ID] = true
+		for _, sha := range e.Commits {
+			shas[sha] = true
+		}
+	}
+
+	var fresh []data.Event
+	skipped := 0
+	for _, e := range imported {
+		seen := ids[e.ID]
+		for _, sha := range e.Commits {
+			seen = seen || shas[sha]
+		}
+		if seen {
+			skipped++
+			continue
+		}
+		ids[e.ID] = true
+		fresh = append(fresh, e)
+	}
+	return fresh, skipped
+}
+
+// Merge adds imported events to existing ones, replacing events with the same
+// ID in place so re-importing an export refreshes titles and states. It returns
+// the merged events and how many were added and updated.
+func Merge(imported, existing []data.Event) (merged []data.Event, added, updated int) {
+	at := make(map[string]int, len(existing))
+	merged = append(merged, existing...)
+	for i, e := range merged {
+		at[e.ID] = i
+	}
+	for _, e := range imported {
+		if i, ok := at[e.ID]; ok {
+			merged[i] = e
+			updated++
+			continue
+		}
+		at[e.ID] = len(merged)
+		merged = append(merged, e)
+		added++
+	}
+	return merged, added, updated
+}
@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupe(t *testing.T) {
+	existing := []data.Event{
+		{ID: "pr:https://github.com/acme/api/pull/1:merged", Commits: []string{"aaa", "bbb"}},
+		{ID: "commit:ccc", Commits: []string{"ccc"}},
+	}
+	imported := []data.Event{
+		{ID: "commit:aaa", Commits: []string{"aaa"}},
+		{ID: "commit:ccc", Commits: []string{"ccc"}},
+		{ID: "commit:ddd", Commits: []string{"ddd"}},
+		{ID: "commit:ddd", Commits: []string{"ddd"}},
+	}
+
+	fresh, skipped := Dedupe(imported, existing)
+	assert.Equal(t, []data.Event{imported[2]}, fresh)
+	assert.Equal(t, 3, skipped)
+}
+
+func TestMerge(t *testing.T) {
+	existing := []data.Event{
+		{ID: "pr:1"},
+		{ID: "jira:ABC-1", Title: "Old title"},
+	}
+	imported := []data.Event{
+		{ID: "jira:ABC-1", Title: "New title"},
+		{ID: "jira:ABC-2", Title: "Second"},
+	}
+
+	merged, added, updated := Merge(imported, existing)
+	assert.Equal(t, []data.Event{existing[0], imported[0], imported[1]}, merged)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, "Old title", existing[1].Title, "existing events are not modified")
+}
@@ -0,0 +1,238 @@
+// Package importer turns work recorded outside GitHub search, such as local
+// git history or Jira and Linear tickets, into events for the store.
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// CommitKind is the event kind of imported commits
+const CommitKind = "commit"
+
+// GitOptions selects the commits Git imports
+type GitOptions struct {
+	Path    string   // Any directory inside the repository
+	Authors []string // git log --author patterns (emails or names); any may match
+	Repo    string   // Repository name for the events; default from the origin remote, else local/<dir>
+	Since   string   // Only commits on or after this date (YYYY-MM-DD)
+	Until   string   // Only commits on or before this date (YYYY-MM-DD)
+	Hosts   []string // Hosts besides github.com whose remotes get commit links
+}
+
+// Record and field separators for git log output; neither appears in commit messages
+const (
+	recordSep = "\x1e"
+	fieldSep  = "\x1f"
+)
+
+// logFormat prints SHA, author name, author email, author date, committer date,
+// subject and body; --numstat lines follow the final separator
+var logFormat = "--format=" + recordSep + strings.Join([]string{"%H", "%an", "%ae", "%aI", "%cI", "%s", "%b"}, fieldSep) + fieldSep
+
+// commit is one parsed git log entry
+type commit struct {
+	SHA         string
+	AuthorName  string
+	AuthorEmail string
+	AuthoredAt  time.Time
+	CommittedAt time.Time
+	Subject     string
+	Body        string
+	Additions   int
+	Deletions   int
+	Files       int
+}
+
+// Git reads the non-merge commits of the repository at opts.Path with git log
+func Git(ctx context.Context, opts GitOptions) ([]data.Event, error) {
+	path := opts.Path
+	if path == "" {
+		path = "."
+	}
+	top, err := runGit(ctx, path, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	top = strings.TrimSpace(top)
+
+	repo, web := opts.Repo, ""
+	if remote, err := runGit(ctx, top, "remote", "get-url", "origin"); err == nil {
+		name, base := remoteRepo(strings.TrimSpace(remote), opts.Hosts)
+		if repo == "" {
+			repo = name
+		}
+		// Links only make sense when the events are named after the remote
+		if repo == name {
+			web = base
+		}
+	}
+	if repo == "" {
+		repo = "local/" + filepath.Base(top)
+	}
+
+	args := []string{"log", "--no-merges", "--numstat", logFormat}
+	for _, a := range opts.Authors {
+		args = append(args, "--author="+a)
+	}
+	if opts.Since != "" {
+		args = append(args, "--since="+opts.Since)
+	}
+	if opts.Until != "" {
+		// --until is a point in time; include the whole day
+		args = append(args, "--until="+opts.Until+" 23:59:59")
+	}
+	out, err := runGit(ctx, top, args...)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := parseLog(out)
+	if err != nil {
+		return nil, err
+	}
+
+	// The query records the selection, without the format string
+	query := []string{"git", "-C", top}
+	for _, a := range args {
+		if a != logFormat {
+			query = append(query, a)
+		}
+	}
+	fetchedAt := time.Now()
+	events := make([]data.Event, 0, len(commits))
+	for _, c := range commits {
+		e := data.Event{
+			ID:           CommitKind + ":" + c.SHA,
+			Action:       data.EventActionCommitted,
+			Kind:         CommitKind,
+			Repo:         repo,
+			Title:        c.Subject,
+			Body:         c.Body,
+			Author:       c.AuthorEmail,
+			Reviewers:    []string{},
+			Commits:      []string{c.SHA},
+			Additions:    c.Additions,
+			Deletions:    c.Deletions,
+			ChangedFiles: c.Files,
+			Timestamps: data.Timestamps{
+				CreatedAt: c.AuthoredAt,
+				UpdatedAt: c.CommittedAt,
+				ClosedAt:  c.CommittedAt,
+			},
+			Source: data.Source{Tool: "git log", Query: strings.Join(query, " "), FetchedAt: fetchedAt},
+		}
+		if web != "" {
+			e.URL = web + "/commit/" + c.SHA
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// AuthorEmail returns the user.email git uses in the repository at path
+func AuthorEmail(ctx context.Context, path string) (string, error) {
+	out, err := runGit(ctx, path, "config", "user.email")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// parseLog parses git log output printed with logFormat and --numstat
+func parseLog(out string) ([]commit, error) {
+	var commits []commit
+	for _, record := range strings.Split(out, recordSep) {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		fields := strings.SplitN(record, fieldSep, 8)
+		if len(fields) != 8 {
+			return nil, fmt.Errorf("unexpected git log output: %q", record)
+		}
+		c := commit{
+			SHA:         fields[0],
+			AuthorName:  fields[1],
+			AuthorEmail: fields[2],
+			Subject:     fields[5],
+			Body:        strings.TrimSpace(fields[6]),
+		}
+		var err error
+		if c.AuthoredAt, err = time.Parse(time.RFC3339, fields[3]); err != nil {
+			return nil, fmt.Errorf("commit %s: invalid author date %q", c.SHA, fields[3])
+		}
+		if c.CommittedAt, err = time.Parse(time.RFC3339, fields[4]); err != nil {
+			return nil, fmt.Errorf("commit %s: invalid commit date %q", c.SHA, fields[4])
+		}
+
+		// --numstat: "<added>\t<deleted>\t<path>", with "-" counts for binary files
+		for _, line := range strings.Split(fields[7], "\n") {
+			parts := strings.SplitN(line, "\t", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			c.Files++
+			if n, err := strconv.Atoi(parts[0]); err == nil {
+				c.Additions += n
+			}
+			if n, err := strconv.Atoi(parts[1]); err == nil {
+				c.Deletions += n
+			}
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// remoteRepo returns owner/name for a remote URL, and the repository's web URL
+// when the remote is on github.com or one of hosts
+func remoteRepo(remote string, hosts []string) (name, web string) {
+	var host, path string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if user, rest, ok := strings.Cut(remote, ":"); ok && !strings.Contains(user, "/") {
+		// scp-like syntax: git@github.com:owner/name.git
+		_, host, _ = strings.Cut(user, "@")
+		if host == "" {
+			host = user
+		}
+		path = rest
+	} else {
+		return "", ""
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimSuffix(path, ".git"), "/"), "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return "", ""
+	}
+	name = parts[len(parts)-2] + "/" + parts[len(parts)-1]
+
+	for _, h := range append([]string{"github.com"}, hosts...) {
+		if strings.EqualFold(h, host) {
+			return name, "https://" + host + "/" + name
+		}
+	}
+	return name, ""
+}
@@ -0,0 +1,117 @@
+package importer
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLog(t *testing.T) {
+	out := "\x1eaaa111\x1fAda\x1fada@example.com\x1f2026-03-04T10:00:00+09:00\x1f2026-03-04T11:00:00+09:00\x1ffeat: add cache\x1fLonger\nexplanation\n\x1f\n\n" +
+		"10\t2\tcache.go\n-\t-\tlogo.png\n" +
+		"\x1ebbb222\x1fAda\x1fada@example.com\x1f2026-03-03T09:00:00Z\x1f2026-03-03T09:00:00Z\x1fdocs: typo\x1f\x1f\n\n1\t1\tREADME.md\n"
+
+	commits, err := parseLog(out)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	c := commits[0]
+	assert.Equal(t, "aaa111", c.SHA)
+	assert.Equal(t, "ada@example.com", c.AuthorEmail)
+	assert.Equal(t, "feat: add cache", c.Subject)
+	assert.Equal(t, "Longer\nexplanation", c.Body)
+	assert.Equal(t, time.Date(2026, 3, 4, 1, 0, 0, 0, time.UTC), c.AuthoredAt.UTC())
+	assert.Equal(t, time.Date(2026, 3, 4, 2, 0, 0, 0, time.UTC), c.CommittedAt.UTC())
+	assert.Equal(t, 10, c.Additions, "binary files add no lines")
+	assert.Equal(t, 2, c.Deletions)
+	assert.Equal(t, 2, c.Files)
+
+	assert.Equal(t, "", commits[1].Body)
+	assert.Equal(t, 1, commits[1].Files)
+
+	_, err = parseLog("\x1eaaa111\x1fAda")
+	assert.Error(t, err)
+}
+
+func TestRemoteRepo(t *testing.T) {
+	tests := []struct {
+		remote   string
+		wantName string
+		wantWeb  string
+	}{
+		{"git@github.com:acme/api.git", "acme/api", "https://github.com/acme/api"},
+		{"https://github.com/acme/api.git", "acme/api", "https://github.com/acme/api"},
+		{"ssh://git@github.example.com:2222/acme/api.git", "acme/api", "https://github.example.com/acme/api"},
+		{"https://gitlab.com/group/sub/tool.git", "sub/tool", ""},
+		{"/srv/git/tool.git", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			name, web := remoteRepo(tt.remote, []string{"github.example.com"})
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantWeb, web)
+		})
+	}
+}
+
+func TestGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(env []string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		cmd.Env = append(cmd.Env, env...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	commit := func(email, date, file, content, msg string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+		git(nil, "add", file)
+		git([]string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date},
+			"-c", "user.name=Dev", "-c", "user.email="+email, "commit", "-q", "-m", msg)
+	}
+
+	git(nil, "init", "-q")
+	commit("me@example.com", "2026-03-02T10:00:00Z", "a.go", "package a\n", "feat: start")
+	commit("other@example.com", "2026-03-03T10:00:00Z", "b.go", "package b\n", "fix: theirs")
+	commit("me@example.com", "2026-03-04T10:00:00Z", "a.go", "package a\n\nvar x = 1\n", "fix: mine\n\nDetails.")
+
+	events, err := Git(context.Background(), GitOptions{Path: dir, Authors: []string{"me@example.com"}, Since: "2026-03-03"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	e := events[0]
+	assert.True(t, strings.HasPrefix(e.ID, "commit:"))
+	assert.Equal(t, data.EventActionCommitted, e.Action)
+	assert.Equal(t, CommitKind, e.Kind)
+	assert.Equal(t, "local/"+filepath.Base(dir), e.Repo)
+	assert.Equal(t, "fix: mine", e.Title)
+	assert.Equal(t, "Details.", e.Body)
+	assert.Equal(t, "me@example.com", e.Author)
+	assert.Equal(t, 2, e.Additions)
+	assert.Equal(t, 1, e.ChangedFiles)
+	assert.Empty(t, e.URL, "local repositories have no web URL")
+
+	// A GitHub origin names the events and links the commits
+	git(nil, "remote", "add", "origin", "git@github.com:acme/tool.git")
+	events, err = Git(context.Background(), GitOptions{Path: dir, Until: "2026-03-02"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "acme/tool", events[0].Repo)
+	assert.Equal(t, "https://github.com/acme/tool/commit/"+events[0].Commits[0], events[0].URL)
+
+	_, err = Git(context.Background(), GitOptions{Path: t.TempDir()})
+	assert.ErrorContains(t, err, "git rev-parse")
+}
@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// JiraSource prefixes the IDs of imported Jira tickets, e.g. jira:ABC-123
+const JiraSource = "jira"
+
+// Jira reads a Jira CSV export ("Export Excel CSV (all fields)") or the JSON
+// returned by the REST search API ({"issues": [...]}). Links are built as
+// <base>/browse/<key>; JSON exports fall back to the site of each issue's self link.
+func Jira(r io.Reader, opts TicketOptions) ([]data.Event, error) {
+	return readTickets(r, JiraSource, opts, jiraCSV, jiraJSON, func(base, key string) string {
+		return base + "/browse/" + key
+	})
+}
+
+func jiraCSV(rows []csvRow) ([]ticket, error) {
+	tickets := make([]ticket, 0, len(rows))
+	for _, row := range rows {
+		t := ticket{
+			Key:         row.get("Issue key"),
+			Title:       row.get("Summary"),
+			Description: row.get("Description"),
+			Reporter:    row.get("Reporter"),
+			Labels:      row["labels"],
+		}
+		// Parent holds the parent's numeric ID in some exports, so only keys count
+		for _, parent := range []string{row.get("Parent key"), row.get("Parent"), row.get("Custom field (Epic Link)")} {
+			if ticketKeyPattern.MatchString(parent) {
+				t.Parent = parent
+				break
+			}
+		}
+		var err error
+		if t.Created, t.Updated, t.Resolved, err = parseTimes(t.Key, row.get("Created"), row.get("Updated"), row.get("Resolved")); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets, nil
+}
+
+// jiraIssue is an issue from the Jira REST API
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Self   string `json:"self"`
+	Fields struct {
+		Summary     string          `json:"summary"`
+		Description json.RawMessage `json:"description"` // A string (API v2) or an Atlassian document (v3)
+		Labels      []string        `json:"labels"`
+		Created     string          `json:"created"`
+		Updated     string          `json:"updated"`
+		Resolved    string          `json:"resolutiondate"`
+		Reporter    *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"reporter"`
+		Parent *struct {
+			Key string `json:"key"`
+		} `json:"parent"`
+	} `json:"fields"`
+}
+
+func jiraJSON(raw []byte) ([]ticket, error) {
+	var issues []jiraIssue
+	if err := json.Unmarshal(raw, &issues); err != nil {
+		var page struct {
+			Issues []jiraIssue `json:"issues"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return nil, err
+		}
+		issues = page.Issues
+	}
+
+	tickets := make([]ticket, 0, len(issues))
+	for _, is := range issues {
+		f := is.Fields
+		t := ticket{
+			Key:         is.Key,
+			Title:       f.Summary,
+			Description: jiraText(f.Description),
+			Labels:      f.Labels,
+		}
+		if u, err := url.Parse(is.Self); err == nil && u.Scheme != "" && u.Host != "" {
+			t.URL = u.Scheme + "://" + u.Host + "/browse/" + strings.ToUpper(is.Key)
+		}
+		if f.Reporter != nil {
+			t.Reporter = f.Reporter.DisplayName
+		}
+		if f.Parent != nil {
+			t.Parent = f.Parent.Key
+		}
+		var err error
+		if t.Created, t.Updated, t.Resolved, err = parseTimes(t.Key, f.Created, f.Updated, f.Resolved); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets, nil
+}
+
+// jiraText returns a description as plain text, joining the text nodes of an
+// Atlassian document with one line per paragraph
+func jiraText(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var doc jiraNode
+	if json.Unmarshal(raw, &doc) != nil {
+		return ""
+	}
+	var b strings.Builder
+	doc.write(&b)
+	return strings.TrimSpace(b.String())
+}
+
+// jiraNode is a node of an Atlassian document
+type jiraNode struct {
+	Type    string     `json:"type"`
+	Text    string     `json:"text"`
+	Content []jiraNode `json:"content"`
+}
+
+func (n jiraNode) write(b *strings.Builder) {
+	b.WriteString(n.Text)
+	for _, c := range n.Content {
+		c.write(b)
+	}
+	switch n.Type {
+	case "paragraph", "heading", "listItem", "codeBlock", "hardBreak":
+		b.WriteString("\n")
+	}
+}
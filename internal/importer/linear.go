@@ -0,0 +1,128 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// LinearSource prefixes the IDs of imported Linear issues, e.g. linear:ENG-42
+const LinearSource = "linear"
+
+// Linear reads a Linear CSV export or the issues of a GraphQL response, given
+// as {"data": {"issues": {"nodes": [...]}}}, {"issues": {"nodes": [...]}} or a
+// plain array. Links are built as <base>/issue/<key> when a base URL such as
+// https://linear.app/acme is given; JSON exports otherwise use each issue's url.
+func Linear(r io.Reader, opts TicketOptions) ([]data.Event, error) {
+	return readTickets(r, LinearSource, opts, linearCSV, linearJSON, func(base, key string) string {
+		return base + "/issue/" + key
+	})
+}
+
+func linearCSV(rows []csvRow) ([]ticket, error) {
+	tickets := make([]ticket, 0, len(rows))
+	for _, row := range rows {
+		t := ticket{
+			Key:         row.get("ID"),
+			Title:       row.get("Title"),
+			Description: row.get("Description"),
+			Reporter:    row.get("Creator"),
+		}
+		// Labels are one comma-separated column
+		for _, l := range strings.Split(row.get("Labels"), ",") {
+			if l = strings.TrimSpace(l); l != "" {
+				t.Labels = append(t.Labels, l)
+			}
+		}
+		if parent := row.get("Parent issue"); ticketKeyPattern.MatchString(parent) {
+			t.Parent = parent
+		}
+		resolved := row.get("Completed")
+		if resolved == "" {
+			resolved = row.get("Canceled")
+		}
+		var err error
+		if t.Created, t.Updated, t.Resolved, err = parseTimes(t.Key, row.get("Created"), row.get("Updated"), resolved); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets, nil
+}
+
+// linearIssue is an issue from the Linear GraphQL API
+type linearIssue struct {
+	Identifier  string `json:"identifier"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+	CompletedAt string `json:"completedAt"`
+	CanceledAt  string `json:"canceledAt"`
+	Creator     *struct {
+		Name string `json:"name"`
+	} `json:"creator"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Parent *struct {
+		Identifier string `json:"identifier"`
+	} `json:"parent"`
+}
+
+type linearIssues struct {
+	Issues struct {
+		Nodes []linearIssue `json:"nodes"`
+	} `json:"issues"`
+}
+
+func linearJSON(raw []byte) ([]ticket, error) {
+	var issues []linearIssue
+	if err := json.Unmarshal(raw, &issues); err != nil {
+		var resp struct {
+			Data *linearIssues `json:"data"`
+			linearIssues
+		}
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, err
+		}
+		issues = resp.Issues.Nodes
+		if resp.Data != nil {
+			issues = resp.Data.Issues.Nodes
+		}
+	}
+
+	tickets := make([]ticket, 0, len(issues))
+	for _, is := range issues {
+		t := ticket{
+			Key:         is.Identifier,
+			Title:       is.Title,
+			Description: is.Description,
+			URL:         is.URL,
+		}
+		if is.Creator != nil {
+			t.Reporter = is.Creator.Name
+		}
+		for _, l := range is.Labels.Nodes {
+			t.Labels = append(t.Labels, l.Name)
+		}
+		if is.Parent != nil {
+			t.Parent = is.Parent.Identifier
+		}
+		resolved := is.CompletedAt
+		if resolved == "" {
+			resolved = is.CanceledAt
+		}
+		var err error
+		if t.Created, t.Updated, t.Resolved, err = parseTimes(t.Key, is.CreatedAt, is.UpdatedAt, resolved); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets, nil
+}
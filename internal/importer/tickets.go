@@ -0,0 +1,231 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// TicketKind is the event kind of imported tickets; they are issues, told
+// apart from GitHub issues by their key
+const TicketKind = "issue"
+
+// Ticket export formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// TicketOptions configure a Jira or Linear import
+type TicketOptions struct {
+	Format  string // FormatCSV or FormatJSON; "" detects it from the content
+	BaseURL string // Site ticket links are built on, e.g. https://acme.atlassian.net or https://linear.app/acme
+	Name    string // File name, recorded as the source query
+}
+
+// ticketKeyPattern matches a whole ticket key, e.g. ABC-123
+var ticketKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*-[0-9]+$`)
+
+// ticket is the part of an exported ticket that becomes an event
+type ticket struct {
+	Key         string
+	Title       string
+	Description string
+	URL         string
+	Reporter    string
+	Labels      []string
+	Parent      string
+	Created     time.Time
+	Updated     time.Time
+	Resolved    time.Time
+}
+
+// detectFormat returns FormatJSON when the content starts like JSON, else FormatCSV
+func detectFormat(r *bufio.Reader) string {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return FormatCSV
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = r.ReadByte()
+		case '{', '[':
+			return FormatJSON
+		default:
+			return FormatCSV
+		}
+	}
+}
+
+// readTickets reads an export with parseCSV or parseJSON and turns it into events
+func readTickets(r io.Reader, source string, opts TicketOptions,
+	parseCSV func(rows []csvRow) ([]ticket, error),
+	parseJSON func(raw []byte) ([]ticket, error),
+	link func(base, key string) string,
+) ([]data.Event, error) {
+	br := bufio.NewReader(r)
+	format := opts.Format
+	if format == "" {
+		format = detectFormat(br)
+	}
+
+	var tickets []ticket
+	switch format {
+	case FormatCSV:
+		rows, err := readCSV(br)
+		if err != nil {
+			return nil, err
+		}
+		if tickets, err = parseCSV(rows); err != nil {
+			return nil, err
+		}
+	case FormatJSON:
+		raw, err := io.ReadAll(br)
+		if err != nil {
+			return nil, err
+		}
+		if tickets, err = parseJSON(raw); err != nil {
+			return nil, fmt.Errorf("invalid %s JSON export: %w", source, err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q (want %s or %s)", format, FormatCSV, FormatJSON)
+	}
+
+	base := strings.TrimRight(opts.BaseURL, "/")
+	fetchedAt := time.Now()
+	events := make([]data.Event, 0, len(tickets))
+	for _, t := range tickets {
+		if !ticketKeyPattern.MatchString(t.Key) {
+			return nil, fmt.Errorf("%s ticket %q has no valid key (e.g. ABC-123)", source, t.Key)
+		}
+		key := strings.ToUpper(t.Key)
+		if base != "" {
+			t.URL = link(base, key)
+		}
+		if t.URL == "" {
+			return nil, fmt.Errorf("%s ticket %s has no URL; pass the site with --base-url", source, key)
+		}
+		if t.Updated.IsZero() {
+			t.Updated = t.Created
+		}
+		events = append(events, data.Event{
+			ID:        source + ":" + key,
+			Kind:      TicketKind,
+			URL:       t.URL,
+			Title:     strings.TrimSpace(t.Title),
+			Body:      strings.TrimSpace(t.Description),
+			Author:    t.Reporter,
+			Labels:    t.Labels,
+			Reviewers: []string{},
+			Key:       key,
+			Parent:    strings.ToUpper(t.Parent),
+			Timestamps: data.Timestamps{
+				CreatedAt: t.Created,
+				UpdatedAt: t.Updated,
+				ClosedAt:  t.Resolved,
+			},
+			Source: data.Source{Tool: source + " " + format, Query: opts.Name, FetchedAt: fetchedAt},
+		})
+	}
+	return events, nil
+}
+
+// csvRow is a CSV record by header name. Jira repeats some headers, such as
+// Labels, once per value, so each name holds every non-empty value.
+type csvRow map[string][]string
+
+// get returns the first value of the first header present
+func (r csvRow) get(names ...string) string {
+	for _, name := range names {
+		if v := r[strings.ToLower(name)]; len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+func readCSV(r io.Reader) ([]csvRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV export: %w", err)
+	}
+	for i, h := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	}
+
+	var rows []csvRow
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV export: %w", err)
+		}
+		row := make(csvRow)
+		for i, v := range record {
+			if v = strings.TrimSpace(v); v != "" && i < len(header) {
+				row[header[i]] = append(row[header[i]], v)
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+}
+
+// dateLayouts are the timestamp formats found in Jira and Linear exports
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000-0700",      // Jira REST
+	"Mon Jan 02 2006 15:04:05 GMT-0700", // Linear CSV, without the zone name
+	"02/Jan/06 3:04 PM",                 // Jira CSV default
+	"02/Jan/06 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTime parses an export timestamp; those without a zone are in loc
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	// Linear writes JavaScript dates: "Tue Mar 04 2026 10:15:30 GMT+0900 (Japan Standard Time)"
+	if i := strings.Index(s, " ("); i > 0 {
+		s = s[:i]
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+}
+
+// parseTimes parses the created, updated and resolved times of a ticket
+func parseTimes(key string, created, updated, resolved string) (c, u, r time.Time, err error) {
+	if c, err = parseTime(created, time.Local); err == nil {
+		if u, err = parseTime(updated, time.Local); err == nil {
+			r, err = parseTime(resolved, time.Local)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("ticket %s: %w", key, err)
+	}
+	return c, u, r, err
+}
@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraCSV(t *testing.T) {
+	csv := "\ufeffSummary,Issue key,Issue id,Status,Reporter,Created,Updated,Resolved,Labels,Labels,Parent,Description\n" +
+		"Fix login,ABC-123,10042,Done,Ada,04/Mar/26 10:15 AM,05/Mar/26 3:00 PM,05/Mar/26 3:00 PM,auth,,ABC-1,\"Users get\nlogged out\"\n" +
+		"Checkout revamp,ABC-1,10001,In Progress,Ada,2026-02-01 09:00,,,,,10000,\n"
+
+	events, err := Jira(strings.NewReader(csv), TicketOptions{BaseURL: "https://acme.atlassian.net/", Name: "jira.csv"})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	e := events[0]
+	assert.Equal(t, "jira:ABC-123", e.ID)
+	assert.Equal(t, TicketKind, e.Kind)
+	assert.Equal(t, "ABC-123", e.Key)
+	assert.Equal(t, "https://acme.atlassian.net/browse/ABC-123", e.URL)
+	assert.Equal(t, "Fix login", e.Title)
+	assert.Equal(t, "Users get\nlogged out", e.Body)
+	assert.Equal(t, []string{"auth"}, e.Labels)
+	assert.Equal(t, "ABC-1", e.Parent)
+	assert.Equal(t, "Ada", e.Author)
+	assert.Empty(t, e.Repo)
+	assert.Equal(t, time.Date(2026, 3, 4, 10, 15, 0, 0, time.Local), e.Timestamps.CreatedAt)
+	assert.Equal(t, time.Date(2026, 3, 5, 15, 0, 0, 0, time.Local), e.Timestamps.ClosedAt)
+	assert.Equal(t, "jira csv", e.Source.Tool)
+	assert.Equal(t, "jira.csv", e.Source.Query)
+
+	assert.Empty(t, events[1].Parent, "numeric parent IDs are not keys")
+	assert.Equal(t, events[1].Timestamps.CreatedAt, events[1].Timestamps.UpdatedAt)
+
+	_, err = Jira(strings.NewReader(csv), TicketOptions{})
+	assert.ErrorContains(t, err, "--base-url")
+}
+
+func TestJiraJSON(t *testing.T) {
+	body := `{"issues": [{
+		"key": "abc-7",
+		"self": "https://acme.atlassian.net/rest/api/3/issue/10007",
+		"fields": {
+			"summary": "Rate limit webhooks",
+			"description": {"type": "doc", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "Limit "}, {"type": "text", "text": "bursts."}]},
+				{"type": "paragraph", "content": [{"type": "text", "text": "See runbook."}]}
+			]},
+			"labels": ["infra"],
+			"created": "2026-03-02T09:30:00.000+0900",
+			"updated": "2026-03-03T09:30:00.000+0900",
+			"resolutiondate": null,
+			"reporter": {"displayName": "Ada"},
+			"parent": {"key": "ABC-1"}
+		}
+	}]}`
+
+	events, err := Jira(strings.NewReader(body), TicketOptions{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	e := events[0]
+	assert.Equal(t, "jira:ABC-7", e.ID)
+	assert.Equal(t, "https://acme.atlassian.net/browse/ABC-7", e.URL)
+	assert.Equal(t, "Limit bursts.\nSee runbook.", e.Body)
+	assert.Equal(t, "ABC-1", e.Parent)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC), e.Timestamps.CreatedAt.UTC())
+	assert.True(t, e.Timestamps.ClosedAt.IsZero())
+	assert.Equal(t, "jira json", e.Source.Tool)
+
+	_, err = Jira(strings.NewReader(`{"issues": [{"key": "not a key"}]}`), TicketOptions{BaseURL: "https://acme.atlassian.net"})
+	assert.ErrorContains(t, err, "no valid key")
+}
+
+func TestLinearCSV(t *testing.T) {
+	csv := "ID,Team,Title,Description,Status,Creator,Labels,Created,Updated,Completed,Canceled,Parent issue\n" +
+		"ENG-42,Engineering,Speed up search,,Done,Ada,\"Performance, Backend\"," +
+		"Tue Mar 03 2026 10:00:00 GMT+0000 (Coordinated Universal Time),,Wed Mar 04 2026 18:00:00 GMT+0000 (Coordinated Universal Time),,ENG-40\n"
+
+	events, err := Linear(strings.NewReader(csv), TicketOptions{BaseURL: "https://linear.app/acme"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	e := events[0]
+	assert.Equal(t, "linear:ENG-42", e.ID)
+	assert.Equal(t, "https://linear.app/acme/issue/ENG-42", e.URL)
+	assert.Equal(t, []string{"Performance", "Backend"}, e.Labels)
+	assert.Equal(t, "ENG-40", e.Parent)
+	assert.Equal(t, time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC), e.Timestamps.CreatedAt.UTC())
+	assert.Equal(t, time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC), e.Timestamps.ClosedAt.UTC())
+}
+
+func TestLinearJSON(t *testing.T) {
+	body := `{"data": {"issues": {"nodes": [{
+		"identifier": "ENG-42",
+		"title": "Speed up search",
+		"url": "https://linear.app/acme/issue/ENG-42/speed-up-search",
+		"createdAt": "2026-03-03T10:00:00.000Z",
+		"updatedAt": "2026-03-04T10:00:00.000Z",
+		"completedAt": null,
+		"canceledAt": "2026-03-05T10:00:00.000Z",
+		"labels": {"nodes": [{"name": "Performance"}]},
+		"parent": {"identifier": "ENG-40"}
+	}]}}}`
+
+	events, err := Linear(strings.NewReader(body), TicketOptions{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "https://linear.app/acme/issue/ENG-42/speed-up-search", events[0].URL)
+	assert.Equal(t, []string{"Performance"}, events[0].Labels)
+	assert.Equal(t, time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC), events[0].Timestamps.ClosedAt.UTC())
+
+	events, err = Linear(strings.NewReader(`{"issues": {"nodes": [{"identifier": "ENG-1", "url": "https://linear.app/acme/issue/ENG-1"}]}}`), TicketOptions{})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	_, err = Linear(strings.NewReader(`[{"identifier": "ENG-1", "createdAt": "yesterday"}]`), TicketOptions{BaseURL: "https://linear.app/acme"})
+	assert.ErrorContains(t, err, `unrecognized date "yesterday"`)
+}
@@ -0,0 +1,393 @@
+package llm
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	defaultContextWindow = 8192
+	outputReserve        = 2048 // Tokens kept free for the model's answer
+	messageOverhead      = 4    // Tokens each chat message costs beyond its content
+)
+
+// modelFamily holds tokenizer and context estimates for a group of models
+type modelFamily struct {
+	match         func(name string) bool
+	asciiPerToken float64 // Characters per token for ASCII text (English, code)
+	runesPerToken float64 // Characters per token for other scripts (Japanese, ...)
+	contextWindow int
+}
+
+func hasPrefix(prefixes ...string) func(string) bool {
+	return func(name string) bool {
+		for _, p := range prefixes {
+			if strings.HasPrefix(name, p) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func contains(subs ...string) func(string) bool {
+	return func(name string) bool {
+		for _, s := range subs {
+			if strings.Contains(name, s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// modelFamilies is checked in order; the first match wins
+var modelFamilies = []modelFamily{
+	{match: hasPrefix("gpt-4o", "gpt-4.1", "gpt-4-turbo", "gpt-5", "o1", "o3", "o4"), asciiPerToken: 4, runesPerToken: 1, contextWindow: 128000},
+	{match: hasPrefix("gpt-4"), asciiPerToken: 4, runesPerToken: 1, contextWindow: 8192},
+	{match: hasPrefix("gpt-3.5"), asciiPerToken: 4, runesPerToken: 0.8, contextWindow: 16385},
+	{match: contains("claude"), asciiPerToken: 3.5, runesPerToken: 0.8, contextWindow: 200000},
+	{match: contains("llama"), asciiPerToken: 3.8, runesPerToken: 1, contextWindow: 128000},
+	{match: contains("mistral", "mixtral", "codestral", "ministral"), asciiPerToken: 3.5, runesPerToken: 0.8, contextWindow: 32000},
+	{match: contains("phi-"), asciiPerToken: 3.8, runesPerToken: 0.8, contextWindow: 128000},
+	{match: contains("deepseek"), asciiPerToken: 3.8, runesPerToken: 1, contextWindow: 64000},
+	{match: contains("gemini", "gemma"), asciiPerToken: 4, runesPerToken: 1, contextWindow: 128000},
+}
+
+// defaultFamily is a conservative estimate for unknown models
+var defaultFamily = modelFamily{asciiPerToken: 3.5, runesPerToken: 0.8, contextWindow: defaultContextWindow}
+
+// familyFor finds the family of a model name such as "openai/gpt-4o"
+func familyFor(model string) modelFamily {
+	name := strings.ToLower(model)
+	if i := strings.LastIndex(name, "/"); i != -1 {
+		name = name[i+1:]
+	}
+	for _, f := range modelFamilies {
+		if f.match(name) {
+			return f
+		}
+	}
+	return defaultFamily
+}
+
+// EstimateTokens approximates how many tokens model's tokenizer produces for text.
+// It errs on the high side for text outside ASCII, such as Japanese.
+func EstimateTokens(model, text string) int {
+	f := familyFor(model)
+	var ascii, other int
+	for _, r := range text {
+		if r < 0x80 {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return int(math.Ceil(float64(ascii)/f.asciiPerToken + float64(other)/f.runesPerToken))
+}
+
+// ContextWindow returns the estimated context size of model in tokens
+func ContextWindow(model string) int {
+	return familyFor(model).contextWindow
+}
+
+// promptBudget is the prompt token limit: cfg.MaxPromptTokens, or the model's
+// context window minus room for the answer
+func promptBudget(cfg Config) int {
+	if cfg.MaxPromptTokens > 0 {
+		return cfg.MaxPromptTokens
+	}
+	return ContextWindow(cfg.Model) - outputReserve
+}
+
+func estimateMessages(model string, messages []message) int {
+	total := 0
+	for _, m := range messages {
+		total += EstimateTokens(model, m.Content) + messageOverhead
+	}
+	return total
+}
+
+// Usage describes one request made to summarize
+type Usage struct {
+	Label   string   // "summary", "part 2/3", "combine", ...
+	Tokens  int      // Estimated prompt tokens
+	Budget  int      // Prompt token budget
+	Trimmed []string // Reductions applied to fit the budget
+
+	// Reported by the endpoint after the request; zero if it doesn't report usage
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// String formats usage for --verbose output
+func (u Usage) String() string {
+	s := fmt.Sprintf("%s: ~%d/%d prompt tokens", u.Label, u.Tokens, u.Budget)
+	if u.PromptTokens > 0 || u.CompletionTokens > 0 {
+		s += fmt.Sprintf(" (actual: %d prompt, %d completion)", u.PromptTokens, u.CompletionTokens)
+	}
+	if len(u.Trimmed) > 0 {
+		s += "; " + strings.Join(u.Trimmed, ", ")
+	}
+	return s
+}
+
+// request is one chat request of a summary plan
+type request struct {
+	input    SummaryInput // Items covered by this request
+	messages []message
+	usage    Usage
+}
+
+// summaryPlan is the set of requests needed to summarize an input within the budget:
+// a single request, or one per part when the input is summarized hierarchically
+type summaryPlan struct {
+	input    SummaryInput // Redacted and trimmed input
+	requests []request
+	budget   int
+}
+
+// trimSteps are applied in order until the prompt fits: bodies first, then
+// everything except the authored (and merged) PRs, least important first
+var trimSteps = []struct {
+	name  string
+	apply func(in *SummaryInput) bool
+}{
+	{"dropped descriptions", dropBodies},
+	{"dropped next up", func(in *SummaryInput) bool {
+		changed := len(in.ReviewRequests)+len(in.AssignedIssues) > 0
+		in.ReviewRequests, in.AssignedIssues = nil, nil
+		return changed
+	}},
+	{"dropped in-progress PRs", func(in *SummaryInput) bool {
+		changed := len(in.InProgress) > 0
+		in.InProgress = nil
+		return changed
+	}},
+	{"dropped issue activity", func(in *SummaryInput) bool {
+		changed := len(in.IssueActivity) > 0
+		in.IssueActivity = nil
+		return changed
+	}},
+	{"dropped reviews", func(in *SummaryInput) bool {
+		changed := len(in.Reviews) > 0
+		in.Reviews = nil
+		return changed
+	}},
+}
+
+// planSummary redacts input and fits it into the prompt budget, trimming it
+// step by step and finally splitting it into parts that each fit
+func planSummary(cfg Config, input SummaryInput) (*summaryPlan, error) {
+	p := &summaryPlan{
+		input:  redactInput(cfg.Redactor, input),
+		budget: promptBudget(cfg),
+	}
+
+	messages, err := buildMessages(cfg, p.input)
+	if err != nil {
+		return nil, err
+	}
+	tokens := estimateMessages(cfg.Model, messages)
+
+	var trimmed []string
+	for _, step := range trimSteps {
+		if tokens <= p.budget {
+			break
+		}
+		if !step.apply(&p.input) {
+			continue
+		}
+		trimmed = append(trimmed, step.name)
+		if messages, err = buildMessages(cfg, p.input); err != nil {
+			return nil, err
+		}
+		tokens = estimateMessages(cfg.Model, messages)
+	}
+
+	if tokens <= p.budget {
+		p.requests = []request{{
+			input:    p.input,
+			messages: messages,
+			usage:    Usage{Label: "summary", Tokens: tokens, Budget: p.budget, Trimmed: trimmed},
+		}}
+		return p, nil
+	}
+
+	parts, err := splitInput(cfg, p.input, p.budget)
+	if err != nil {
+		return nil, err
+	}
+	for i, part := range parts {
+		messages, err := buildMessages(cfg, part)
+		if err != nil {
+			return nil, err
+		}
+		usage := Usage{
+			Label:   fmt.Sprintf("part %d/%d", i+1, len(parts)),
+			Tokens:  estimateMessages(cfg.Model, messages),
+			Budget:  p.budget,
+			Trimmed: trimmed,
+		}
+		p.requests = append(p.requests, request{input: part, messages: messages, usage: usage})
+	}
+	return p, nil
+}
+
+// dropBodies removes every description from in
+func dropBodies(in *SummaryInput) bool {
+	changed := false
+	clearPRs := func(prs []PREntry) []PREntry {
+		out := make([]PREntry, len(prs))
+		for i, pr := range prs {
+			changed = changed || pr.Body != ""
+			pr.Body = ""
+			out[i] = pr
+		}
+		return out
+	}
+	clearIssues := func(issues []IssueEntry) []IssueEntry {
+		out := make([]IssueEntry, len(issues))
+		for i, ig := range issues {
+			changed = changed || ig.Body != ""
+			ig.Body = ""
+			ig.PRs = clearPRs(ig.PRs)
+			out[i] = ig
+		}
+		return out
+	}
+
+	epics := make([]EpicEntry, len(in.Epics))
+	for i, e := range in.Epics {
+		changed = changed || e.Body != ""
+		e.Body = ""
+		e.Issues = clearIssues(e.Issues)
+		epics[i] = e
+	}
+	in.Epics = epics
+	in.IssueGroups = clearIssues(in.IssueGroups)
+	in.StandalonePRs = clearPRs(in.StandalonePRs)
+	in.InProgress = clearPRs(in.InProgress)
+	in.ReviewRequests = clearPRs(in.ReviewRequests)
+	in.AssignedIssues = clearIssues(in.AssignedIssues)
+	return changed
+}
+
+// splitInput packs the items of in into parts whose prompts each fit budget.
+// An item too large on its own still gets a part of its own.
+func splitInput(cfg Config, in SummaryInput, budget int) ([]SummaryInput, error) {
+	var parts []SummaryInput
+	current := SummaryInput{DateLabel: in.DateLabel}
+	empty := true
+
+	for _, item := range splitItems(in) {
+		next := appendInput(current, item)
+		if !empty {
+			messages, err := buildMessages(cfg, next)
+			if err != nil {
+				return nil, err
+			}
+			if estimateMessages(cfg.Model, messages) > budget {
+				parts = append(parts, current)
+				next = appendInput(SummaryInput{DateLabel: in.DateLabel}, item)
+			}
+		}
+		current, empty = next, false
+	}
+	if !empty {
+		parts = append(parts, current)
+	}
+	return parts, nil
+}
+
+// splitItems breaks in into inputs holding one item each; epics are split per issue
+func splitItems(in SummaryInput) []SummaryInput {
+	var items []SummaryInput
+	one := func(f func(*SummaryInput)) {
+		item := SummaryInput{DateLabel: in.DateLabel}
+		f(&item)
+		items = append(items, item)
+	}
+
+	for _, e := range in.Epics {
+		for _, ig := range e.Issues {
+			epic := e
+			epic.Issues = []IssueEntry{ig}
+			one(func(item *SummaryInput) { item.Epics = []EpicEntry{epic} })
+		}
+	}
+	for _, ig := range in.IssueGroups {
+		one(func(item *SummaryInput) { item.IssueGroups = []IssueEntry{ig} })
+	}
+	for _, pr := range in.StandalonePRs {
+		one(func(item *SummaryInput) { item.StandalonePRs = []PREntry{pr} })
+	}
+	for _, r := range in.Reviews {
+		one(func(item *SummaryInput) { item.Reviews = []ReviewEntry{r} })
+	}
+	for _, ia := range in.IssueActivity {
+		one(func(item *SummaryInput) { item.IssueActivity = []IssueActivityEntry{ia} })
+	}
+	for _, n := range in.Notes {
+		one(func(item *SummaryInput) { item.Notes = []NoteEntry{n} })
+	}
+	for _, pr := range in.InProgress {
+		one(func(item *SummaryInput) { item.InProgress = []PREntry{pr} })
+	}
+	for _, pr := range in.ReviewRequests {
+		one(func(item *SummaryInput) { item.ReviewRequests = []PREntry{pr} })
+	}
+	for _, issue := range in.AssignedIssues {
+		one(func(item *SummaryInput) { item.AssignedIssues = []IssueEntry{issue} })
+	}
+	return items
+}
+
+// appendInput returns a with the items of b added, merging issues of the same epic
+func appendInput(a, b SummaryInput) SummaryInput {
+	out := a
+	out.Epics = append([]EpicEntry(nil), a.Epics...)
+	for _, e := range b.Epics {
+		if n := len(out.Epics); n > 0 && out.Epics[n-1].URL == e.URL {
+			last := out.Epics[n-1]
+			last.Issues = append(append([]IssueEntry(nil), last.Issues...), e.Issues...)
+			out.Epics[n-1] = last
+			continue
+		}
+		out.Epics = append(out.Epics, e)
+	}
+	out.IssueGroups = append(append([]IssueEntry(nil), a.IssueGroups...), b.IssueGroups...)
+	out.StandalonePRs = append(append([]PREntry(nil), a.StandalonePRs...), b.StandalonePRs...)
+	out.Reviews = append(append([]ReviewEntry(nil), a.Reviews...), b.Reviews...)
+	out.IssueActivity = append(append([]IssueActivityEntry(nil), a.IssueActivity...), b.IssueActivity...)
+	out.Notes = append(append([]NoteEntry(nil), a.Notes...), b.Notes...)
+	out.InProgress = append(append([]PREntry(nil), a.InProgress...), b.InProgress...)
+	out.ReviewRequests = append(append([]PREntry(nil), a.ReviewRequests...), b.ReviewRequests...)
+	out.AssignedIssues = append(append([]IssueEntry(nil), a.AssignedIssues...), b.AssignedIssues...)
+	return out
+}
+
+// packParts groups partial summaries so each combine prompt fits budget
+func packParts(cfg Config, dateLabel string, parts []string, budget int) ([][]string, error) {
+	var groups [][]string
+	var current []string
+	for _, part := range parts {
+		next := append(append([]string(nil), current...), part)
+		if len(current) > 0 {
+			messages, err := buildCombineMessages(cfg, dateLabel, next)
+			if err != nil {
+				return nil, err
+			}
+			if estimateMessages(cfg.Model, messages) > budget {
+				groups = append(groups, current)
+				next = []string{part}
+			}
+		}
+		current = next
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+	return groups, nil
+}
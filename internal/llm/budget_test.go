@@ -0,0 +1,193 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 100, EstimateTokens("openai/gpt-4o", strings.Repeat("a", 400)))
+	assert.Equal(t, 10, EstimateTokens("openai/gpt-4o", strings.Repeat("日", 10)))
+	assert.Equal(t, 115, EstimateTokens("unknown-model", strings.Repeat("a", 400)))
+	assert.Equal(t, 0, EstimateTokens("openai/gpt-4o", ""))
+}
+
+func TestContextWindow(t *testing.T) {
+	tests := map[string]int{
+		"openai/gpt-4o":                    128000,
+		"openai/gpt-4o-mini":               128000,
+		"gpt-4":                            8192,
+		"meta/Meta-Llama-3.1-8B-Instruct":  128000,
+		"mistral-ai/Mistral-Large-2411":    32000,
+		"anthropic/claude-3-5-sonnet":      200000,
+		"something-local":                  defaultContextWindow,
+		"microsoft/Phi-4-mini-instruct":    128000,
+		"deepseek/DeepSeek-R1":             64000,
+		"openai/o3-mini":                   128000,
+		"llama.cpp/qwen2.5-coder-7b.gguf":  defaultContextWindow,
+		"ollama/gemma2:9b":                 128000,
+		"OPENAI/GPT-3.5-TURBO":             16385,
+		"openai/gpt-4.1":                   128000,
+		"openai/gpt-5":                     128000,
+		"xai/grok-3":                       defaultContextWindow,
+		"cohere/cohere-command-r-08-2024":  defaultContextWindow,
+		"ai21-labs/AI21-Jamba-1.5-Large":   defaultContextWindow,
+		"core42/jais-30b-chat":             defaultContextWindow,
+		"mistral-ai/Codestral-2501":        32000,
+		"mistral-ai/Ministral-3B":          32000,
+		"mistral-ai/mixtral-8x7b-instruct": 32000,
+	}
+	for model, want := range tests {
+		assert.Equal(t, want, ContextWindow(model), model)
+	}
+}
+
+func busyInput(prs int) SummaryInput {
+	input := SummaryInput{DateLabel: "2026-01-05..2026-01-09"}
+	for i := range prs {
+		input.StandalonePRs = append(input.StandalonePRs, PREntry{
+			Title:  fmt.Sprintf("Change %d", i),
+			URL:    fmt.Sprintf("https://github.com/org/repo/pull/%d", i),
+			Body:   strings.Repeat("details ", 60),
+			Status: "merged",
+		})
+	}
+	input.Reviews = []ReviewEntry{{PRTitle: "Someone's PR", PRURL: "https://github.com/org/repo/pull/999", States: []string{"APPROVED"}}}
+	return input
+}
+
+// promptTokens estimates the prompt for input after applying the given trim steps
+func promptTokens(t *testing.T, cfg Config, input SummaryInput, trims ...func(*SummaryInput) bool) int {
+	t.Helper()
+	for _, trim := range trims {
+		trim(&input)
+	}
+	messages, err := buildMessages(cfg, input)
+	require.NoError(t, err)
+	return estimateMessages(cfg.Model, messages)
+}
+
+func dropReviews(in *SummaryInput) bool {
+	in.Reviews = nil
+	return true
+}
+
+func TestPlanSummary(t *testing.T) {
+	cfg := applyDefaults(Config{Lang: "en"})
+	input := busyInput(3)
+	withoutBodies := promptTokens(t, cfg, input, dropBodies)
+	withoutReviews := promptTokens(t, cfg, input, dropBodies, dropReviews)
+
+	t.Run("fits", func(t *testing.T) {
+		plan, err := planSummary(cfg, input)
+		require.NoError(t, err)
+		require.Len(t, plan.requests, 1)
+		assert.Empty(t, plan.requests[0].usage.Trimmed)
+		assert.Equal(t, 128000-outputReserve, plan.requests[0].usage.Budget)
+		assert.Contains(t, plan.requests[0].messages[1].Content, "Description: details")
+	})
+
+	t.Run("drops bodies first", func(t *testing.T) {
+		cfg := cfg
+		cfg.MaxPromptTokens = withoutBodies
+		plan, err := planSummary(cfg, input)
+		require.NoError(t, err)
+		require.Len(t, plan.requests, 1)
+		assert.Equal(t, []string{"dropped descriptions"}, plan.requests[0].usage.Trimmed)
+		user := plan.requests[0].messages[1].Content
+		assert.NotContains(t, user, "Description:")
+		assert.Contains(t, user, "REVIEWS SUBMITTED:")
+	})
+
+	t.Run("drops reviews before merged PRs", func(t *testing.T) {
+		cfg := cfg
+		cfg.MaxPromptTokens = withoutReviews
+		plan, err := planSummary(cfg, input)
+		require.NoError(t, err)
+		require.Len(t, plan.requests, 1)
+		assert.Equal(t, []string{"dropped descriptions", "dropped reviews"}, plan.requests[0].usage.Trimmed)
+		user := plan.requests[0].messages[1].Content
+		assert.NotContains(t, user, "REVIEWS SUBMITTED:")
+		assert.Contains(t, user, "- Change 2 [merged]")
+	})
+
+	t.Run("splits into parts", func(t *testing.T) {
+		cfg := cfg
+		cfg.MaxPromptTokens = withoutReviews
+		plan, err := planSummary(cfg, busyInput(40))
+		require.NoError(t, err)
+		require.Greater(t, len(plan.requests), 1)
+
+		covered := 0
+		for i, req := range plan.requests {
+			assert.Equal(t, fmt.Sprintf("part %d/%d", i+1, len(plan.requests)), req.usage.Label)
+			assert.LessOrEqual(t, req.usage.Tokens, withoutReviews)
+			covered += len(req.input.StandalonePRs)
+		}
+		assert.Equal(t, 40, covered)
+	})
+}
+
+func TestDropBodies_KeepsInput(t *testing.T) {
+	input := SummaryInput{
+		Epics: []EpicEntry{{Body: "epic", Issues: []IssueEntry{{Body: "issue", PRs: []PREntry{{Body: "pr"}}}}}},
+	}
+	trimmed := input
+	assert.True(t, dropBodies(&trimmed))
+	assert.Empty(t, trimmed.Epics[0].Issues[0].PRs[0].Body)
+	assert.Equal(t, "pr", input.Epics[0].Issues[0].PRs[0].Body)
+	assert.False(t, dropBodies(&trimmed))
+}
+
+func TestSummarize_Hierarchical(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		user := req.Messages[1].Content
+		requests = append(requests, user)
+
+		answer := fmt.Sprintf("- part summary %d", len(requests))
+		if strings.Contains(user, "PARTIAL SUMMARIES") {
+			answer = "- combined"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"content": answer}}},
+			"usage":   map[string]int{"prompt_tokens": 10, "completion_tokens": 2},
+		})
+	}))
+	defer server.Close()
+
+	var usages []Usage
+	cfg := Config{
+		Endpoint: server.URL,
+		Model:    "openai/gpt-4o",
+		OnUsage:  func(u Usage) { usages = append(usages, u) },
+	}
+	cfg.MaxPromptTokens = promptTokens(t, applyDefaults(cfg), busyInput(3), dropBodies, dropReviews)
+
+	summary, err := Summarize(context.Background(), cfg, busyInput(40))
+	require.NoError(t, err)
+	assert.Equal(t, "- combined", summary)
+
+	require.Greater(t, len(requests), 2)
+	last := requests[len(requests)-1]
+	assert.Contains(t, last, "--- Part 1 ---\n- part summary 1")
+	require.Len(t, usages, len(requests))
+	assert.Equal(t, "part 1/", usages[0].Label[:7])
+	assert.Equal(t, 10, usages[0].PromptTokens)
+	assert.Contains(t, usages[len(usages)-1].Label, "combine")
+}
+
+func TestUsage_String(t *testing.T) {
+	u := Usage{Label: "summary", Tokens: 900, Budget: 1000, Trimmed: []string{"dropped descriptions"}, PromptTokens: 950, CompletionTokens: 120}
+	assert.Equal(t, "summary: ~900/1000 prompt tokens (actual: 950 prompt, 120 completion); dropped descriptions", u.String())
+}
@@ -0,0 +1,137 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OtherTheme is the fallback theme for events that fit none of the configured themes
+const OtherTheme = "Other"
+
+const classifySystemPrompt = `You are an AI assistant that classifies a developer's GitHub pull requests and issues into themes.
+
+Allowed themes:
+%s
+
+For each numbered item, pick exactly one allowed theme based on its title and description.
+Use "Other" only if none of the allowed themes fit.
+Report your confidence as a number between 0 and 1.
+
+Respond with JSON only, in this exact shape:
+{"results": [{"id": 1, "theme": "<theme>", "confidence": 0.9}]}
+`
+
+// ClassifyItem is an event to classify
+type ClassifyItem struct {
+	Title   string
+	Body    string
+	Private bool // From a private repository
+}
+
+// ClassifyResult is the theme assigned to the ClassifyItem at the same index
+type ClassifyResult struct {
+	Theme      string
+	Confidence float64
+}
+
+type classifyResponse struct {
+	Results []struct {
+		ID         int     `json:"id"`
+		Theme      string  `json:"theme"`
+		Confidence float64 `json:"confidence"`
+	} `json:"results"`
+}
+
+// Classify asks the model to assign one of themes to each item.
+// Results are returned in item order; items the model skipped or assigned
+// an unknown theme get OtherTheme with zero confidence.
+func Classify(ctx context.Context, cfg Config, themes []string, items []ClassifyItem) ([]ClassifyResult, error) {
+	cfg = applyDefaults(cfg)
+
+	if cfg.Redactor != nil {
+		redacted := make([]ClassifyItem, len(items))
+		for i, item := range items {
+			redacted[i] = ClassifyItem{Title: cfg.Redactor.Text(item.Title), Body: cfg.Redactor.Body(item.Body, item.Private)}
+		}
+		items = redacted
+	}
+
+	content, err := complete(ctx, cfg, chatRequest{
+		Model:          cfg.Model,
+		Messages:       buildClassifyMessages(themes, items),
+		ResponseFormat: &responseFormat{Type: "json_object"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseClassifyResponse(content, themes, len(items))
+}
+
+// buildClassifyMessages constructs the chat messages for classification
+func buildClassifyMessages(themes []string, items []ClassifyItem) []message {
+	var themeList strings.Builder
+	for _, t := range themes {
+		themeList.WriteString(fmt.Sprintf("- %s\n", t))
+	}
+
+	var sb strings.Builder
+	for i, item := range items {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, item.Title))
+		if item.Body != "" {
+			sb.WriteString(fmt.Sprintf("   Description: %s\n", truncateBody(item.Body)))
+		}
+	}
+
+	return []message{
+		{Role: "system", Content: fmt.Sprintf(classifySystemPrompt, themeList.String())},
+		{Role: "user", Content: sb.String()},
+	}
+}
+
+// parseClassifyResponse maps the model's JSON answer back onto item positions
+func parseClassifyResponse(content string, themes []string, n int) ([]ClassifyResult, error) {
+	var resp classifyResponse
+	if err := json.Unmarshal([]byte(stripCodeFence(content)), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse classification: %w", err)
+	}
+
+	known := make(map[string]string, len(themes))
+	for _, t := range themes {
+		known[strings.ToLower(t)] = t
+	}
+
+	results := make([]ClassifyResult, n)
+	for i := range results {
+		results[i] = ClassifyResult{Theme: OtherTheme}
+	}
+	for _, r := range resp.Results {
+		if r.ID < 1 || r.ID > n {
+			continue
+		}
+		theme, ok := known[strings.ToLower(strings.TrimSpace(r.Theme))]
+		if !ok {
+			continue
+		}
+		results[r.ID-1] = ClassifyResult{
+			Theme:      theme,
+			Confidence: min(max(r.Confidence, 0), 1),
+		}
+	}
+	return results, nil
+}
+
+// stripCodeFence removes a surrounding Markdown code fence some models add around JSON
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	if i := strings.Index(s, "\n"); i != -1 {
+		s = s[i+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "```"))
+}
@@ -0,0 +1,89 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClassifyResponse(t *testing.T) {
+	themes := []string{"Feature", "Bug Fix"}
+
+	tests := []struct {
+		name     string
+		content  string
+		n        int
+		expected []ClassifyResult
+		wantErr  bool
+	}{
+		{
+			name:    "results mapped by id",
+			content: `{"results": [{"id": 2, "theme": "Bug Fix", "confidence": 0.8}, {"id": 1, "theme": "Feature", "confidence": 0.9}]}`,
+			n:       2,
+			expected: []ClassifyResult{
+				{Theme: "Feature", Confidence: 0.9},
+				{Theme: "Bug Fix", Confidence: 0.8},
+			},
+		},
+		{
+			name:    "code fence stripped",
+			content: "```json\n{\"results\": [{\"id\": 1, \"theme\": \"Feature\", \"confidence\": 0.7}]}\n```",
+			n:       1,
+			expected: []ClassifyResult{
+				{Theme: "Feature", Confidence: 0.7},
+			},
+		},
+		{
+			name:    "unknown theme, missing id and out of range id fall back to Other",
+			content: `{"results": [{"id": 1, "theme": "Security", "confidence": 0.9}, {"id": 7, "theme": "Feature", "confidence": 0.9}]}`,
+			n:       2,
+			expected: []ClassifyResult{
+				{Theme: OtherTheme},
+				{Theme: OtherTheme},
+			},
+		},
+		{
+			name:    "theme matched case-insensitively and confidence clamped",
+			content: `{"results": [{"id": 1, "theme": "bug fix", "confidence": 1.5}]}`,
+			n:       1,
+			expected: []ClassifyResult{
+				{Theme: "Bug Fix", Confidence: 1},
+			},
+		},
+		{
+			name:    "invalid JSON",
+			content: "I think it's a feature",
+			n:       1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseClassifyResponse(tt.content, themes, tt.n)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBuildClassifyMessages(t *testing.T) {
+	messages := buildClassifyMessages(
+		[]string{"Feature", "Docs"},
+		[]ClassifyItem{
+			{Title: "Add login page"},
+			{Title: "Update handbook", Body: "Rewrites onboarding section"},
+		},
+	)
+
+	assert.Len(t, messages, 2)
+	assert.Contains(t, messages[0].Content, "- Feature\n- Docs")
+	assert.Contains(t, messages[1].Content, "1. Add login page")
+	assert.Contains(t, messages[1].Content, "2. Update handbook")
+	assert.Contains(t, messages[1].Content, "Description: Rewrites onboarding section")
+}
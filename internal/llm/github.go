@@ -11,43 +11,99 @@ import (
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/jackchuka/gh-brag/internal/redact"
 )
 
 const (
 	githubModelsEndpoint = "https://models.github.ai/inference/chat/completions"
-	defaultModel         = "openai/gpt-4o"
+	DefaultModel         = "openai/gpt-4o"
 	defaultTimeout       = 30 * time.Second
 )
 
 // Config holds the LLM summarization configuration
 type Config struct {
-	Model   string
-	Lang    string
-	Prompt  string // User's custom prompt injection
-	Timeout time.Duration
+	Endpoint  string // OpenAI-compatible chat completions URL (empty uses GitHub Models)
+	APIKeyEnv string // Environment variable holding the API key for custom endpoints
+	Model     string
+	Lang      string
+	Prompt    string // User's custom prompt injection
+	Timeout   time.Duration
+
+	SystemTemplate string // Go template over PromptData replacing the built-in system prompt
+	UserTemplate   string // Go template over PromptData replacing the built-in user prompt
+	Structured     bool   // Ask for JSON themes with source URLs (see SummarizeStructured)
+
+	Redactor *redact.Redactor // Applied to every title and body before sending; nil sends them as is
+
+	MaxPromptTokens int         // Prompt token budget; 0 uses the model's context window minus room for the answer
+	OnUsage         func(Usage) // Called after each summary request, e.g. for --verbose output
 }
 
 // SummaryInput contains the data to summarize
 type SummaryInput struct {
 	DateLabel     string
-	IssueGroups   []IssueEntry
+	Epics         []EpicEntry  // Issues rolled up by their parent epic
+	IssueGroups   []IssueEntry // Issues without an epic
 	StandalonePRs []PREntry
 	Reviews       []ReviewEntry
+	IssueActivity []IssueActivityEntry
+	Notes         []NoteEntry // Work outside GitHub the developer recorded by hand
+
+	InProgress     []PREntry    // Open PRs the developer is working on
+	ReviewRequests []PREntry    // Open PRs waiting on the developer's review
+	AssignedIssues []IssueEntry // Open issues assigned to the developer
+}
+
+// EpicEntry represents a parent issue (initiative) with the issues worked on under it
+type EpicEntry struct {
+	Title   string
+	URL     string
+	Body    string
+	Private bool
+	Issues  []IssueEntry
 }
 
 // IssueEntry represents an issue with its linked PRs
 type IssueEntry struct {
-	Title string
-	URL   string
-	Body  string
-	PRs   []PREntry
+	Title   string
+	URL     string
+	Body    string
+	Private bool
+	PRs     []PREntry
 }
 
 // PREntry represents a pull request
 type PREntry struct {
+	Title   string
+	URL     string
+	Body    string
+	Status  string // Review and CI status such as "draft" or "approved, CI failing"
+	Private bool   // From a private repository
+}
+
+// IssueActivityEntry represents what the developer did on an issue
+type IssueActivityEntry struct {
+	Title   string
+	URL     string
+	Actions string // e.g. "opened, labeled bug"
+}
+
+// NoteEntry represents a manual note (gh brag note)
+type NoteEntry struct {
+	ID    string // Event ID (note:...), cited in structured summaries when there is no URL
 	Title string
-	URL   string
-	Body  string
+	URL   string // Optional link
+	Repo  string // owner/name when URL points at a GitHub repository
+	Theme string
+}
+
+// source returns what a structured summary cites the note by: its URL, or its ID
+func (n NoteEntry) source() string {
+	if n.URL != "" {
+		return n.URL
+	}
+	return n.ID
 }
 
 // ReviewEntry represents reviews submitted on a PR
@@ -59,8 +115,13 @@ type ReviewEntry struct {
 
 // chatRequest is the request body for the chat completions API
 type chatRequest struct {
-	Model    string    `json:"model"`
-	Messages []message `json:"messages"`
+	Model          string          `json:"model"`
+	Messages       []message       `json:"messages"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+}
+
+type responseFormat struct {
+	Type string `json:"type"`
 }
 
 type message struct {
@@ -75,16 +136,124 @@ type chatResponse struct {
 			Content string `json:"content"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage *apiUsage `json:"usage,omitempty"`
 	Error *struct {
 		Message string `json:"message"`
 	} `json:"error,omitempty"`
 }
 
-// Summarize generates a summary using GitHub Models API
+// apiUsage is the token usage an endpoint reports for a request
+type apiUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// Summarize generates a summary using GitHub Models API.
+// Input too large for the prompt budget is trimmed, then summarized in parts
+// whose summaries are combined.
 func Summarize(ctx context.Context, cfg Config, input SummaryInput) (string, error) {
-	// Apply defaults
+	cfg = applyDefaults(cfg)
+	plan, err := planSummary(cfg, input)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(plan.requests))
+	for _, req := range plan.requests {
+		content, err := send(ctx, cfg, req.messages, req.usage, nil)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, content)
+	}
+	return combineParts(ctx, cfg, plan, parts)
+}
+
+// combineParts merges partial summaries, in rounds if they don't fit one prompt
+func combineParts(ctx context.Context, cfg Config, plan *summaryPlan, parts []string) (string, error) {
+	for round := 1; len(parts) > 1; round++ {
+		groups, err := packParts(cfg, plan.input.DateLabel, parts, plan.budget)
+		if err != nil {
+			return "", err
+		}
+		if len(groups) == len(parts) {
+			// No two parts fit together: send them all and let the endpoint decide
+			groups = [][]string{parts}
+		}
+
+		next := make([]string, 0, len(groups))
+		for i, group := range groups {
+			if len(group) == 1 {
+				next = append(next, group[0])
+				continue
+			}
+			messages, err := buildCombineMessages(cfg, plan.input.DateLabel, group)
+			if err != nil {
+				return "", err
+			}
+			usage := Usage{
+				Label:  fmt.Sprintf("combine %d.%d", round, i+1),
+				Tokens: estimateMessages(cfg.Model, messages),
+				Budget: plan.budget,
+			}
+			content, err := send(ctx, cfg, messages, usage, nil)
+			if err != nil {
+				return "", err
+			}
+			next = append(next, content)
+		}
+		parts = next
+	}
+	return parts[0], nil
+}
+
+// send makes one summary request and reports its usage
+func send(ctx context.Context, cfg Config, messages []message, usage Usage, format *responseFormat) (string, error) {
+	content, actual, err := completeWithUsage(ctx, cfg, chatRequest{
+		Model:          cfg.Model,
+		Messages:       messages,
+		ResponseFormat: format,
+	})
+	if err != nil {
+		return "", err
+	}
+	if cfg.OnUsage != nil {
+		if actual != nil {
+			usage.PromptTokens = actual.PromptTokens
+			usage.CompletionTokens = actual.CompletionTokens
+		}
+		cfg.OnUsage(usage)
+	}
+	return content, nil
+}
+
+// Preview returns the requests Summarize (or SummarizeStructured when cfg.Structured
+// is set) would send, after redaction and trimming, without sending them
+func Preview(cfg Config, input SummaryInput) (string, error) {
+	cfg = applyDefaults(cfg)
+	plan, err := planSummary(cfg, input)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Model: %s\n", cfg.Model))
+	for _, req := range plan.requests {
+		sb.WriteString(fmt.Sprintf("\n=== %s ===\n", req.usage))
+		for _, m := range req.messages {
+			sb.WriteString(fmt.Sprintf("\n--- %s ---\n%s\n", m.Role, strings.TrimRight(m.Content, "\n")))
+		}
+	}
+	if len(plan.requests) > 1 && !cfg.Structured {
+		sb.WriteString("\nThe part summaries are then combined into one summary.\n")
+	}
+	return sb.String(), nil
+}
+
+// applyDefaults fills unset configuration values
+func applyDefaults(cfg Config) Config {
 	if cfg.Model == "" {
-		cfg.Model = defaultModel
+		cfg.Model = DefaultModel
 	}
 	if cfg.Timeout == 0 {
 		cfg.Timeout = defaultTimeout
@@ -92,43 +261,50 @@ func Summarize(ctx context.Context, cfg Config, input SummaryInput) (string, err
 	if cfg.Lang == "" {
 		cfg.Lang = "en"
 	}
+	return cfg
+}
 
-	// Get GitHub token
-	token, err := getGHToken()
-	if err != nil {
-		return "", fmt.Errorf("failed to get GitHub token: %w", err)
-	}
+// complete sends a chat completion request and returns the first choice's content
+func complete(ctx context.Context, cfg Config, reqBody chatRequest) (string, error) {
+	content, _, err := completeWithUsage(ctx, cfg, reqBody)
+	return content, err
+}
 
-	// Build messages
-	messages := buildMessages(cfg, input)
+// completeWithUsage is complete that also returns the usage the endpoint reported, if any
+func completeWithUsage(ctx context.Context, cfg Config, reqBody chatRequest) (string, *apiUsage, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = githubModelsEndpoint
+	}
 
-	// Create request
-	reqBody := chatRequest{
-		Model:    cfg.Model,
-		Messages: messages,
+	token, err := resolveToken(cfg)
+	if err != nil {
+		return "", nil, err
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Create HTTP request with context timeout
 	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, "POST", githubModelsEndpoint, bytes.NewReader(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(jsonBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	// Execute request
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return "", nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -136,28 +312,48 @@ func Summarize(ctx context.Context, cfg Config, input SummaryInput) (string, err
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return "", nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
 	}
 
 	// Parse response
 	var chatResp chatResponse
 	if err := json.Unmarshal(body, &chatResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return "", nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if chatResp.Error != nil {
-		return "", fmt.Errorf("API error: %s", chatResp.Error.Message)
+		return "", nil, fmt.Errorf("API error: %s", chatResp.Error.Message)
 	}
 
 	if len(chatResp.Choices) == 0 {
-		return "", fmt.Errorf("no response from model")
+		return "", nil, fmt.Errorf("no response from model")
 	}
 
-	return strings.TrimSpace(chatResp.Choices[0].Message.Content), nil
+	return strings.TrimSpace(chatResp.Choices[0].Message.Content), chatResp.Usage, nil
+}
+
+// resolveToken returns the bearer token for the configured endpoint.
+// GitHub Models uses the gh token; custom endpoints use APIKeyEnv and may be unauthenticated.
+func resolveToken(cfg Config) (string, error) {
+	if cfg.Endpoint == "" {
+		token, err := getGHToken()
+		if err != nil {
+			return "", fmt.Errorf("failed to get GitHub token: %w", err)
+		}
+		return token, nil
+	}
+	if cfg.APIKeyEnv == "" {
+		return "", nil
+	}
+	token := os.Getenv(cfg.APIKeyEnv)
+	if token == "" {
+		return "", fmt.Errorf("environment variable %s is not set", cfg.APIKeyEnv)
+	}
+	return token, nil
 }
 
 // getGHToken retrieves the GitHub token from environment or gh CLI
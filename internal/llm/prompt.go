@@ -3,6 +3,8 @@ package llm
 import (
 	"fmt"
 	"strings"
+	"text/template"
+	"unicode/utf8"
 )
 
 const systemPromptTemplate = `You are an AI assistant that summarizes a developer's daily GitHub activity.
@@ -19,6 +21,8 @@ Content:
 - Use simple language suitable for a general audience
 - State the action taken in a sentence
 - Each theme gets a top-level bullet with sub-bullets for details
+- When initiatives (epics) are listed, describe progress in terms of the initiative (e.g. "Progressed on <initiative>: ...")
+- If in-progress or next-up work is listed, end with "In progress" and "Next up" bullets covering it
 
 Formatting:
 - Add a blank line before and after code blocks
@@ -49,36 +53,179 @@ func getLangName(code string) string {
 	return code // fallback to code if not found
 }
 
-// truncateBody limits body text to avoid excessive prompt size
+// truncateBody limits body text to maxBodyLength characters to avoid excessive
+// prompt size, without splitting a multi-byte character
 func truncateBody(body string) string {
 	body = strings.TrimSpace(body)
-	if len(body) > maxBodyLength {
-		return body[:maxBodyLength] + "..."
+	if utf8.RuneCountInString(body) > maxBodyLength {
+		return string([]rune(body)[:maxBodyLength]) + "..."
 	}
 	return body
 }
 
-// buildMessages constructs the chat messages for the LLM
-func buildMessages(cfg Config, input SummaryInput) []message {
-	// Build structured user content
+// PromptData is the data passed to custom system and user prompt templates
+type PromptData struct {
+	SummaryInput
+	Lang   string // Full output language name, e.g. "English"
+	Prompt string // Additional instructions from --summarize-prompt
+}
+
+// promptFuncs are the helpers available to custom prompt templates
+var promptFuncs = template.FuncMap{
+	"truncate": truncateBody,
+	"join":     strings.Join,
+}
+
+// ParsePromptTemplate parses a custom system or user prompt template
+func ParsePromptTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(promptFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s prompt template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// executePrompt renders a custom prompt template over data
+func executePrompt(name, text string, data PromptData) (string, error) {
+	tmpl, err := ParsePromptTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to execute %s prompt template: %w", name, err)
+	}
+	return sb.String(), nil
+}
+
+// withURL appends the source URL to a title when the model must cite sources
+func withURL(title, url string, cite bool) string {
+	if !cite || url == "" {
+		return title
+	}
+	return fmt.Sprintf("%s <%s>", title, url)
+}
+
+// writeIssue writes an issue and its PRs as nested bullets at the given indent
+func writeIssue(sb *strings.Builder, ig IssueEntry, indent string, cite bool) {
+	sb.WriteString(fmt.Sprintf("%s- Issue: %s\n", indent, withURL(ig.Title, ig.URL, cite)))
+	if ig.Body != "" {
+		sb.WriteString(fmt.Sprintf("%s  Description: %s\n", indent, truncateBody(ig.Body)))
+	}
+	for _, pr := range ig.PRs {
+		sb.WriteString(fmt.Sprintf("%s  - PR: %s%s\n", indent, withURL(pr.Title, pr.URL, cite), statusSuffix(pr.Status)))
+		if pr.Body != "" {
+			sb.WriteString(fmt.Sprintf("%s    Description: %s\n", indent, truncateBody(pr.Body)))
+		}
+	}
+}
+
+// statusSuffix formats a PR status for appending to its title
+func statusSuffix(status string) string {
+	if status == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", status)
+}
+
+// buildMessages constructs the chat messages for the LLM.
+// Custom templates in cfg replace the built-in system and user prompts.
+func buildMessages(cfg Config, input SummaryInput) ([]message, error) {
+	data := promptData(cfg, input)
+
+	systemPrompt, err := buildSystemPrompt(cfg, data)
+	if err != nil {
+		return nil, err
+	}
+
+	userContent := buildUserContent(cfg, input)
+	if cfg.UserTemplate != "" {
+		if userContent, err = executePrompt("user", cfg.UserTemplate, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return []message{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: userContent},
+	}, nil
+}
+
+func promptData(cfg Config, input SummaryInput) PromptData {
+	return PromptData{
+		SummaryInput: input,
+		Lang:         getLangName(cfg.Lang),
+		Prompt:       cfg.Prompt,
+	}
+}
+
+// buildSystemPrompt renders the built-in or custom system prompt
+func buildSystemPrompt(cfg Config, data PromptData) (string, error) {
+	systemPrompt := fmt.Sprintf(systemPromptTemplate, data.Lang)
+	if cfg.SystemTemplate != "" {
+		var err error
+		if systemPrompt, err = executePrompt("system", cfg.SystemTemplate, data); err != nil {
+			return "", err
+		}
+	}
+	if cfg.Structured {
+		systemPrompt += structuredOutputPrompt
+	}
+	return systemPrompt, nil
+}
+
+// buildCombineMessages asks the model to merge partial summaries of one period
+// into a single summary (the reduce step of hierarchical summarization)
+func buildCombineMessages(cfg Config, dateLabel string, parts []string) ([]message, error) {
+	systemPrompt, err := buildSystemPrompt(cfg, promptData(cfg, SummaryInput{DateLabel: dateLabel}))
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("DATE: %s\n\n", dateLabel))
+	sb.WriteString("PARTIAL SUMMARIES (each covers part of the activity; merge them into one summary):\n")
+	for i, part := range parts {
+		sb.WriteString(fmt.Sprintf("\n--- Part %d ---\n%s\n", i+1, strings.TrimSpace(part)))
+	}
+	if cfg.Prompt != "" {
+		sb.WriteString(fmt.Sprintf("\nAdditional instructions: %s\n", cfg.Prompt))
+	}
+
+	return []message{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: sb.String()},
+	}, nil
+}
+
+// buildUserContent builds the built-in user prompt listing the activity.
+// In structured mode each item carries its URL so the model can cite it.
+func buildUserContent(cfg Config, input SummaryInput) string {
+	cite := cfg.Structured
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("DATE: %s\n\n", input.DateLabel))
 
+	// Epics (issues organized by parent initiative)
+	if len(input.Epics) > 0 {
+		sb.WriteString("INITIATIVES (epics):\n")
+		for _, epic := range input.Epics {
+			sb.WriteString(fmt.Sprintf("- Epic: %s\n", withURL(epic.Title, epic.URL, cite)))
+			if epic.Body != "" {
+				sb.WriteString(fmt.Sprintf("  Description: %s\n", truncateBody(epic.Body)))
+			}
+			for _, ig := range epic.Issues {
+				writeIssue(&sb, ig, "  ", cite)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
 	// Issue groups (PRs organized by linked issues)
 	if len(input.IssueGroups) > 0 {
 		sb.WriteString("ISSUES WORKED ON:\n")
 		for _, ig := range input.IssueGroups {
-			sb.WriteString(fmt.Sprintf("- Issue: %s\n", ig.Title))
-			if ig.Body != "" {
-				sb.WriteString(fmt.Sprintf("  Description: %s\n", truncateBody(ig.Body)))
-			}
-			for _, pr := range ig.PRs {
-				sb.WriteString(fmt.Sprintf("  - PR: %s\n", pr.Title))
-				if pr.Body != "" {
-					sb.WriteString(fmt.Sprintf("    Description: %s\n", truncateBody(pr.Body)))
-				}
-			}
+			writeIssue(&sb, ig, "", cite)
 		}
 		sb.WriteString("\n")
 	}
@@ -87,7 +234,7 @@ func buildMessages(cfg Config, input SummaryInput) []message {
 	if len(input.StandalonePRs) > 0 {
 		sb.WriteString("AUTHORED PRs:\n")
 		for _, pr := range input.StandalonePRs {
-			sb.WriteString(fmt.Sprintf("- %s\n", pr.Title))
+			sb.WriteString(fmt.Sprintf("- %s%s\n", withURL(pr.Title, pr.URL, cite), statusSuffix(pr.Status)))
 			if pr.Body != "" {
 				sb.WriteString(fmt.Sprintf("  Description: %s\n", truncateBody(pr.Body)))
 			}
@@ -100,7 +247,49 @@ func buildMessages(cfg Config, input SummaryInput) []message {
 		sb.WriteString("REVIEWS SUBMITTED:\n")
 		for _, r := range input.Reviews {
 			states := strings.Join(r.States, ", ")
-			sb.WriteString(fmt.Sprintf("- %s on: %s\n", states, r.PRTitle))
+			sb.WriteString(fmt.Sprintf("- %s on: %s\n", states, withURL(r.PRTitle, r.PRURL, cite)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Issue activity (opened, commented, closed, triaged)
+	if len(input.IssueActivity) > 0 {
+		sb.WriteString("ISSUE ACTIVITY:\n")
+		for _, ia := range input.IssueActivity {
+			sb.WriteString(fmt.Sprintf("- %s (%s)\n", withURL(ia.Title, ia.URL, cite), ia.Actions))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Notes: work outside GitHub, written by the developer
+	if len(input.Notes) > 0 {
+		sb.WriteString("NOTES (recorded by the developer, outside GitHub):\n")
+		for _, n := range input.Notes {
+			theme := ""
+			if n.Theme != "" {
+				theme = fmt.Sprintf(" [%s]", n.Theme)
+			}
+			sb.WriteString(fmt.Sprintf("- %s%s\n", withURL(n.Title, n.source(), cite), theme))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Open work: current state rather than activity in the range
+	if len(input.InProgress) > 0 {
+		sb.WriteString("IN PROGRESS (open PRs):\n")
+		for _, pr := range input.InProgress {
+			sb.WriteString(fmt.Sprintf("- %s%s\n", withURL(pr.Title, pr.URL, cite), statusSuffix(pr.Status)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(input.ReviewRequests) > 0 || len(input.AssignedIssues) > 0 {
+		sb.WriteString("NEXT UP:\n")
+		for _, pr := range input.ReviewRequests {
+			sb.WriteString(fmt.Sprintf("- Review requested: %s\n", withURL(pr.Title, pr.URL, cite)))
+		}
+		for _, issue := range input.AssignedIssues {
+			sb.WriteString(fmt.Sprintf("- Assigned issue: %s\n", withURL(issue.Title, issue.URL, cite)))
 		}
 		sb.WriteString("\n")
 	}
@@ -110,12 +299,5 @@ func buildMessages(cfg Config, input SummaryInput) []message {
 		sb.WriteString(fmt.Sprintf("\nAdditional instructions: %s\n", cfg.Prompt))
 	}
 
-	// Build system prompt with language
-	langName := getLangName(cfg.Lang)
-	systemPrompt := fmt.Sprintf(systemPromptTemplate, langName)
-
-	return []message{
-		{Role: "system", Content: systemPrompt},
-		{Role: "user", Content: sb.String()},
-	}
+	return sb.String()
 }
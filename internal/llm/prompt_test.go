@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetLangName(t *testing.T) {
@@ -52,6 +53,11 @@ func TestTruncateBody(t *testing.T) {
 			input:    strings.Repeat("a", 500),
 			expected: strings.Repeat("a", 500),
 		},
+		{
+			name:     "multi-byte text truncated by character",
+			input:    strings.Repeat("日本", 300),
+			expected: strings.Repeat("日本", 250) + "...",
+		},
 		{
 			name:     "empty string",
 			input:    "",
@@ -114,6 +120,31 @@ func TestBuildMessages(t *testing.T) {
 			wantSystemLang:  "English",
 			wantUserContent: []string{"ISSUES WORKED ON:", "Fix bug", "Bug fix PR"},
 		},
+		{
+			name: "with epics",
+			cfg:  Config{Lang: "en"},
+			input: SummaryInput{
+				DateLabel: "2026-01-07",
+				Epics: []EpicEntry{
+					{
+						Title: "Auth revamp",
+						Body:  "Move login to OAuth",
+						Issues: []IssueEntry{
+							{
+								Title: "Login broken",
+								Body:  "Users can't sign in",
+								PRs:   []PREntry{{Title: "Fix login", Status: "merged"}},
+							},
+						},
+					},
+				},
+			},
+			wantSystemLang: "English",
+			wantUserContent: []string{
+				"INITIATIVES (epics):\n- Epic: Auth revamp\n  Description: Move login to OAuth\n" +
+					"  - Issue: Login broken\n    Description: Users can't sign in\n    - PR: Fix login [merged]\n",
+			},
+		},
 		{
 			name: "with reviews",
 			cfg:  Config{Lang: "en"},
@@ -135,6 +166,41 @@ func TestBuildMessages(t *testing.T) {
 			wantSystemLang:  "English",
 			wantUserContent: []string{"Additional instructions: be formal"},
 		},
+		{
+			name: "with issue activity",
+			cfg:  Config{Lang: "en"},
+			input: SummaryInput{
+				DateLabel:     "2026-01-07",
+				IssueActivity: []IssueActivityEntry{{Title: "Crash on login", Actions: "labeled bug, assigned alice"}},
+			},
+			wantSystemLang:  "English",
+			wantUserContent: []string{"ISSUE ACTIVITY:\n- Crash on login (labeled bug, assigned alice)\n"},
+		},
+		{
+			name: "with notes",
+			cfg:  Config{Lang: "en"},
+			input: SummaryInput{
+				DateLabel: "2026-01-07",
+				Notes:     []NoteEntry{{Title: "Spoke at GopherCon", Theme: "Feature"}, {Title: "Mentored a new hire"}},
+			},
+			wantSystemLang:  "English",
+			wantUserContent: []string{"NOTES (recorded by the developer, outside GitHub):\n- Spoke at GopherCon [Feature]\n- Mentored a new hire\n"},
+		},
+		{
+			name: "with open work",
+			cfg:  Config{Lang: "en"},
+			input: SummaryInput{
+				DateLabel:      "2026-01-07",
+				InProgress:     []PREntry{{Title: "Refactor cache", Status: "draft"}, {Title: "Add metrics"}},
+				ReviewRequests: []PREntry{{Title: "Teammate's PR"}},
+				AssignedIssues: []IssueEntry{{Title: "Flaky test"}},
+			},
+			wantSystemLang: "English",
+			wantUserContent: []string{
+				"IN PROGRESS (open PRs):\n- Refactor cache [draft]\n- Add metrics\n",
+				"NEXT UP:\n- Review requested: Teammate's PR\n- Assigned issue: Flaky test\n",
+			},
+		},
 		{
 			name: "with PR body",
 			cfg:  Config{Lang: "en"},
@@ -147,11 +213,61 @@ func TestBuildMessages(t *testing.T) {
 			wantSystemLang:  "English",
 			wantUserContent: []string{"Description: This PR adds a new feature"},
 		},
+		{
+			name: "structured mode cites URLs",
+			cfg:  Config{Lang: "en", Structured: true},
+			input: SummaryInput{
+				DateLabel: "2026-01-07",
+				StandalonePRs: []PREntry{
+					{Title: "Add feature", URL: "https://github.com/org/repo/pull/1"},
+				},
+			},
+			wantSystemLang:  `"sources"`,
+			wantUserContent: []string{"- Add feature <https://github.com/org/repo/pull/1>\n"},
+		},
+		{
+			name: "structured mode cites notes without a URL by ID",
+			cfg:  Config{Lang: "en", Structured: true},
+			input: SummaryInput{
+				DateLabel: "2026-01-07",
+				Notes:     []NoteEntry{{ID: "note:20260107-1a2b", Title: "Mentored a new hire"}},
+			},
+			wantSystemLang:  `"sources"`,
+			wantUserContent: []string{"- Mentored a new hire <note:20260107-1a2b>\n"},
+		},
+		{
+			name: "custom templates",
+			cfg: Config{
+				Lang:           "ja",
+				Prompt:         "be brief",
+				SystemTemplate: "Summarize in {{.Lang}}.",
+				UserTemplate:   "{{.DateLabel}}:{{range .StandalonePRs}} {{.Title}}{{end}} ({{.Prompt}})",
+			},
+			input: SummaryInput{
+				DateLabel:     "2026-01-07",
+				StandalonePRs: []PREntry{{Title: "Add feature"}},
+			},
+			wantSystemLang:  "Summarize in Japanese.",
+			wantUserContent: []string{"2026-01-07: Add feature (be brief)"},
+		},
+		{
+			name: "with PR status",
+			cfg:  Config{Lang: "en"},
+			input: SummaryInput{
+				DateLabel: "2026-01-07",
+				StandalonePRs: []PREntry{
+					{Title: "Add feature", URL: "https://github.com/org/repo/pull/1", Status: "approved, CI failing"},
+				},
+			},
+			wantSystemLang:  "English",
+			wantUserContent: []string{"- Add feature [approved, CI failing]\n"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			messages := buildMessages(tt.cfg, tt.input)
+			messages, err := buildMessages(tt.cfg, tt.input)
+			require.NoError(t, err)
 
 			assert.Len(t, messages, 2)
 			assert.Equal(t, "system", messages[0].Role)
@@ -165,3 +281,11 @@ func TestBuildMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildMessages_TemplateError(t *testing.T) {
+	_, err := buildMessages(Config{UserTemplate: "{{.Missing"}, SummaryInput{})
+	assert.Error(t, err)
+
+	_, err = buildMessages(Config{SystemTemplate: "{{.NoSuchField}}"}, SummaryInput{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package llm
+
+import "github.com/jackchuka/gh-brag/internal/redact"
+
+// redactInput applies r to every title and body in input. Items from repos r
+// doesn't allow are dropped; PRs whose issue or epic is dropped move up a level.
+func redactInput(r *redact.Redactor, input SummaryInput) SummaryInput {
+	if r == nil {
+		return input
+	}
+	allowed := func(url string) bool {
+		return r.Allowed(redact.RepoFromURL(url))
+	}
+
+	out := SummaryInput{DateLabel: input.DateLabel}
+
+	// PRs and issues are filtered individually, so an item in a denied repo
+	// never rides along under an allowed parent
+	redactPRs := func(prs []PREntry) []PREntry {
+		var kept []PREntry
+		for _, pr := range prs {
+			if !allowed(pr.URL) {
+				continue
+			}
+			pr.Title = r.Text(pr.Title)
+			pr.Body = r.Body(pr.Body, pr.Private)
+			kept = append(kept, pr)
+		}
+		return kept
+	}
+	redactIssue := func(ig IssueEntry) IssueEntry {
+		ig.Title = r.Text(ig.Title)
+		ig.Body = r.Body(ig.Body, ig.Private)
+		ig.PRs = redactPRs(ig.PRs)
+		return ig
+	}
+	addIssue := func(ig IssueEntry) {
+		if allowed(ig.URL) {
+			if ig = redactIssue(ig); len(ig.PRs) > 0 {
+				out.IssueGroups = append(out.IssueGroups, ig)
+			}
+			return
+		}
+		out.StandalonePRs = append(out.StandalonePRs, redactPRs(ig.PRs)...)
+	}
+
+	for _, epic := range input.Epics {
+		if !allowed(epic.URL) {
+			for _, ig := range epic.Issues {
+				addIssue(ig)
+			}
+			continue
+		}
+		kept := EpicEntry{
+			Title:   r.Text(epic.Title),
+			URL:     epic.URL,
+			Body:    r.Body(epic.Body, epic.Private),
+			Private: epic.Private,
+		}
+		for _, ig := range epic.Issues {
+			if !allowed(ig.URL) {
+				out.StandalonePRs = append(out.StandalonePRs, redactPRs(ig.PRs)...)
+				continue
+			}
+			if ig = redactIssue(ig); len(ig.PRs) > 0 {
+				kept.Issues = append(kept.Issues, ig)
+			}
+		}
+		if len(kept.Issues) > 0 {
+			out.Epics = append(out.Epics, kept)
+		}
+	}
+	for _, ig := range input.IssueGroups {
+		addIssue(ig)
+	}
+	out.StandalonePRs = append(out.StandalonePRs, redactPRs(input.StandalonePRs)...)
+
+	for _, rv := range input.Reviews {
+		if allowed(rv.PRURL) {
+			rv.PRTitle = r.Text(rv.PRTitle)
+			out.Reviews = append(out.Reviews, rv)
+		}
+	}
+	for _, ia := range input.IssueActivity {
+		if allowed(ia.URL) {
+			ia.Title = r.Text(ia.Title)
+			out.IssueActivity = append(out.IssueActivity, ia)
+		}
+	}
+
+	for _, n := range input.Notes {
+		if n.Repo == "" || r.Allowed(n.Repo) {
+			n.Title = r.Text(n.Title)
+			out.Notes = append(out.Notes, n)
+		}
+	}
+
+	out.InProgress = redactPRs(input.InProgress)
+	out.ReviewRequests = redactPRs(input.ReviewRequests)
+	for _, issue := range input.AssignedIssues {
+		if allowed(issue.URL) {
+			out.AssignedIssues = append(out.AssignedIssues, redactIssue(issue))
+		}
+	}
+	return out
+}
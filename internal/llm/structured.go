@@ -0,0 +1,151 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const structuredOutputPrompt = `
+Output:
+- Respond with JSON only, in this exact shape:
+  {"themes": [{"title": "<theme>", "bullets": [{"text": "<one line>", "sources": ["<url>"]}]}]}
+- Every bullet must list the URLs or note IDs (shown in <angle brackets> in the data) of the items it is based on
+- Do not use Markdown inside the JSON strings
+`
+
+// Summary is a structured summary: themes of bullets, each citing its sources
+type Summary struct {
+	Themes  []SummaryTheme `json:"themes"`
+	Dropped int            `json:"-"` // Bullets discarded for citing no known source URL
+}
+
+// SummaryTheme is a group of related bullets
+type SummaryTheme struct {
+	Title   string          `json:"title"`
+	Bullets []SummaryBullet `json:"bullets"`
+}
+
+// SummaryBullet is one summarized item and the URLs it is based on
+type SummaryBullet struct {
+	Text    string   `json:"text"`
+	Sources []string `json:"sources"`
+}
+
+// SummarizeStructured asks the model for a JSON summary and validates it against input.
+// Source URLs that don't appear in input are removed, and bullets left without a
+// source are dropped, so items the model made up don't reach the report.
+func SummarizeStructured(ctx context.Context, cfg Config, input SummaryInput) (*Summary, error) {
+	cfg = applyDefaults(cfg)
+	cfg.Structured = true
+
+	plan, err := planSummary(cfg, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parts are summarized independently and their themes merged by title
+	summary := &Summary{Themes: make([]SummaryTheme, 0)}
+	themes := make(map[string]int)
+	for _, req := range plan.requests {
+		content, err := send(ctx, cfg, req.messages, req.usage, &responseFormat{Type: "json_object"})
+		if err != nil {
+			return nil, err
+		}
+		// Only URLs that were actually sent count as sources
+		part, err := parseStructuredSummary(content, req.input)
+		if err != nil {
+			return nil, err
+		}
+		summary.Dropped += part.Dropped
+		for _, theme := range part.Themes {
+			key := strings.ToLower(theme.Title)
+			if i, ok := themes[key]; ok {
+				summary.Themes[i].Bullets = append(summary.Themes[i].Bullets, theme.Bullets...)
+				continue
+			}
+			themes[key] = len(summary.Themes)
+			summary.Themes = append(summary.Themes, theme)
+		}
+	}
+	return summary, nil
+}
+
+// parseStructuredSummary decodes the model's JSON answer and drops unsourced bullets
+func parseStructuredSummary(content string, input SummaryInput) (*Summary, error) {
+	var raw Summary
+	if err := json.Unmarshal([]byte(stripCodeFence(content)), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse structured summary: %w", err)
+	}
+
+	known := knownURLs(input)
+	summary := &Summary{Themes: make([]SummaryTheme, 0, len(raw.Themes))}
+	for _, theme := range raw.Themes {
+		kept := SummaryTheme{Title: strings.TrimSpace(theme.Title)}
+		for _, b := range theme.Bullets {
+			var sources []string
+			for _, u := range b.Sources {
+				u = strings.TrimSpace(u)
+				if known[u] && !slices.Contains(sources, u) {
+					sources = append(sources, u)
+				}
+			}
+			text := strings.TrimSpace(b.Text)
+			if len(sources) == 0 || text == "" {
+				summary.Dropped++
+				continue
+			}
+			kept.Bullets = append(kept.Bullets, SummaryBullet{Text: text, Sources: sources})
+		}
+		if len(kept.Bullets) > 0 {
+			summary.Themes = append(summary.Themes, kept)
+		}
+	}
+	return summary, nil
+}
+
+// knownURLs collects every item URL in the input, and the IDs of notes without one
+func knownURLs(input SummaryInput) map[string]bool {
+	known := make(map[string]bool)
+	add := func(u string) {
+		if u != "" {
+			known[u] = true
+		}
+	}
+	addIssue := func(ig IssueEntry) {
+		add(ig.URL)
+		for _, pr := range ig.PRs {
+			add(pr.URL)
+		}
+	}
+
+	for _, epic := range input.Epics {
+		add(epic.URL)
+		for _, ig := range epic.Issues {
+			addIssue(ig)
+		}
+	}
+	for _, ig := range input.IssueGroups {
+		addIssue(ig)
+	}
+	for _, ig := range input.AssignedIssues {
+		addIssue(ig)
+	}
+	for _, prs := range [][]PREntry{input.StandalonePRs, input.InProgress, input.ReviewRequests} {
+		for _, pr := range prs {
+			add(pr.URL)
+		}
+	}
+	for _, r := range input.Reviews {
+		add(r.PRURL)
+	}
+	for _, ia := range input.IssueActivity {
+		add(ia.URL)
+	}
+	for _, n := range input.Notes {
+		add(n.source())
+	}
+	return known
+}
@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStructuredSummary(t *testing.T) {
+	input := SummaryInput{
+		IssueGroups: []IssueEntry{
+			{URL: "https://github.com/org/repo/issues/1", PRs: []PREntry{{URL: "https://github.com/org/repo/pull/2"}}},
+		},
+		Reviews: []ReviewEntry{{PRURL: "https://github.com/org/repo/pull/3"}},
+	}
+
+	content := "```json\n" + `{"themes": [
+		{"title": "Auth", "bullets": [
+			{"text": "Fixed login", "sources": ["https://github.com/org/repo/pull/2", "https://github.com/org/repo/pull/2", "https://example.com/made-up"]},
+			{"text": "Invented work", "sources": ["https://github.com/org/repo/pull/99"]},
+			{"text": "No sources"}
+		]},
+		{"title": "Reviews", "bullets": [
+			{"text": "Reviewed a PR", "sources": [" https://github.com/org/repo/pull/3 "]}
+		]},
+		{"title": "Empty", "bullets": [
+			{"text": "Hallucinated", "sources": []}
+		]}
+	]}` + "\n```"
+
+	summary, err := parseStructuredSummary(content, input)
+	require.NoError(t, err)
+
+	assert.Equal(t, []SummaryTheme{
+		{Title: "Auth", Bullets: []SummaryBullet{{Text: "Fixed login", Sources: []string{"https://github.com/org/repo/pull/2"}}}},
+		{Title: "Reviews", Bullets: []SummaryBullet{{Text: "Reviewed a PR", Sources: []string{"https://github.com/org/repo/pull/3"}}}},
+	}, summary.Themes)
+	assert.Equal(t, 3, summary.Dropped)
+}
+
+func TestParseStructuredSummary_InvalidJSON(t *testing.T) {
+	_, err := parseStructuredSummary("- not json", SummaryInput{})
+	assert.Error(t, err)
+}
+
+func TestParseStructuredSummary_Notes(t *testing.T) {
+	input := SummaryInput{Notes: []NoteEntry{
+		{ID: "note:20260304-1a2b", Title: "Mentored a new hire"},
+		{ID: "note:20260304-3c4d", Title: "Wrote the design doc", URL: "https://docs.example.com/design"},
+	}}
+
+	content := `{"themes": [{"title": "Team", "bullets": [
+		{"text": "Mentored a new hire", "sources": ["note:20260304-1a2b"]},
+		{"text": "Wrote the design doc", "sources": ["https://docs.example.com/design", "note:20260304-3c4d"]}
+	]}]}`
+
+	summary, err := parseStructuredSummary(content, input)
+	require.NoError(t, err)
+
+	// Notes without a URL are cited by ID; those with one, by URL only
+	assert.Equal(t, []SummaryTheme{{Title: "Team", Bullets: []SummaryBullet{
+		{Text: "Mentored a new hire", Sources: []string{"note:20260304-1a2b"}},
+		{Text: "Wrote the design doc", Sources: []string{"https://docs.example.com/design"}},
+	}}}, summary.Themes)
+	assert.Zero(t, summary.Dropped)
+}
@@ -0,0 +1,141 @@
+// Package note records accomplishments outside GitHub, such as talks, incidents
+// or mentoring, as events in the same store as collected activity.
+package note
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/redact"
+)
+
+// Kind is the event kind of notes
+const Kind = "note"
+
+// idPrefix starts every note ID, e.g. note:20261018-3f9a1c2e
+const idPrefix = Kind + ":"
+
+// DateLayout is the format of note dates
+const DateLayout = "2006-01-02"
+
+// Fields are the parts of a note a user sets
+type Fields struct {
+	Date   time.Time // Day the accomplishment happened on
+	Title  string
+	Theme  string  // Configured theme name; "" lets keywords decide
+	URL    string  // Optional link, e.g. a design doc or talk recording
+	Impact float64 // Replaces the noted action weight when > 0
+}
+
+// New returns a note event for f with a fresh ID
+func New(f Fields, now time.Time) (data.Event, error) {
+	if err := f.Validate(); err != nil {
+		return data.Event{}, err
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return data.Event{}, fmt.Errorf("failed to generate note ID: %w", err)
+	}
+	e := data.Event{
+		ID:        idPrefix + f.Date.Format("20060102") + "-" + hex.EncodeToString(suffix),
+		Action:    data.EventActionNoted,
+		Kind:      Kind,
+		Reviewers: []string{},
+		Source:    data.Source{Tool: "gh brag note", FetchedAt: now},
+	}
+	Apply(&e, f)
+	return e, nil
+}
+
+// Apply sets the fields of an existing note
+func Apply(e *data.Event, f Fields) {
+	e.Title = strings.TrimSpace(f.Title)
+	e.Theme = f.Theme
+	e.URL = f.URL
+	e.Repo = repoOf(f.URL)
+	e.Impact = f.Impact
+	e.Timestamps = data.Timestamps{CreatedAt: f.Date, UpdatedAt: f.Date, ClosedAt: f.Date}
+}
+
+// FieldsOf returns the fields of a note
+func FieldsOf(e data.Event) Fields {
+	return Fields{
+		Date:   e.Timestamps.CreatedAt,
+		Title:  e.Title,
+		Theme:  e.Theme,
+		URL:    e.URL,
+		Impact: e.Impact,
+	}
+}
+
+// Validate reports fields a note can't be saved with
+func (f Fields) Validate() error {
+	if strings.TrimSpace(f.Title) == "" {
+		return fmt.Errorf("title must not be empty")
+	}
+	if f.Date.IsZero() {
+		return fmt.Errorf("date must be set")
+	}
+	if f.Impact < 0 {
+		return fmt.Errorf("impact must not be negative")
+	}
+	if f.URL != "" {
+		u, err := url.Parse(f.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("url must be an http(s) URL")
+		}
+	}
+	return nil
+}
+
+// ParseDate parses a YYYY-MM-DD date as midnight in loc. An empty date is today.
+func ParseDate(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		now = now.In(loc)
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc), nil
+	}
+	t, err := time.ParseInLocation(DateLayout, s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD)", s)
+	}
+	return t, nil
+}
+
+// Is reports whether e is a note
+func Is(e data.Event) bool {
+	return e.Kind == Kind
+}
+
+// Find returns the index of the note with the given ID, which may omit the "note:" prefix
+func Find(events []data.Event, id string) (int, error) {
+	if !strings.HasPrefix(id, idPrefix) {
+		id = idPrefix + id
+	}
+	for i, e := range events {
+		if Is(e) && e.ID == id {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("no note with ID %q", id)
+}
+
+// Day returns the calendar date of a note. Notes are dated by day, so the day
+// is read in the zone the note was written in rather than converted.
+func Day(e data.Event) string {
+	return e.Timestamps.CreatedAt.Format(DateLayout)
+}
+
+// repoOf returns owner/name for github.com repository URLs, or "" for links
+// elsewhere, such as a design doc whose path happens to have two parts
+func repoOf(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || !strings.EqualFold(u.Host, "github.com") {
+		return ""
+	}
+	return redact.RepoFromURL(raw)
+}
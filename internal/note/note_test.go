@@ -0,0 +1,104 @@
+package note
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	date, err := ParseDate("2026-03-04", time.Now(), tokyo)
+	require.NoError(t, err)
+	now := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	e, err := New(Fields{
+		Date:   date,
+		Title:  " Spoke at GopherCon ",
+		Theme:  "Feature",
+		URL:    "https://github.com/acme/talks/pull/7",
+		Impact: 20,
+	}, now)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(e.ID, "note:20260304-"), e.ID)
+	assert.Equal(t, data.EventActionNoted, e.Action)
+	assert.Equal(t, Kind, e.Kind)
+	assert.Equal(t, "Spoke at GopherCon", e.Title)
+	assert.Equal(t, "acme/talks", e.Repo)
+	assert.Equal(t, 20.0, e.Impact)
+	assert.Equal(t, date, e.Timestamps.UpdatedAt)
+	assert.Equal(t, now, e.Source.FetchedAt)
+
+	// The day survives a round trip through the store, whatever the local zone
+	raw, err := json.Marshal(e)
+	require.NoError(t, err)
+	var loaded data.Event
+	require.NoError(t, json.Unmarshal(raw, &loaded))
+	assert.Equal(t, "2026-03-04", Day(loaded))
+
+	other, err := New(Fields{Date: date, Title: "Another"}, now)
+	require.NoError(t, err)
+	assert.NotEqual(t, e.ID, other.ID)
+}
+
+func TestFields_Validate(t *testing.T) {
+	date := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		fields  Fields
+		wantErr string
+	}{
+		{"no title", Fields{Date: date, Title: " "}, "title must not be empty"},
+		{"no date", Fields{Title: "x"}, "date must be set"},
+		{"negative impact", Fields{Date: date, Title: "x", Impact: -1}, "impact must not be negative"},
+		{"bad url", Fields{Date: date, Title: "x", URL: "docs/talk.pdf"}, "url must be an http(s) URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.fields.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2026, 3, 4, 23, 30, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	today, err := ParseDate("", now, tokyo)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 5, 0, 0, 0, 0, tokyo), today)
+
+	_, err = ParseDate("2026/03/04", now, tokyo)
+	assert.EqualError(t, err, `invalid date "2026/03/04" (want YYYY-MM-DD)`)
+}
+
+func TestFind(t *testing.T) {
+	events := []data.Event{
+		{ID: "pr:https://github.com/acme/api/pull/1:merged", Kind: "prs"},
+		{ID: "note:20260304-3f9a1c2e", Kind: Kind},
+	}
+
+	i, err := Find(events, "20260304-3f9a1c2e")
+	require.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	i, err = Find(events, "note:20260304-3f9a1c2e")
+	require.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	_, err = Find(events, "pr:https://github.com/acme/api/pull/1:merged")
+	assert.Error(t, err, "only notes can be found")
+}
+
+func TestRepoOf(t *testing.T) {
+	assert.Equal(t, "acme/api", repoOf("https://github.com/acme/api/issues/3"))
+	assert.Equal(t, "", repoOf("https://github.com/acme"))
+	assert.Equal(t, "", repoOf("https://docs.example.com/acme/api"))
+	assert.Equal(t, "", repoOf(""))
+}
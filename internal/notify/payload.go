@@ -0,0 +1,200 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Targets lists the supported --post targets
+var Targets = []string{"slack", "teams", "discord", "webhook"}
+
+// Platform limits, in characters
+const (
+	slackSectionLimit = 3000  // Text of one section block
+	slackBlockLimit   = 50    // Blocks per message
+	discordLimit      = 2000  // Message content
+	teamsLimit        = 20000 // Stays well under the ~28KB Teams payload limit
+)
+
+// Message is a report ready to post
+type Message struct {
+	Title string // Plain-text title, used for notifications and previews
+	Text  string // Body in the target's markup (Slack mrkdwn, Markdown)
+	JSON  []byte // Raw body posted by the generic webhook target
+}
+
+// Payloads builds the request bodies for target, splitting the message
+// into several posts when it exceeds the platform's limits
+func Payloads(target string, msg Message) ([][]byte, error) {
+	switch target {
+	case "slack":
+		return slackPayloads(msg)
+	case "teams":
+		return teamsPayloads(msg)
+	case "discord":
+		return discordPayloads(msg)
+	case "webhook":
+		if len(msg.JSON) == 0 {
+			return nil, fmt.Errorf("webhook message has no JSON body")
+		}
+		return [][]byte{msg.JSON}, nil
+	default:
+		return nil, fmt.Errorf("unsupported target %q: must be one of %v", target, Targets)
+	}
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type string    `json:"type"`
+	Text slackText `json:"text"`
+}
+
+type slackMessage struct {
+	Text   string       `json:"text"` // Notification fallback
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// slackPayloads renders Block Kit messages with one mrkdwn section per chunk
+func slackPayloads(msg Message) ([][]byte, error) {
+	chunks := splitText(msg.Text, slackSectionLimit)
+
+	var payloads [][]byte
+	for len(chunks) > 0 {
+		n := min(len(chunks), slackBlockLimit)
+		m := slackMessage{Text: msg.Title}
+		for _, c := range chunks[:n] {
+			m.Blocks = append(m.Blocks, slackBlock{Type: "section", Text: slackText{Type: "mrkdwn", Text: c}})
+		}
+		chunks = chunks[n:]
+
+		p, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, p)
+	}
+	return payloads, nil
+}
+
+type teamsTextBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	Wrap bool   `json:"wrap"`
+}
+
+type teamsCard struct {
+	Schema  string           `json:"$schema"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Body    []teamsTextBlock `json:"body"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Summary     string            `json:"summary,omitempty"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+// teamsPayloads renders one Adaptive Card message per chunk
+func teamsPayloads(msg Message) ([][]byte, error) {
+	var payloads [][]byte
+	for _, c := range splitText(msg.Text, teamsLimit) {
+		m := teamsMessage{
+			Type:    "message",
+			Summary: msg.Title,
+			Attachments: []teamsAttachment{{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content: teamsCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body:    []teamsTextBlock{{Type: "TextBlock", Text: c, Wrap: true}},
+				},
+			}},
+		}
+
+		p, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, p)
+	}
+	return payloads, nil
+}
+
+type discordMessage struct {
+	Content string `json:"content"`
+}
+
+// discordPayloads renders one message per chunk
+func discordPayloads(msg Message) ([][]byte, error) {
+	var payloads [][]byte
+	for _, c := range splitText(msg.Text, discordLimit) {
+		p, err := json.Marshal(discordMessage{Content: c})
+		if err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, p)
+	}
+	return payloads, nil
+}
+
+// splitText cuts text into chunks of at most limit characters, breaking between lines.
+// Lines longer than limit are cut on their own.
+func splitText(text string, limit int) []string {
+	text = strings.TrimRight(text, "\n")
+	if utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+
+	var chunks []string
+	var cur strings.Builder
+	curLen := 0
+	flush := func() {
+		if curLen > 0 {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+			curLen = 0
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		lineLen := utf8.RuneCountInString(line)
+
+		// Oversized line: emit it in limit-sized pieces
+		for lineLen > limit {
+			flush()
+			runes := []rune(line)
+			chunks = append(chunks, string(runes[:limit]))
+			line = string(runes[limit:])
+			lineLen -= limit
+		}
+
+		sep := 0
+		if curLen > 0 {
+			sep = 1
+		}
+		if curLen+sep+lineLen > limit {
+			flush()
+			sep = 0
+		}
+		if sep == 1 {
+			cur.WriteByte('\n')
+		}
+		cur.WriteString(line)
+		curLen += sep + lineLen
+	}
+	flush()
+	return chunks
+}
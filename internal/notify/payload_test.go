@@ -0,0 +1,109 @@
+package notify
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{name: "fits", text: "a\nb\n", limit: 10, want: []string{"a\nb"}},
+		{name: "breaks between lines", text: "aaa\nbbb\nccc", limit: 7, want: []string{"aaa\nbbb", "ccc"}},
+		{name: "long line is cut", text: "abcdefgh\nij", limit: 3, want: []string{"abc", "def", "gh", "ij"}},
+		{name: "multibyte", text: "日本語\nテキスト", limit: 4, want: []string{"日本語", "テキスト"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitText(tt.text, tt.limit))
+		})
+	}
+}
+
+func TestPayloads(t *testing.T) {
+	msg := Message{Title: "Daily report (2026-05-12)", Text: "*What I Did*\n• <https://x|PR>", JSON: []byte(`{"dateLabel":"2026-05-12"}`)}
+
+	t.Run("slack", func(t *testing.T) {
+		payloads, err := Payloads("slack", msg)
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+
+		var m slackMessage
+		require.NoError(t, json.Unmarshal(payloads[0], &m))
+		assert.Equal(t, msg.Title, m.Text)
+		require.Len(t, m.Blocks, 1)
+		assert.Equal(t, "section", m.Blocks[0].Type)
+		assert.Equal(t, "mrkdwn", m.Blocks[0].Text.Type)
+		assert.Equal(t, msg.Text, m.Blocks[0].Text.Text)
+	})
+
+	t.Run("teams", func(t *testing.T) {
+		payloads, err := Payloads("teams", msg)
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+
+		var m teamsMessage
+		require.NoError(t, json.Unmarshal(payloads[0], &m))
+		require.Len(t, m.Attachments, 1)
+		assert.Equal(t, "application/vnd.microsoft.card.adaptive", m.Attachments[0].ContentType)
+		assert.Equal(t, "AdaptiveCard", m.Attachments[0].Content.Type)
+		assert.Equal(t, msg.Text, m.Attachments[0].Content.Body[0].Text)
+	})
+
+	t.Run("discord", func(t *testing.T) {
+		payloads, err := Payloads("discord", msg)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"content":"*What I Did*\n• <https://x|PR>"}`, string(payloads[0]))
+	})
+
+	t.Run("webhook", func(t *testing.T) {
+		payloads, err := Payloads("webhook", msg)
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{msg.JSON}, payloads)
+
+		_, err = Payloads("webhook", Message{Text: "x"})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := Payloads("email", msg)
+		assert.Error(t, err)
+	})
+}
+
+func TestPayloads_Splitting(t *testing.T) {
+	line := strings.Repeat("x", 99)
+	var lines []string
+	for range 2000 {
+		lines = append(lines, line)
+	}
+	long := Message{Title: "t", Text: strings.Join(lines, "\n")} // 200,000 characters
+
+	slack, err := Payloads("slack", long)
+	require.NoError(t, err)
+	require.Len(t, slack, 2) // 67 sections of 3000 characters, 50 per message
+	var m slackMessage
+	require.NoError(t, json.Unmarshal(slack[0], &m))
+	assert.Len(t, m.Blocks, slackBlockLimit)
+	for _, b := range m.Blocks {
+		assert.LessOrEqual(t, utf8.RuneCountInString(b.Text.Text), slackSectionLimit)
+	}
+
+	discord, err := Payloads("discord", long)
+	require.NoError(t, err)
+	assert.Len(t, discord, 100)
+
+	teams, err := Payloads("teams", long)
+	require.NoError(t, err)
+	assert.Len(t, teams, 10)
+}
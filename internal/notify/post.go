@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAttempts = 3
+	defaultBackoff  = time.Second
+	maxRetryAfter   = 30 * time.Second
+)
+
+// Options control how payloads are delivered
+type Options struct {
+	Client   *http.Client  // nil uses http.DefaultClient
+	Attempts int           // Tries per payload (default 3)
+	Backoff  time.Duration // Delay before the first retry, doubled each time (default 1s)
+}
+
+// EnvVar returns the environment variable that overrides the configured URL for target
+func EnvVar(target string) string {
+	if target == "webhook" {
+		return "GH_BRAG_WEBHOOK_URL"
+	}
+	return "GH_BRAG_" + strings.ToUpper(target) + "_WEBHOOK"
+}
+
+// ResolveURL returns the webhook URL for target, preferring the environment over config
+func ResolveURL(target, configured string) string {
+	if v := os.Getenv(EnvVar(target)); v != "" {
+		return v
+	}
+	return configured
+}
+
+// Post sends each payload to url in order. Network errors, 429 and 5xx
+// responses are retried with exponential backoff, honoring Retry-After.
+func Post(ctx context.Context, url string, payloads [][]byte, opts Options) error {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.Attempts <= 0 {
+		opts.Attempts = defaultAttempts
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = defaultBackoff
+	}
+
+	for i, p := range payloads {
+		if err := postWithRetry(ctx, url, p, opts); err != nil {
+			if len(payloads) > 1 {
+				return fmt.Errorf("part %d of %d: %w", i+1, len(payloads), err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func postWithRetry(ctx context.Context, url string, payload []byte, opts Options) error {
+	backoff := opts.Backoff
+	var lastErr error
+
+	for attempt := 1; attempt <= opts.Attempts; attempt++ {
+		wait, err := postOnce(ctx, url, payload, opts.Client)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if wait < 0 || attempt == opts.Attempts {
+			break
+		}
+
+		if wait == 0 {
+			wait = backoff
+			backoff *= 2
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	return lastErr
+}
+
+// postOnce sends one request. On failure it returns how long to wait before
+// retrying: 0 for the default backoff, or -1 if the error is not retryable.
+func postOnce(ctx context.Context, url string, payload []byte, client *http.Client) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return -1, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return -1, ctx.Err()
+		}
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return 0, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("webhook error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return retryAfter(resp.Header.Get("Retry-After")), err
+	}
+	return -1, err
+}
+
+// retryAfter parses a Retry-After header in seconds, capped at maxRetryAfter
+func retryAfter(header string) time.Duration {
+	secs, err := strconv.ParseFloat(header, 64)
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return min(time.Duration(secs*float64(time.Second)), maxRetryAfter)
+}
@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubServer replies with the given status codes in order, then 200
+type stubServer struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+	headers  []http.Header
+}
+
+func (s *stubServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	s.bodies = append(s.bodies, string(body))
+	s.headers = append(s.headers, r.Header.Clone())
+
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status = s.statuses[0]
+		s.statuses = s.statuses[1:]
+	}
+	if status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "0.01")
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func TestPost(t *testing.T) {
+	fast := Options{Backoff: time.Millisecond}
+
+	t.Run("posts every payload in order", func(t *testing.T) {
+		stub := &stubServer{}
+		srv := httptest.NewServer(stub)
+		defer srv.Close()
+
+		err := Post(context.Background(), srv.URL, [][]byte{[]byte(`{"n":1}`), []byte(`{"n":2}`)}, fast)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`{"n":1}`, `{"n":2}`}, stub.bodies)
+		assert.Equal(t, "application/json", stub.headers[0].Get("Content-Type"))
+	})
+
+	t.Run("retries server errors and rate limits", func(t *testing.T) {
+		stub := &stubServer{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+		srv := httptest.NewServer(stub)
+		defer srv.Close()
+
+		err := Post(context.Background(), srv.URL, [][]byte{[]byte(`{}`)}, fast)
+		require.NoError(t, err)
+		assert.Len(t, stub.bodies, 3)
+	})
+
+	t.Run("gives up after attempts", func(t *testing.T) {
+		stub := &stubServer{statuses: []int{500, 500, 500, 500}}
+		srv := httptest.NewServer(stub)
+		defer srv.Close()
+
+		err := Post(context.Background(), srv.URL, [][]byte{[]byte(`{}`)}, fast)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 500")
+		assert.Len(t, stub.bodies, 3)
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		stub := &stubServer{statuses: []int{http.StatusBadRequest}}
+		srv := httptest.NewServer(stub)
+		defer srv.Close()
+
+		err := Post(context.Background(), srv.URL, [][]byte{[]byte(`{}`), []byte(`{}`)}, fast)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "part 1 of 2")
+		assert.Len(t, stub.bodies, 1)
+	})
+}
+
+func TestResolveURL(t *testing.T) {
+	assert.Equal(t, "GH_BRAG_SLACK_WEBHOOK", EnvVar("slack"))
+	assert.Equal(t, "GH_BRAG_WEBHOOK_URL", EnvVar("webhook"))
+
+	t.Setenv("GH_BRAG_DISCORD_WEBHOOK", "")
+	assert.Equal(t, "https://config", ResolveURL("discord", "https://config"))
+
+	t.Setenv("GH_BRAG_DISCORD_WEBHOOK", "https://env")
+	assert.Equal(t, "https://env", ResolveURL("discord", "https://config"))
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), retryAfter(""))
+	assert.Equal(t, 2*time.Second, retryAfter("2"))
+	assert.Equal(t, maxRetryAfter, retryAfter("3600"))
+}
@@ -0,0 +1,152 @@
+// Package redact removes sensitive data from text before it is sent to an LLM.
+package redact
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Rule replaces every match of Pattern with Replace.
+// Replace may use $1-style references; empty uses "[REDACTED:<name>]".
+type Rule struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"`
+	Replace string `yaml:"replace"`
+}
+
+// Options configures a Redactor
+type Options struct {
+	Rules            []Rule   // Custom regex rules, applied after the secret patterns
+	Secrets          bool     // Apply the built-in secret patterns (tokens, keys, emails)
+	Allow            []string // Repos or orgs whose items may be sent; empty allows all
+	Deny             []string // Repos or orgs whose items are never sent; wins over Allow
+	PrivateTitleOnly bool     // Send only titles for items in private repos
+}
+
+// SecretRules are the built-in patterns applied when Options.Secrets is set
+var SecretRules = []Rule{
+	{Name: "private-key", Pattern: `-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`},
+	{Name: "github-token", Pattern: `\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`},
+	{Name: "aws-key", Pattern: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+	{Name: "slack-token", Pattern: `\bxox[abposr]-[A-Za-z0-9-]{10,}`},
+	{Name: "openai-key", Pattern: `\bsk-[A-Za-z0-9_-]{20,}`},
+	{Name: "jwt", Pattern: `\beyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`},
+	{Name: "secret", Pattern: `(?i)\b((?:api[_-]?key|secret|token|password|passwd)\s*[:=]\s*)["']?[^\s"']{6,}["']?`, Replace: "${1}[REDACTED:secret]"},
+	{Name: "email", Pattern: `\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`},
+}
+
+type compiledRule struct {
+	re      *regexp.Regexp
+	replace string
+}
+
+// Redactor applies redaction rules and repo filters
+type Redactor struct {
+	rules            []compiledRule
+	allow            []string
+	deny             []string
+	privateTitleOnly bool
+}
+
+// New compiles the rules in opts
+func New(opts Options) (*Redactor, error) {
+	r := &Redactor{
+		allow:            opts.Allow,
+		deny:             opts.Deny,
+		privateTitleOnly: opts.PrivateTitleOnly,
+	}
+
+	var rules []Rule
+	if opts.Secrets {
+		rules = append(rules, SecretRules...)
+	}
+	rules = append(rules, opts.Rules...)
+
+	for i, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			name := rule.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			return nil, fmt.Errorf("invalid redaction rule %s: %w", name, err)
+		}
+		replace := rule.Replace
+		if replace == "" {
+			name := rule.Name
+			if name == "" {
+				name = "custom"
+			}
+			replace = "[REDACTED:" + name + "]"
+		}
+		r.rules = append(r.rules, compiledRule{re: re, replace: replace})
+	}
+	return r, nil
+}
+
+// Text applies every rule to s
+func (r *Redactor) Text(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, rule := range r.rules {
+		s = rule.re.ReplaceAllString(s, rule.replace)
+	}
+	return s
+}
+
+// Body redacts an item body, dropping it entirely for private repos in title-only mode
+func (r *Redactor) Body(s string, private bool) string {
+	if r == nil {
+		return s
+	}
+	if private && r.privateTitleOnly {
+		return ""
+	}
+	return r.Text(s)
+}
+
+// Allowed reports whether items from repo ("owner/name") may be sent.
+// Deny and Allow entries match an org ("owner"), a repo ("owner/name")
+// or a glob over the repo ("owner/api-*").
+func (r *Redactor) Allowed(repo string) bool {
+	if r == nil {
+		return true
+	}
+	if matchAny(r.deny, repo) {
+		return false
+	}
+	return len(r.allow) == 0 || matchAny(r.allow, repo)
+}
+
+func matchAny(patterns []string, repo string) bool {
+	owner, _, _ := strings.Cut(repo, "/")
+	for _, p := range patterns {
+		if !strings.Contains(p, "/") {
+			if ok, _ := path.Match(strings.ToLower(p), strings.ToLower(owner)); ok {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(strings.ToLower(p), strings.ToLower(repo)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RepoFromURL returns "owner/name" from a GitHub URL such as
+// https://github.com/owner/name/pull/1, or "" if url has no repo path
+func RepoFromURL(url string) string {
+	_, rest, ok := strings.Cut(url, "://")
+	if !ok {
+		return ""
+	}
+	parts := strings.SplitN(rest, "/", 4)
+	if len(parts) < 3 || parts[1] == "" || parts[2] == "" {
+		return ""
+	}
+	return parts[1] + "/" + parts[2]
+}
@@ -0,0 +1,226 @@
+// Package rules decides which events are noise, such as bot PRs, forks and archived repos.
+package rules
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// Rule matches events. Every condition that is set must hold; a list
+// condition holds when any of its entries matches.
+type Rule struct {
+	Name     string   `yaml:"name"`     // Shown by --show-excluded (defaults to the conditions)
+	Repos    []string `yaml:"repos"`    // owner/name globs, e.g. "archived/*" or "*/docs-*"
+	Authors  []string `yaml:"authors"`  // Author login globs, e.g. "renovate*"
+	Bot      *bool    `yaml:"bot"`      // Author is (or isn't) a bot account
+	Fork     *bool    `yaml:"fork"`     // Repository is (or isn't) a fork
+	Archived *bool    `yaml:"archived"` // Repository is (or isn't) archived
+	Labels   []string `yaml:"labels"`   // Label globs; any label of the event may match
+	Title    string   `yaml:"title"`    // Regular expression matched against the title
+	Kinds    []string `yaml:"kinds"`    // pr, issue, note, commit
+	Actions  []string `yaml:"actions"`  // merged, authored, reviewed, noted, committed
+}
+
+// Kinds are the event kinds rules can match
+var Kinds = []string{"pr", "issue", "note", "commit"}
+
+// Actions are the event actions rules can match
+var Actions = []string{string(data.EventActionMerged), string(data.EventActionAuthored), string(data.EventActionReviewed), string(data.EventActionNoted), string(data.EventActionCommitted)}
+
+// rule is a compiled Rule
+type rule struct {
+	Rule
+	title *regexp.Regexp
+	label string
+}
+
+// Set is a compiled list of exclude and include rules. An event is excluded
+// when an exclude rule matches it and no include rule does. A nil Set keeps everything.
+type Set struct {
+	exclude []rule
+	include []rule
+}
+
+// Exclusion is an event a Set dropped, with the rule that dropped it
+type Exclusion struct {
+	Event data.Event
+	Rule  string
+}
+
+// New compiles exclude and include rules
+func New(exclude, include []Rule) (*Set, error) {
+	s := &Set{}
+	for _, list := range []struct {
+		name  string
+		rules []Rule
+		dst   *[]rule
+	}{{"exclude", exclude, &s.exclude}, {"include", include, &s.include}} {
+		for i, r := range list.rules {
+			c, err := compile(r)
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d]: %w", list.name, i, err)
+			}
+			*list.dst = append(*list.dst, c)
+		}
+	}
+	return s, nil
+}
+
+func compile(r Rule) (rule, error) {
+	conditions := r.String()
+	if conditions == "" {
+		return rule{}, fmt.Errorf("rule has no conditions and would match every event")
+	}
+	c := rule{Rule: r, label: r.Name}
+	if c.label == "" {
+		c.label = conditions
+	}
+	for _, globs := range [][]string{r.Repos, r.Authors, r.Labels} {
+		for _, g := range globs {
+			if _, err := path.Match(g, ""); err != nil {
+				return rule{}, fmt.Errorf("invalid pattern %q", g)
+			}
+		}
+	}
+	for _, k := range r.Kinds {
+		if !slices.Contains(Kinds, k) {
+			return rule{}, fmt.Errorf("unknown kind %q (want one of %s)", k, strings.Join(Kinds, ", "))
+		}
+	}
+	for _, a := range r.Actions {
+		if !slices.Contains(Actions, a) {
+			return rule{}, fmt.Errorf("unknown action %q (want one of %s)", a, strings.Join(Actions, ", "))
+		}
+	}
+	if r.Title != "" {
+		re, err := regexp.Compile(r.Title)
+		if err != nil {
+			return rule{}, fmt.Errorf("invalid title pattern: %w", err)
+		}
+		c.title = re
+	}
+	return c, nil
+}
+
+// String describes the rule's conditions, e.g. `repos=archived/* bot=true`
+func (r Rule) String() string {
+	var parts []string
+	list := func(key string, values []string) {
+		if len(values) > 0 {
+			parts = append(parts, key+"="+strings.Join(values, ","))
+		}
+	}
+	flag := func(key string, v *bool) {
+		if v != nil {
+			parts = append(parts, fmt.Sprintf("%s=%t", key, *v))
+		}
+	}
+	list("repos", r.Repos)
+	list("authors", r.Authors)
+	flag("bot", r.Bot)
+	flag("fork", r.Fork)
+	flag("archived", r.Archived)
+	list("labels", r.Labels)
+	if r.Title != "" {
+		parts = append(parts, "title=/"+r.Title+"/")
+	}
+	list("kinds", r.Kinds)
+	list("actions", r.Actions)
+	return strings.Join(parts, " ")
+}
+
+// Excluded returns the name of the rule that excludes e, or "" if e is kept
+func (s *Set) Excluded(e data.Event) string {
+	if s == nil {
+		return ""
+	}
+	for _, r := range s.exclude {
+		if !r.matches(e) {
+			continue
+		}
+		for _, inc := range s.include {
+			if inc.matches(e) {
+				return ""
+			}
+		}
+		return r.label
+	}
+	return ""
+}
+
+// Filter splits events into those kept and those excluded
+func (s *Set) Filter(events []data.Event) ([]data.Event, []Exclusion) {
+	if s == nil || len(s.exclude) == 0 {
+		return events, nil
+	}
+	kept := events[:0:0]
+	var excluded []Exclusion
+	for _, e := range events {
+		if name := s.Excluded(e); name != "" {
+			excluded = append(excluded, Exclusion{Event: e, Rule: name})
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept, excluded
+}
+
+func (r rule) matches(e data.Event) bool {
+	if len(r.Repos) > 0 && !matchAny(r.Repos, e.Repo) {
+		return false
+	}
+	if len(r.Authors) > 0 && !matchAny(r.Authors, e.Author) {
+		return false
+	}
+	if r.Bot != nil && *r.Bot != IsBot(e) {
+		return false
+	}
+	if r.Fork != nil && *r.Fork != e.Fork {
+		return false
+	}
+	if r.Archived != nil && *r.Archived != e.Archived {
+		return false
+	}
+	if len(r.Labels) > 0 && !slices.ContainsFunc(e.Labels, func(l string) bool { return matchAny(r.Labels, l) }) {
+		return false
+	}
+	if r.title != nil && !r.title.MatchString(e.Title) {
+		return false
+	}
+	if len(r.Kinds) > 0 && !slices.Contains(r.Kinds, Kind(e)) {
+		return false
+	}
+	if len(r.Actions) > 0 && !slices.Contains(r.Actions, string(e.Action)) {
+		return false
+	}
+	return true
+}
+
+// matchAny reports whether s equals or matches any of the globs, ignoring case
+// as GitHub does for names. Equality lets "renovate[bot]" match literally.
+func matchAny(globs []string, s string) bool {
+	s = strings.ToLower(s)
+	for _, g := range globs {
+		g = strings.ToLower(g)
+		if ok, _ := path.Match(g, s); ok || g == s {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBot reports whether the event's author is a bot. Events collected before
+// the author type was recorded are recognized by a "[bot]" login suffix.
+func IsBot(e data.Event) bool {
+	return e.AuthorBot || strings.HasSuffix(e.Author, "[bot]")
+}
+
+// Kind returns the event's kind as pr, issue, note or commit; collect records prs and issues
+func Kind(e data.Event) string {
+	return strings.TrimSuffix(e.Kind, "s")
+}
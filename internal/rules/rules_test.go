@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr(b bool) *bool { return &b }
+
+func TestExcluded(t *testing.T) {
+	set, err := New([]Rule{
+		{Name: "bots", Bot: ptr(true)},
+		{Repos: []string{"archived/*"}},
+		{Name: "forks", Fork: ptr(true)},
+		{Name: "chores", Title: `^(chore|bump)\b`, Kinds: []string{"pr"}},
+		{Name: "skip label", Labels: []string{"no-brag*"}},
+		{Name: "renovate reviews", Authors: []string{"renovate*"}, Actions: []string{"reviewed"}},
+	}, []Rule{
+		{Name: "keep my bot", Authors: []string{"acme-release[bot]"}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		event data.Event
+		want  string
+	}{
+		{"bot account", data.Event{Author: "dependabot", AuthorBot: true}, "bots"},
+		{"bot login suffix", data.Event{Author: "github-actions[bot]"}, "bots"},
+		{"included bot", data.Event{Author: "acme-release[bot]"}, ""},
+		{"repo glob, unnamed rule", data.Event{Repo: "Archived/old-api"}, "repos=archived/*"},
+		{"fork", data.Event{Repo: "me/cli", Fork: true}, "forks"},
+		{"title and kind", data.Event{Kind: "prs", Title: "chore: tidy"}, "chores"},
+		{"title on other kind", data.Event{Kind: "issue", Title: "chore: tidy"}, ""},
+		{"label glob", data.Event{Labels: []string{"bug", "no-brag-please"}}, "skip label"},
+		{"author and action", data.Event{Author: "renovate-bot", Action: data.EventActionReviewed}, "renovate reviews"},
+		{"author, other action", data.Event{Author: "renovate-bot", Action: data.EventActionMerged}, ""},
+		{"kept", data.Event{Repo: "acme/api", Author: "alice", Title: "feat: add login"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, set.Excluded(tt.event))
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	set, err := New([]Rule{{Name: "bots", Bot: ptr(true)}}, nil)
+	require.NoError(t, err)
+
+	events := []data.Event{{ID: "1", Author: "alice"}, {ID: "2", Author: "dependabot[bot]"}}
+	kept, excluded := set.Filter(events)
+	assert.Equal(t, []data.Event{events[0]}, kept)
+	assert.Equal(t, []Exclusion{{Event: events[1], Rule: "bots"}}, excluded)
+
+	var none *Set
+	kept, excluded = none.Filter(events)
+	assert.Equal(t, events, kept)
+	assert.Empty(t, excluded)
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr string
+	}{
+		{"no conditions", Rule{Name: "all"}, "exclude[0]: rule has no conditions and would match every event"},
+		{"bad glob", Rule{Repos: []string{"acme/["}}, `exclude[0]: invalid pattern "acme/["`},
+		{"bad title", Rule{Title: "("}, "exclude[0]: invalid title pattern: error parsing regexp: missing closing ): `(`"},
+		{"bad kind", Rule{Kinds: []string{"gist"}}, `exclude[0]: unknown kind "gist" (want one of pr, issue, note, commit)`},
+		{"bad action", Rule{Actions: []string{"commented"}}, `exclude[0]: unknown action "commented" (want one of merged, authored, reviewed, noted, committed)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New([]Rule{tt.rule}, nil)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRuleString(t *testing.T) {
+	r := Rule{Repos: []string{"a/*", "b/*"}, Bot: ptr(false), Title: "^wip", Actions: []string{"merged"}}
+	assert.Equal(t, "repos=a/*,b/* bot=false title=/^wip/ actions=merged", r.String())
+}
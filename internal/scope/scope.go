@@ -0,0 +1,76 @@
+// Package scope limits activity to chosen organizations and repositories.
+package scope
+
+import (
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// Scope selects activity by org and repository. With no include lists,
+// everything that isn't excluded is in scope.
+type Scope struct {
+	Orgs         []string // Include activity in these orgs
+	Repos        []string // Include activity in these repos (owner/name)
+	ExcludeOrgs  []string // Never include these orgs
+	ExcludeRepos []string // Never include these repos (owner/name)
+}
+
+// Queries returns the search queries covering the scope: base narrowed to each
+// included org and repo in turn (or base alone when none are), with the
+// exclusions appended to every query
+func (s Scope) Queries(base string) []string {
+	var exclude string
+	for _, org := range s.ExcludeOrgs {
+		exclude += " -org:" + org
+	}
+	for _, repo := range s.ExcludeRepos {
+		exclude += " -repo:" + repo
+	}
+
+	queries := make([]string, 0, len(s.Orgs)+len(s.Repos))
+	for _, org := range s.Orgs {
+		queries = append(queries, base+" org:"+org+exclude)
+	}
+	for _, repo := range s.Repos {
+		queries = append(queries, base+" repo:"+repo+exclude)
+	}
+	if len(queries) == 0 {
+		queries = append(queries, base+exclude)
+	}
+	return queries
+}
+
+// Allows reports whether activity in repo (owner/name) is in scope
+func (s Scope) Allows(repo string) bool {
+	org, _, _ := strings.Cut(repo, "/")
+	if containsFold(s.ExcludeOrgs, org) || containsFold(s.ExcludeRepos, repo) {
+		return false
+	}
+	if len(s.Orgs) == 0 && len(s.Repos) == 0 {
+		return true
+	}
+	return containsFold(s.Orgs, org) || containsFold(s.Repos, repo)
+}
+
+// Filter returns the events whose repository is in scope. Events without
+// a repository, such as notes, are always kept.
+func (s Scope) Filter(events []data.Event) []data.Event {
+	kept := events[:0:0]
+	for _, e := range events {
+		if e.Repo == "" || s.Allows(e.Repo) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// containsFold reports whether list contains s, ignoring case as GitHub does for names
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
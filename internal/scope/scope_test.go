@@ -0,0 +1,68 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueries(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope Scope
+		want  []string
+	}{
+		{
+			name: "empty",
+			want: []string{"is:pr"},
+		},
+		{
+			name:  "includes",
+			scope: Scope{Orgs: []string{"acme"}, Repos: []string{"cli/cli"}},
+			want:  []string{"is:pr org:acme", "is:pr repo:cli/cli"},
+		},
+		{
+			name:  "excludes on every query",
+			scope: Scope{Orgs: []string{"acme", "globex"}, ExcludeRepos: []string{"acme/secret"}, ExcludeOrgs: []string{"initech"}},
+			want: []string{
+				"is:pr org:acme -org:initech -repo:acme/secret",
+				"is:pr org:globex -org:initech -repo:acme/secret",
+			},
+		},
+		{
+			name:  "excludes only",
+			scope: Scope{ExcludeOrgs: []string{"acme"}},
+			want:  []string{"is:pr -org:acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.scope.Queries("is:pr"))
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	s := Scope{
+		Orgs:         []string{"Acme"},
+		Repos:        []string{"cli/cli"},
+		ExcludeRepos: []string{"acme/secret"},
+	}
+
+	assert.True(t, s.Allows("acme/api"))
+	assert.True(t, s.Allows("CLI/cli"))
+	assert.False(t, s.Allows("cli/go-gh"))
+	assert.False(t, s.Allows("acme/secret"))
+	assert.True(t, Scope{}.Allows("anyone/anything"))
+	assert.False(t, Scope{ExcludeOrgs: []string{"acme"}}.Allows("acme/api"))
+}
+
+func TestFilter(t *testing.T) {
+	events := []data.Event{{ID: "1", Repo: "acme/api"}, {ID: "2", Repo: "cli/cli"}, {ID: "3", Repo: "acme/web"}, {ID: "note:1"}}
+
+	got := Scope{Orgs: []string{"acme"}}.Filter(events)
+	assert.Equal(t, []data.Event{events[0], events[2], events[3]}, got)
+	assert.Len(t, events, 4, "input is left untouched")
+}
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/ticket"
+)
+
+// The API has its own response types rather than serializing analyze.Metrics,
+// whose themes carry a copy of every event.
+
+type filtersResponse struct {
+	From          string   `json:"from"` // First day with activity, YYYY-MM-DD
+	To            string   `json:"to"`   // Last day with activity
+	Themes        []string `json:"themes"`
+	Repos         []string `json:"repos"`
+	Collaborators []string `json:"collaborators"`
+}
+
+type metricsResponse struct {
+	Events         int         `json:"events"`
+	From           string      `json:"from"`
+	To             string      `json:"to"`
+	ImpactScore    float64     `json:"impactScore"`
+	ImpactTier     string      `json:"impactTier"`
+	Velocity       float64     `json:"velocity"` // Events per week
+	OwnershipCount int         `json:"ownershipCount"`
+	Weekly         []weekRow   `json:"weekly"`
+	Themes         []themeRow  `json:"themes"`
+	Repos          []repoRow   `json:"repos"`
+	Reviewers      []userRow   `json:"reviewers"` // People who reviewed my PRs
+	Reviewees      []userRow   `json:"reviewees"` // People whose PRs I reviewed
+	ByAction       []shareRow  `json:"byAction"`
+	ByMonth        []shareRow  `json:"byMonth"`
+	TopEvents      []impactRow `json:"topEvents"`
+	Tickets        []ticketRow `json:"tickets"`
+}
+
+type weekRow struct {
+	Week   string `json:"week"` // Monday the ISO week starts on
+	Events int    `json:"events"`
+}
+
+type themeRow struct {
+	Name    string  `json:"name"`
+	Events  int     `json:"events"`
+	Percent float64 `json:"percent"`
+	Impact  float64 `json:"impact"`
+}
+
+type repoRow struct {
+	Name     string  `json:"name"`
+	Merged   int     `json:"merged"`
+	Issues   int     `json:"issues"`
+	Reviewed int     `json:"reviewed"`
+	Commits  int     `json:"commits"`
+	Impact   float64 `json:"impact"`
+}
+
+type userRow struct {
+	Login string `json:"login"`
+	Count int    `json:"count"`
+}
+
+type shareRow struct {
+	Name    string  `json:"name"`
+	Score   float64 `json:"score"`
+	Percent float64 `json:"percent"`
+}
+
+type impactRow struct {
+	ID     string  `json:"id"`
+	Title  string  `json:"title"`
+	URL    string  `json:"url"`
+	Repo   string  `json:"repo"`
+	Action string  `json:"action"`
+	Theme  string  `json:"theme"`
+	Score  float64 `json:"score"`
+}
+
+type ticketRow struct {
+	Key    string  `json:"key"`
+	Title  string  `json:"title"`
+	URL    string  `json:"url"`
+	Score  float64 `json:"score"`
+	Events int     `json:"events"`
+}
+
+type eventsResponse struct {
+	Events []eventRow `json:"events"`
+}
+
+type eventRow struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Repo      string    `json:"repo"`
+	Number    int       `json:"number,omitempty"`
+	Action    string    `json:"action"`
+	Kind      string    `json:"kind"`
+	Author    string    `json:"author"`
+	Labels    []string  `json:"labels"`
+	Theme     string    `json:"theme"`
+	Impact    float64   `json:"impact"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// filters collects the themes, repositories, collaborators and date bounds of the activity
+func (s *Server) filters(events []data.Event) filtersResponse {
+	events, _ = ticket.Split(events)
+	themes := make(map[string]bool)
+	repos := make(map[string]bool)
+	people := make(map[string]bool)
+	var first, last time.Time
+	for _, e := range events {
+		themes[s.analyzer.Score(e).Theme] = true
+		if e.Repo != "" {
+			repos[e.Repo] = true
+		}
+		for _, login := range collaborators(e) {
+			people[login] = true
+		}
+		at := e.Timestamps.UpdatedAt
+		if first.IsZero() || at.Before(first) {
+			first = at
+		}
+		if at.After(last) {
+			last = at
+		}
+	}
+	return filtersResponse{
+		From:          formatDate(first),
+		To:            formatDate(last),
+		Themes:        sortedKeys(themes),
+		Repos:         sortedKeys(repos),
+		Collaborators: sortedKeys(people),
+	}
+}
+
+// metrics converts analyze's metrics to the API's response
+func (s *Server) metrics(m analyze.Metrics) metricsResponse {
+	resp := metricsResponse{
+		From:           formatDate(m.PeriodStart),
+		To:             formatDate(m.PeriodEnd),
+		ImpactScore:    m.ImpactScore,
+		ImpactTier:     m.ImpactTier,
+		Velocity:       m.Velocity,
+		OwnershipCount: m.OwnershipCount,
+		Weekly:         []weekRow{},
+		Themes:         []themeRow{},
+		Repos:          []repoRow{},
+		Reviewers:      users(m.Collaboration.Reviewers),
+		Reviewees:      users(m.Collaboration.Reviewees),
+		ByAction:       shareRows(m.Impact.ByAction),
+		ByMonth:        shareRows(m.Impact.ByMonth),
+		TopEvents:      []impactRow{},
+		Tickets:        []ticketRow{},
+	}
+	for _, p := range m.WeeklyTrend {
+		resp.Weekly = append(resp.Weekly, weekRow{Week: p.Date, Events: p.Count})
+	}
+
+	themeImpact := scores(m.Impact.ByTheme)
+	for _, t := range m.Theme {
+		resp.Events += t.Count
+		resp.Themes = append(resp.Themes, themeRow{
+			Name:    t.Name,
+			Events:  t.Count,
+			Percent: m.ContributionMix[t.Name],
+			Impact:  themeImpact[t.Name],
+		})
+	}
+	sort.SliceStable(resp.Themes, func(i, j int) bool {
+		if resp.Themes[i].Events != resp.Themes[j].Events {
+			return resp.Themes[i].Events > resp.Themes[j].Events
+		}
+		return resp.Themes[i].Name < resp.Themes[j].Name
+	})
+
+	repoImpact := scores(m.Impact.ByRepo)
+	for _, r := range m.RepoStats.Summary {
+		resp.Repos = append(resp.Repos, repoRow{
+			Name:     r.Name,
+			Merged:   r.Merged,
+			Issues:   r.Issues,
+			Reviewed: r.Reviewed,
+			Commits:  r.Commits,
+			Impact:   repoImpact[r.Name],
+		})
+	}
+
+	for _, e := range m.Impact.TopEvents {
+		resp.TopEvents = append(resp.TopEvents, impactRow{
+			ID:     e.ID,
+			Title:  e.Title,
+			URL:    e.URL,
+			Repo:   e.Repo,
+			Action: string(e.Action),
+			Theme:  e.Theme,
+			Score:  e.Score,
+		})
+	}
+	for _, t := range m.Tickets {
+		resp.Tickets = append(resp.Tickets, ticketRow{
+			Key:    t.Key,
+			Title:  t.Title,
+			URL:    t.URL,
+			Score:  t.Score,
+			Events: len(t.Events),
+		})
+	}
+	return resp
+}
+
+// eventRows returns the events with their theme and impact, most recently updated first
+func (s *Server) eventRows(events []data.Event) []eventRow {
+	rows := make([]eventRow, 0, len(events))
+	for _, e := range events {
+		score := s.analyzer.Score(e)
+		labels := e.Labels
+		if labels == nil {
+			labels = []string{}
+		}
+		rows = append(rows, eventRow{
+			ID:        e.ID,
+			Title:     e.Title,
+			URL:       e.URL,
+			Repo:      e.Repo,
+			Number:    e.Number,
+			Action:    string(e.Action),
+			Kind:      e.Kind,
+			Author:    e.Author,
+			Labels:    labels,
+			Theme:     score.Theme,
+			Impact:    score.Score,
+			UpdatedAt: e.Timestamps.UpdatedAt,
+		})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UpdatedAt.After(rows[j].UpdatedAt)
+	})
+	return rows
+}
+
+func users(stats []analyze.UserStat) []userRow {
+	rows := make([]userRow, 0, len(stats))
+	for _, u := range stats {
+		rows = append(rows, userRow{Login: u.Login, Count: u.Count})
+	}
+	// analyze orders by count only; break ties by login so the lists don't reshuffle
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Login < rows[j].Login
+	})
+	return rows
+}
+
+func shareRows(shares []analyze.ImpactShare) []shareRow {
+	rows := make([]shareRow, 0, len(shares))
+	for _, s := range shares {
+		rows = append(rows, shareRow{Name: s.Name, Score: s.Score, Percent: s.Percent})
+	}
+	return rows
+}
+
+func scores(shares []analyze.ImpactShare) map[string]float64 {
+	m := make(map[string]float64, len(shares))
+	for _, s := range shares {
+		m[s.Name] = s.Score
+	}
+	return m
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(time.Local).Format(dateLayout)
+}
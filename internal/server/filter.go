@@ -0,0 +1,102 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/jackchuka/gh-brag/internal/ticket"
+)
+
+const dateLayout = "2006-01-02"
+
+// filter selects events by the query parameters from and to (inclusive
+// YYYY-MM-DD days in local time, matched against UpdatedAt as in analyze's
+// trend), theme, repo and collaborator. Empty parameters match everything.
+type filter struct {
+	from, to     time.Time // to is exclusive: the day after the to parameter
+	theme        string
+	repo         string
+	collaborator string
+}
+
+func parseFilter(q url.Values) (filter, error) {
+	f := filter{
+		theme:        q.Get("theme"),
+		repo:         q.Get("repo"),
+		collaborator: q.Get("collaborator"),
+	}
+	var err error
+	if v := q.Get("from"); v != "" {
+		if f.from, err = time.ParseInLocation(dateLayout, v, time.Local); err != nil {
+			return filter{}, fmt.Errorf("invalid from date %q: want YYYY-MM-DD", v)
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		if f.to, err = time.ParseInLocation(dateLayout, v, time.Local); err != nil {
+			return filter{}, fmt.Errorf("invalid to date %q: want YYYY-MM-DD", v)
+		}
+		f.to = f.to.AddDate(0, 0, 1)
+	}
+	if !f.from.IsZero() && !f.to.IsZero() && !f.from.Before(f.to) {
+		return filter{}, fmt.Errorf("from date %s is after to date %s", q.Get("from"), q.Get("to"))
+	}
+	return f, nil
+}
+
+// apply returns the activity the filter matches, and the imported tickets.
+// Imported commits a collected PR already counts are left out, as in analyze.
+func (f filter) apply(a *analyze.Analyzer, events []data.Event) (matched, tickets []data.Event) {
+	events, tickets = ticket.Split(events)
+	events = analyze.DropCountedCommits(events)
+	for _, e := range events {
+		if f.match(a, e) {
+			matched = append(matched, e)
+		}
+	}
+	return matched, tickets
+}
+
+func (f filter) match(a *analyze.Analyzer, e data.Event) bool {
+	at := e.Timestamps.UpdatedAt
+	if !f.from.IsZero() && at.Before(f.from) {
+		return false
+	}
+	if !f.to.IsZero() && !at.Before(f.to) {
+		return false
+	}
+	if f.repo != "" && e.Repo != f.repo {
+		return false
+	}
+	if f.collaborator != "" && !slices.Contains(collaborators(e), f.collaborator) {
+		return false
+	}
+	if f.theme != "" && a.Score(e).Theme != f.theme {
+		return false
+	}
+	return true
+}
+
+// collaborators returns who an event was worked on with, as analyze's
+// collaboration stats count them: the author of a PR that was reviewed, and
+// the reviewers of a merged PR other than its author
+func collaborators(e data.Event) []string {
+	switch e.Action {
+	case data.EventActionReviewed:
+		if e.Author != "" && (e.Subject == "" || e.Author != e.Subject) {
+			return []string{e.Author}
+		}
+	case data.EventActionMerged:
+		var logins []string
+		for _, r := range e.Reviewers {
+			if r != e.Author {
+				logins = append(logins, r)
+			}
+		}
+		return logins
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := parseFilter(url.Values{"from": {"2026-03-01"}, "to": {"2026-03-31"}, "repo": {"acme/api"}})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), f.from)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local), f.to, "to is inclusive")
+	assert.Equal(t, "acme/api", f.repo)
+
+	_, err = parseFilter(url.Values{"from": {"2026-03-01"}, "to": {"2026-03-01"}})
+	require.NoError(t, err, "a single day")
+
+	_, err = parseFilter(url.Values{"to": {"03/01/2026"}})
+	assert.ErrorContains(t, err, "invalid to date")
+	_, err = parseFilter(url.Values{"from": {"2026-03-02"}, "to": {"2026-03-01"}})
+	assert.ErrorContains(t, err, "is after")
+}
+
+func TestCollaborators(t *testing.T) {
+	tests := []struct {
+		name  string
+		event data.Event
+		want  []string
+	}{
+		{"Reviewed PR's author", data.Event{Action: data.EventActionReviewed, Author: "carol"}, []string{"carol"}},
+		{"Own PR reviewed as subject", data.Event{Action: data.EventActionReviewed, Author: "me", Subject: "me"}, nil},
+		{"Merged PR's reviewers but the author", data.Event{Action: data.EventActionMerged, Author: "me", Reviewers: []string{"bob", "me", "dave"}}, []string{"bob", "dave"}},
+		{"Authored issue", data.Event{Action: data.EventActionAuthored, Author: "me"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, collaborators(tt.event))
+		})
+	}
+}
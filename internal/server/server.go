@@ -0,0 +1,100 @@
+// Package server serves the browser dashboard and the JSON API it reads.
+package server
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+//go:embed static
+var static embed.FS
+
+// LoadFunc returns the events to serve. It is called on every API request,
+// so events collected while the server runs show up on reload.
+type LoadFunc func() ([]data.Event, error)
+
+// Server is the dashboard's HTTP handler
+type Server struct {
+	analyzer *analyze.Analyzer
+	load     LoadFunc
+	mux      *http.ServeMux
+}
+
+// New creates a dashboard server over the events load returns
+func New(a *analyze.Analyzer, load LoadFunc) *Server {
+	s := &Server{analyzer: a, load: load, mux: http.NewServeMux()}
+
+	assets, _ := fs.Sub(static, "static")
+	s.mux.Handle("GET /", http.FileServerFS(assets))
+	s.mux.HandleFunc("GET /api/filters", s.handleFilters)
+	s.mux.HandleFunc("GET /api/metrics", s.handleMetrics)
+	s.mux.HandleFunc("GET /api/events", s.handleEvents)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleFilters lists the values the dashboard's filters can take
+func (s *Server) handleFilters(w http.ResponseWriter, r *http.Request) {
+	events, err := s.load()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, s.filters(events))
+}
+
+// handleMetrics analyzes the events the query selects
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	events, tickets, ok := s.query(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, s.metrics(s.analyzer.Analyze(append(events, tickets...))))
+}
+
+// handleEvents lists the events the query selects, most recently updated first
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	events, _, ok := s.query(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, eventsResponse{Events: s.eventRows(events)})
+}
+
+// query loads the events and applies the request's filters to the activity.
+// Imported tickets are returned apart and unfiltered, since they only group
+// the events that mention them. On failure it writes the error and returns false.
+func (s *Server) query(w http.ResponseWriter, r *http.Request) (events, tickets []data.Event, ok bool) {
+	f, err := parseFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, nil, false
+	}
+	all, err := s.load()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return nil, nil, false
+	}
+	events, tickets = f.apply(s.analyzer, all)
+	return events, tickets, true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
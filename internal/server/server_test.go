@@ -0,0 +1,145 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-brag/internal/analyze"
+	"github.com/jackchuka/gh-brag/internal/config"
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testServer(t *testing.T, load LoadFunc) *Server {
+	t.Helper()
+	a, err := analyze.New(&config.Config{
+		Themes: []config.Theme{{Name: "Feature", Keywords: []string{"feat"}}},
+		Metrics: config.Metrics{
+			ActionWeights: map[data.EventAction]float64{data.EventActionMerged: 10, data.EventActionReviewed: 3},
+			ThemeWeights:  map[string]float64{"Feature": 2},
+		},
+	})
+	require.NoError(t, err)
+	return New(a, load)
+}
+
+func testEvents() []data.Event {
+	at := time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local)
+	return []data.Event{
+		{ID: "pr:1:merged", Action: data.EventActionMerged, Kind: "pr", Repo: "acme/api", Number: 1, Title: "feat: cache ABC-1", URL: "https://github.com/acme/api/pull/1", Author: "me", Reviewers: []string{"bob", "me"}, Timestamps: data.Timestamps{UpdatedAt: at}},
+		{ID: "pr:2:reviewed", Action: data.EventActionReviewed, Kind: "pr", Repo: "acme/web", Number: 2, Title: "Fix layout", Author: "carol", Timestamps: data.Timestamps{UpdatedAt: at.AddDate(0, 0, 7)}},
+		{ID: "pr:3:merged", Action: data.EventActionMerged, Kind: "pr", Repo: "acme/web", Number: 3, Title: "feat: dark mode", Author: "me", Timestamps: data.Timestamps{UpdatedAt: at.AddDate(0, 1, 0)}},
+		{ID: "jira:ABC-1", Kind: "issue", Key: "ABC-1", Title: "Speed up", URL: "https://acme.atlassian.net/browse/ABC-1"},
+	}
+}
+
+func get(t *testing.T, s *Server, target string, v any) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if v != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), v), rec.Body.String())
+	}
+	return rec.Code
+}
+
+func TestFilters(t *testing.T) {
+	s := testServer(t, func() ([]data.Event, error) { return testEvents(), nil })
+
+	var got filtersResponse
+	require.Equal(t, http.StatusOK, get(t, s, "/api/filters", &got))
+	assert.Equal(t, filtersResponse{
+		From:          "2026-03-04",
+		To:            "2026-04-04",
+		Themes:        []string{"Feature", "Other"},
+		Repos:         []string{"acme/api", "acme/web"},
+		Collaborators: []string{"bob", "carol"},
+	}, got, "tickets are not activity")
+}
+
+func TestMetrics(t *testing.T) {
+	s := testServer(t, func() ([]data.Event, error) { return testEvents(), nil })
+
+	var all metricsResponse
+	require.Equal(t, http.StatusOK, get(t, s, "/api/metrics", &all))
+	assert.Equal(t, 3, all.Events)
+	assert.Equal(t, 43.0, all.ImpactScore)
+	total := float64(all.Events)
+	assert.Equal(t, []themeRow{
+		{Name: "Feature", Events: 2, Percent: 2 / total * 100, Impact: 40},
+		{Name: "Other", Events: 1, Percent: 1 / total * 100, Impact: 3},
+	}, all.Themes)
+	assert.Equal(t, []userRow{{Login: "bob", Count: 1}}, all.Reviewers)
+	assert.Equal(t, []userRow{{Login: "carol", Count: 1}}, all.Reviewees)
+	require.Len(t, all.Tickets, 1)
+	assert.Equal(t, ticketRow{Key: "ABC-1", Title: "Speed up", URL: "https://acme.atlassian.net/browse/ABC-1", Score: 20, Events: 1}, all.Tickets[0])
+
+	var web metricsResponse
+	require.Equal(t, http.StatusOK, get(t, s, "/api/metrics?repo=acme/web&from=2026-03-11&to=2026-03-11", &web))
+	assert.Equal(t, 1, web.Events)
+	assert.Equal(t, "2026-03-11", web.From)
+	assert.Equal(t, []weekRow{{Week: "2026-03-09", Events: 1}}, web.Weekly)
+	assert.Empty(t, web.Tickets, "no matching event mentions the ticket")
+
+	var empty metricsResponse
+	require.Equal(t, http.StatusOK, get(t, s, "/api/metrics?theme=Nope", &empty))
+	assert.Zero(t, empty.Events)
+	assert.NotNil(t, empty.Themes, "lists are never null")
+}
+
+func TestEvents(t *testing.T) {
+	s := testServer(t, func() ([]data.Event, error) { return testEvents(), nil })
+
+	var got eventsResponse
+	require.Equal(t, http.StatusOK, get(t, s, "/api/events?theme=Feature", &got))
+	require.Len(t, got.Events, 2)
+	assert.Equal(t, "pr:3:merged", got.Events[0].ID, "most recent first")
+	assert.Equal(t, "pr:1:merged", got.Events[1].ID)
+	assert.Equal(t, 20.0, got.Events[1].Impact)
+	assert.Equal(t, "Feature", got.Events[1].Theme)
+	assert.Equal(t, []string{}, got.Events[1].Labels)
+
+	require.Equal(t, http.StatusOK, get(t, s, "/api/events?collaborator=carol", &got))
+	require.Len(t, got.Events, 1)
+	assert.Equal(t, "pr:2:reviewed", got.Events[0].ID)
+
+	require.Equal(t, http.StatusOK, get(t, s, "/api/events?collaborator=me", &got))
+	assert.Empty(t, got.Events, "self-reviews are not collaboration")
+}
+
+func TestErrors(t *testing.T) {
+	s := testServer(t, func() ([]data.Event, error) { return testEvents(), nil })
+
+	var body map[string]string
+	assert.Equal(t, http.StatusBadRequest, get(t, s, "/api/events?from=March", &body))
+	assert.Contains(t, body["error"], "invalid from date")
+	assert.Equal(t, http.StatusBadRequest, get(t, s, "/api/metrics?from=2026-03-05&to=2026-03-04", &body))
+	assert.Contains(t, body["error"], "is after")
+
+	failing := testServer(t, func() ([]data.Event, error) { return nil, errors.New("disk on fire") })
+	assert.Equal(t, http.StatusInternalServerError, get(t, failing, "/api/filters", &body))
+	assert.Equal(t, "disk on fire", body["error"])
+}
+
+func TestStatic(t *testing.T) {
+	s := testServer(t, func() ([]data.Event, error) { return nil, nil })
+
+	for _, path := range []string{"/", "/app.js", "/style.css"} {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.NotEmpty(t, rec.Body.String(), path)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/events", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	var empty metricsResponse
+	require.Equal(t, http.StatusOK, get(t, s, "/api/metrics", &empty), "an empty store still has a dashboard")
+}
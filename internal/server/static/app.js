@@ -0,0 +1,192 @@
+// gh brag dashboard: reads the JSON API and redraws on every filter change.
+// The filters live in the page's query string, so a view can be shared by URL.
+"use strict";
+
+const FILTERS = ["from", "to", "theme", "repo", "collaborator"];
+const form = document.getElementById("filters");
+let state = Object.fromEntries(FILTERS.map((k) => [k, new URLSearchParams(location.search).get(k) || ""]));
+
+// el creates an element with attributes and children; strings become text nodes
+function el(tag, attrs, ...children) {
+  const node = tag === "svg" || tag === "rect" || tag === "text" || tag === "title" || tag === "line"
+    ? document.createElementNS("http://www.w3.org/2000/svg", tag)
+    : document.createElement(tag);
+  for (const [k, v] of Object.entries(attrs || {})) {
+    if (k.startsWith("on")) node.addEventListener(k.slice(2), v);
+    else if (v !== undefined && v !== null && v !== false) node.setAttribute(k, v);
+  }
+  for (const c of children.flat()) {
+    if (c !== undefined && c !== null) node.append(c);
+  }
+  return node;
+}
+
+function query() {
+  const q = new URLSearchParams();
+  for (const k of FILTERS) if (state[k]) q.set(k, state[k]);
+  return q.toString();
+}
+
+async function getJSON(path) {
+  const res = await fetch(path);
+  const body = await res.json();
+  if (!res.ok) throw new Error(body.error || res.statusText);
+  return body;
+}
+
+function fmt(n) {
+  return Number.isInteger(n) ? String(n) : n.toFixed(1);
+}
+
+// setFilter changes filters and redraws; choosing the active value again clears it
+function setFilter(changes) {
+  for (const [k, v] of Object.entries(changes)) state[k] = state[k] === v && !["from", "to"].includes(k) ? "" : v;
+  refresh();
+}
+
+function fillSelect(name, values) {
+  const select = form.elements[name];
+  select.append(...values.map((v) => el("option", { value: v }, v)));
+}
+
+function syncForm() {
+  for (const k of FILTERS) form.elements[k].value = state[k];
+}
+
+// bars draws a horizontal bar list; rows are {label, value, text, filter}
+function bars(id, rows, active) {
+  const box = document.getElementById(id);
+  const max = Math.max(1, ...rows.map((r) => r.value));
+  box.replaceChildren(...rows.map((r) => {
+    const clickable = r.filter !== undefined;
+    return el("div", {
+      class: "bar" + (clickable ? " clickable" : "") + (active && r.label === active ? " active" : ""),
+      title: clickable ? "Filter by " + r.label : undefined,
+      onclick: clickable ? () => setFilter(r.filter) : undefined,
+    },
+      el("span", { class: "bar-label" }, r.label),
+      el("span", { class: "bar-track" }, el("span", { class: "bar-fill", style: `width:${(100 * r.value) / max}%` })),
+      el("span", { class: "bar-value" }, r.text ?? fmt(r.value)));
+  }));
+  if (rows.length === 0) box.append(el("p", { class: "empty" }, "Nothing here."));
+}
+
+function addDays(date, days) {
+  const d = new Date(date + "T00:00:00");
+  d.setDate(d.getDate() + days);
+  return [d.getFullYear(), String(d.getMonth() + 1).padStart(2, "0"), String(d.getDate()).padStart(2, "0")].join("-");
+}
+
+// monthEnd returns the last day of a YYYY-MM month
+function monthEnd(month) {
+  const [y, m] = month.split("-").map(Number);
+  return `${month}-${String(new Date(y, m, 0).getDate()).padStart(2, "0")}`;
+}
+
+// weekly draws the events per week as an SVG column chart
+function weekly(points) {
+  const svg = document.getElementById("weekly");
+  const width = 900, height = 160, pad = 20;
+  svg.setAttribute("viewBox", `0 0 ${width} ${height + pad}`);
+  const max = Math.max(1, ...points.map((p) => p.events));
+  const step = points.length ? width / points.length : width;
+  svg.replaceChildren(
+    el("line", { x1: 0, y1: height, x2: width, y2: height, class: "axis" }),
+    ...points.map((p, i) => {
+      const h = (height - 4) * (p.events / max);
+      return el("rect", {
+        x: i * step + 1, y: height - h, width: Math.max(1, step - 2), height: h,
+        class: "column",
+        onclick: () => setFilter({ from: p.week, to: addDays(p.week, 6) }),
+      }, el("title", {}, `Week of ${p.week}: ${p.events} events`));
+    }),
+    ...points
+      .filter((_, i) => i % Math.ceil(points.length / 8) === 0)
+      .map((p) => el("text", { x: points.indexOf(p) * step + 2, y: height + 14 }, p.week)),
+  );
+}
+
+function link(url, text) {
+  return url ? el("a", { href: url, target: "_blank", rel: "noopener" }, text) : text;
+}
+
+function renderMetrics(m) {
+  document.getElementById("events-count").textContent = m.events;
+  document.getElementById("impact-score").textContent = fmt(m.impactScore);
+  document.getElementById("impact-tier").textContent = m.impactTier ? `Impact · ${m.impactTier}` : "Impact";
+  document.getElementById("velocity").textContent = fmt(m.velocity);
+  document.getElementById("ownership").textContent = m.ownershipCount;
+
+  weekly(m.weekly);
+  bars("themes", m.themes.map((t) => ({
+    label: t.name, value: t.events, text: `${t.events} · ${fmt(t.percent)}%`, filter: { theme: t.name },
+  })), state.theme);
+  bars("repos", m.repos.map((r) => ({
+    label: r.name, value: r.impact, text: `${r.merged + r.issues + r.reviewed + r.commits} · ${fmt(r.impact)}`, filter: { repo: r.name },
+  })), state.repo);
+  bars("months", m.byMonth.map((s) => ({
+    label: s.name, value: s.score, filter: { from: s.name + "-01", to: monthEnd(s.name) },
+  })));
+  bars("actions", m.byAction.map((s) => ({ label: s.name, value: s.score, text: `${fmt(s.score)} · ${fmt(s.percent)}%` })));
+  bars("reviewers", m.reviewers.map((u) => ({ label: u.login, value: u.count, filter: { collaborator: u.login } })), state.collaborator);
+  bars("reviewees", m.reviewees.map((u) => ({ label: u.login, value: u.count, filter: { collaborator: u.login } })), state.collaborator);
+
+  document.getElementById("top-events").replaceChildren(...m.topEvents.map((e) =>
+    el("li", {}, link(e.url, e.title), el("span", { class: "meta" }, ` ${e.repo} · ${e.action} · ${e.theme} · ${fmt(e.score)}`))));
+  document.getElementById("tickets-section").hidden = m.tickets.length === 0;
+  document.getElementById("tickets").replaceChildren(...m.tickets.map((t) =>
+    el("li", {}, link(t.url, `${t.key} ${t.title}`), el("span", { class: "meta" }, ` ${t.events} linked · ${fmt(t.score)}`))));
+}
+
+function renderEvents(events) {
+  document.getElementById("events-total").textContent = `${events.length} shown`;
+  document.querySelector("#events tbody").replaceChildren(...events.map((e) => el("tr", {},
+    el("td", {}, e.updatedAt.slice(0, 10)),
+    el("td", {}, e.action),
+    el("td", {}, link(e.url, e.number ? `#${e.number} ${e.title}` : e.title)),
+    el("td", {}, e.repo ? el("a", { href: "#", onclick: (ev) => { ev.preventDefault(); setFilter({ repo: e.repo }); } }, e.repo) : ""),
+    el("td", {}, el("a", { href: "#", onclick: (ev) => { ev.preventDefault(); setFilter({ theme: e.theme }); } }, e.theme)),
+    el("td", { class: "num" }, fmt(e.impact)),
+  )));
+}
+
+async function refresh() {
+  syncForm();
+  const q = query();
+  history.replaceState(null, "", q ? "?" + q : location.pathname);
+  const error = document.getElementById("error");
+  try {
+    const [metrics, events] = await Promise.all([getJSON("api/metrics?" + q), getJSON("api/events?" + q)]);
+    error.hidden = true;
+    renderMetrics(metrics);
+    renderEvents(events.events);
+  } catch (err) {
+    error.textContent = err.message;
+    error.hidden = false;
+  }
+}
+
+form.addEventListener("change", (ev) => {
+  state[ev.target.name] = ev.target.value;
+  refresh();
+});
+form.addEventListener("reset", (ev) => {
+  ev.preventDefault();
+  state = Object.fromEntries(FILTERS.map((k) => [k, ""]));
+  refresh();
+});
+
+getJSON("api/filters").then((f) => {
+  fillSelect("theme", f.themes);
+  fillSelect("repo", f.repos);
+  fillSelect("collaborator", f.collaborators);
+  for (const k of ["from", "to"]) {
+    form.elements[k].min = f.from;
+    form.elements[k].max = f.to;
+  }
+  refresh();
+}).catch((err) => {
+  const error = document.getElementById("error");
+  error.textContent = err.message;
+  error.hidden = false;
+});
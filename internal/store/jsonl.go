@@ -52,3 +52,49 @@ func AppendEvents(filepath string, events []data.Event) error {
 	}
 	return nil
 }
+
+// LoadEvents reads all events from the JSONL file. Malformed lines are skipped.
+func LoadEvents(filepath string) ([]data.Event, error) {
+	f, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var events []data.Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var evt data.Event
+		if err := json.Unmarshal(scanner.Bytes(), &evt); err == nil {
+			events = append(events, evt)
+		}
+	}
+	return events, scanner.Err()
+}
+
+// WriteEvents replaces the JSONL file with the given events.
+// The file is written to a temporary path first and renamed into place.
+func WriteEvents(filepath string, events []data.Event) error {
+	tmp := filepath + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(f)
+	for _, evt := range events {
+		if err := encoder.Encode(evt); err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmp)
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, filepath)
+}
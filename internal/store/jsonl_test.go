@@ -80,4 +80,35 @@ func TestJSONL(t *testing.T) {
 			t.Errorf("expected 2 IDs after appends, got %d", len(ids))
 		}
 	})
+
+	t.Run("Write and LoadEvents", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(tmpDir, "rewrite.jsonl")
+
+		if err := AppendEvents(path, []data.Event{{ID: "1"}, {ID: "2"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		events, err := LoadEvents(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(events))
+		}
+
+		events[0].Title = "Updated"
+		if err := WriteEvents(path, events[:1]); err != nil {
+			t.Fatal(err)
+		}
+
+		events, err = LoadEvents(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 1 || events[0].Title != "Updated" {
+			t.Errorf("expected single updated event, got %v", events)
+		}
+	})
 }
@@ -0,0 +1,77 @@
+package team
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/github"
+	"gopkg.in/yaml.v3"
+)
+
+// Team lists the people whose activity is collected and analyzed together.
+type Team struct {
+	Name       string   `yaml:"name"`
+	Members    []string `yaml:"members"`
+	GitHubTeam string   `yaml:"github_team"` // Optional org/team-slug expanded via the GitHub API
+}
+
+// Load reads a team definition from a YAML file.
+func Load(path string) (*Team, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("team file %s does not exist", path)
+		}
+		return nil, err
+	}
+
+	var t Team
+	if err := yaml.Unmarshal(raw, &t); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal team file: %w", err)
+	}
+	if len(t.Members) == 0 && t.GitHubTeam == "" {
+		return nil, errors.New("team file must list members or a github_team")
+	}
+	return &t, nil
+}
+
+// Resolve returns the sorted, deduplicated member logins,
+// expanding GitHubTeam through the GitHub API if set.
+func (t *Team) Resolve() ([]string, error) {
+	logins := append([]string(nil), t.Members...)
+
+	if t.GitHubTeam != "" {
+		org, slug, ok := strings.Cut(t.GitHubTeam, "/")
+		if !ok || org == "" || slug == "" {
+			return nil, fmt.Errorf("invalid github_team %q: expected org/team-slug", t.GitHubTeam)
+		}
+		members, err := github.GetTeamMembers(org, slug)
+		if err != nil {
+			return nil, err
+		}
+		logins = append(logins, members...)
+	}
+
+	return normalize(logins), nil
+}
+
+// normalize trims, deduplicates (case-insensitively) and sorts logins
+func normalize(logins []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, l := range logins {
+		l = strings.TrimPrefix(strings.TrimSpace(l), "@")
+		if l == "" || seen[strings.ToLower(l)] {
+			continue
+		}
+		seen[strings.ToLower(l)] = true
+		out = append(out, l)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return strings.ToLower(out[i]) < strings.ToLower(out[j])
+	})
+	return out
+}
@@ -0,0 +1,44 @@
+package team
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "team.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("name: platform\nmembers: [bob, \"@alice\", Bob, \" carol \"]\n"), 0644))
+
+	team, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "platform", team.Name)
+
+	members, err := team.Resolve()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, members)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "does not exist")
+
+	empty := filepath.Join(dir, "empty.yaml")
+	require.NoError(t, os.WriteFile(empty, []byte("name: nobody\n"), 0644))
+	_, err = Load(empty)
+	assert.ErrorContains(t, err, "must list members or a github_team")
+
+	bad := filepath.Join(dir, "bad.yaml")
+	require.NoError(t, os.WriteFile(bad, []byte("github_team: no-slash\n"), 0644))
+	team, err := Load(bad)
+	require.NoError(t, err)
+	_, err = team.Resolve()
+	assert.ErrorContains(t, err, "expected org/team-slug")
+}
@@ -0,0 +1,89 @@
+// Package ticket links events to Jira and Linear tickets imported into the
+// store, by ticket keys such as ABC-123 found in titles, branch names or bodies.
+package ticket
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+)
+
+// keyPattern matches ticket key candidates. Branch names are often lower case
+// (abc-123-fix-login), so case is ignored and keys are upper-cased.
+var keyPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])([a-z][a-z0-9]*-[0-9]+)`)
+
+// Is reports whether e is an imported ticket rather than activity
+func Is(e data.Event) bool {
+	return e.Key != ""
+}
+
+// Keys returns the ticket key candidates in text, upper-cased, in order of
+// first appearance and without duplicates
+func Keys(text string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, m := range keyPattern.FindAllStringSubmatch(text, -1) {
+		key := strings.ToUpper(m[1])
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Split separates imported tickets from activity events, keeping their order
+func Split(events []data.Event) (activity, tickets []data.Event) {
+	for _, e := range events {
+		if Is(e) {
+			tickets = append(tickets, e)
+		} else {
+			activity = append(activity, e)
+		}
+	}
+	return activity, tickets
+}
+
+// Index looks up imported tickets by key
+type Index map[string]data.Event
+
+// NewIndex indexes the tickets among events; a later ticket with the same key wins
+func NewIndex(events []data.Event) Index {
+	idx := make(Index)
+	for _, e := range events {
+		if Is(e) {
+			idx[strings.ToUpper(e.Key)] = e
+		}
+	}
+	return idx
+}
+
+// Linked returns the indexed tickets whose keys appear in the event's title,
+// branch or body, in that order. Only known keys count, so strings like
+// UTF-8 never link. Tickets themselves link to nothing.
+func (idx Index) Linked(e data.Event) []data.Event {
+	if len(idx) == 0 || Is(e) {
+		return nil
+	}
+	var linked []data.Event
+	seen := make(map[string]bool)
+	for _, text := range []string{e.Title, e.Branch, e.Body} {
+		for _, key := range Keys(text) {
+			if t, ok := idx[key]; ok && !seen[key] {
+				seen[key] = true
+				linked = append(linked, t)
+			}
+		}
+	}
+	return linked
+}
+
+// Parent returns the indexed parent of a ticket, if it was imported too
+func (idx Index) Parent(t data.Event) (data.Event, bool) {
+	if t.Parent == "" {
+		return data.Event{}, false
+	}
+	p, ok := idx[strings.ToUpper(t.Parent)]
+	return p, ok
+}
@@ -0,0 +1,57 @@
+package ticket
+
+import (
+	"testing"
+
+	"github.com/jackchuka/gh-brag/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeys(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"ABC-123: fix login", []string{"ABC-123"}},
+		{"feature/abc-123-fix-login", []string{"ABC-123"}},
+		{"Fixes ENG-7 and ENG-7, see OPS2-40", []string{"ENG-7", "OPS2-40"}},
+		{"fix_abc-9", []string{"ABC-9"}},
+		{"1ABC-123 starts with a digit", nil},
+		{"no keys here", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, Keys(tt.text))
+		})
+	}
+}
+
+func TestIndexLinked(t *testing.T) {
+	epic := data.Event{ID: "jira:ABC-1", Kind: "issue", Key: "ABC-1", Title: "Checkout revamp"}
+	login := data.Event{ID: "jira:ABC-123", Kind: "issue", Key: "ABC-123", Title: "Fix login", Parent: "ABC-1"}
+	other := data.Event{ID: "linear:ENG-7", Kind: "issue", Key: "ENG-7", Title: "Rate limits"}
+	idx := NewIndex([]data.Event{{ID: "pr:1"}, epic, login, other})
+	assert.Len(t, idx, 3)
+
+	pr := data.Event{ID: "pr:2", Title: "Handle UTF-8 names", Branch: "abc-123-login", Body: "Also touches ENG-7 and ABC-123."}
+	linked := idx.Linked(pr)
+	if assert.Len(t, linked, 2) {
+		assert.Equal(t, "ABC-123", linked[0].Key)
+		assert.Equal(t, "ENG-7", linked[1].Key)
+	}
+
+	assert.Empty(t, idx.Linked(data.Event{Title: "chore: bump deps"}))
+	assert.Empty(t, idx.Linked(login), "tickets do not link to each other")
+
+	parent, ok := idx.Parent(login)
+	assert.True(t, ok)
+	assert.Equal(t, "Checkout revamp", parent.Title)
+	_, ok = idx.Parent(other)
+	assert.False(t, ok)
+}
+
+func TestSplit(t *testing.T) {
+	activity, tickets := Split([]data.Event{{ID: "pr:1"}, {ID: "jira:A-1", Key: "A-1"}, {ID: "note:1"}})
+	assert.Len(t, activity, 2)
+	assert.Len(t, tickets, 1)
+}
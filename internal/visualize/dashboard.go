@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/jackchuka/gh-brag/internal/analyze"
 )
 
@@ -39,10 +40,13 @@ func (d *dashboard) Render() {
 	right := d.renderRepoPulse()
 	fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top, left, right))
 
-	// 4. Activity Pulse (Heatmap)
+	// 4. Impact Breakdown
+	d.renderImpactBreakdown()
+
+	// 5. Activity Pulse (Heatmap)
 	d.renderHeatmap()
 
-	// 5. Collaboration Network
+	// 6. Collaboration Network
 	d.renderCollabNetwork()
 }
 
@@ -74,11 +78,9 @@ func (d *dashboard) generateSummary() string {
 		topRepo = d.metrics.RepoStats.Summary[0].Name
 	}
 
-	impactLevel := "Active"
-	if d.metrics.ImpactScore > 500 {
-		impactLevel = "Powerhouse"
-	} else if d.metrics.ImpactScore > 200 {
-		impactLevel = "Driver"
+	impactLevel := d.metrics.ImpactTier
+	if impactLevel == "" {
+		impactLevel = "Active"
 	}
 
 	return fmt.Sprintf("You've been a %s %s this period, with significant impact in %s.",
@@ -98,7 +100,7 @@ func (d *dashboard) renderKPIs() {
 
 	impact := cardStyle.Render(fmt.Sprintf("IMPACT\n%s\n%s",
 		lipgloss.NewStyle().Bold(true).Foreground(alertColor).Render(fmt.Sprintf("%.1f", d.metrics.ImpactScore)),
-		lipgloss.NewStyle().Faint(true).Render("⚡ High Power")))
+		lipgloss.NewStyle().Faint(true).Render("⚡ "+d.metrics.ImpactTier)))
 
 	velocity := cardStyle.Render(fmt.Sprintf("VELOCITY\n%s\n%s",
 		lipgloss.NewStyle().Bold(true).Foreground(successColor).Render(fmt.Sprintf("%.1f", d.metrics.Velocity)),
@@ -110,6 +112,44 @@ func (d *dashboard) renderKPIs() {
 
 	fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top, impact, velocity, ownership))
 	fmt.Println()
+
+	// Custom metric cards, three per row
+	var cards []string
+	for _, m := range d.metrics.Custom {
+		if !m.Dashboard {
+			continue
+		}
+		value := lipgloss.NewStyle().Bold(true).Foreground(successColor).Render(formatValue(m.Value))
+		if m.Error != "" {
+			value = lipgloss.NewStyle().Bold(true).Foreground(alertColor).Render("error")
+		}
+		cards = append(cards, cardStyle.Render(fmt.Sprintf("%s\n%s\n%s",
+			truncate(strings.ToUpper(m.Name), 20), value, lipgloss.NewStyle().Faint(true).Render(m.Unit))))
+	}
+	for i := 0; i < len(cards); i += 3 {
+		fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top, cards[i:min(i+3, len(cards))]...))
+		fmt.Println()
+	}
+}
+
+// formatValue prints whole numbers without decimals
+func formatValue(v float64) string {
+	if v == float64(int64(v)) {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// truncate shortens s to at most width terminal cells, ending with "..." when cut.
+// Wide characters such as CJK and emoji take two cells, and are never split.
+func truncate(s string, width int) string {
+	return ansi.Truncate(s, width, "...")
+}
+
+// fit truncates s to width cells and pads it with spaces to exactly width, for aligned columns
+func fit(s string, width int) string {
+	s = truncate(s, width)
+	return s + strings.Repeat(" ", max(0, width-ansi.StringWidth(s)))
 }
 
 func (d *dashboard) renderThemeDist() string {
@@ -136,12 +176,7 @@ func (d *dashboard) renderThemeDist() string {
 		bar := lipgloss.NewStyle().Foreground(successColor).Render(strings.Repeat("█", filled))
 		empty := lipgloss.NewStyle().Foreground(neutralColor).Render(strings.Repeat("░", barWidth-filled))
 
-		name := t.Name
-		if len(name) > 12 {
-			name = name[:9] + "..."
-		}
-
-		content.WriteString(fmt.Sprintf("%-12s %s%s %d\n", name, bar, empty, t.Count))
+		content.WriteString(fmt.Sprintf("%s %s%s %d\n", fit(t.Name, 12), bar, empty, t.Count))
 	}
 
 	return lipgloss.NewStyle().Padding(1, 2).Width(42).Render(content.String())
@@ -171,16 +206,54 @@ func (d *dashboard) renderRepoPulse() string {
 		pulse := lipgloss.NewStyle().Foreground(alertColor).Render(strings.Repeat("█", filled)) +
 			lipgloss.NewStyle().Foreground(neutralColor).Render(strings.Repeat("▒", pulseWidth-filled))
 
-		name := r.Name
-		if len(name) > 18 {
-			name = name[:15] + "..."
-		}
-		content.WriteString(fmt.Sprintf("%-18s %s %2d\n", name, pulse, r.Merged))
+		content.WriteString(fmt.Sprintf("%s %s %2d\n", fit(r.Name, 18), pulse, r.Merged))
 	}
 
 	return lipgloss.NewStyle().Padding(1, 1).Width(40).Render(content.String())
 }
 
+func (d *dashboard) renderImpactBreakdown() {
+	title := lipgloss.NewStyle().Bold(true).Foreground(primaryColor).Render("IMPACT BREAKDOWN")
+	fmt.Println(title)
+
+	var left strings.Builder
+	left.WriteString(lipgloss.NewStyle().Underline(true).Render("By Action") + "\n")
+	for _, s := range d.metrics.Impact.ByAction {
+		left.WriteString(d.renderShare(s))
+	}
+	left.WriteString("\n" + lipgloss.NewStyle().Underline(true).Render("By Theme") + "\n")
+	for i, s := range d.metrics.Impact.ByTheme {
+		if i >= 5 {
+			break
+		}
+		left.WriteString(d.renderShare(s))
+	}
+
+	var right strings.Builder
+	right.WriteString(lipgloss.NewStyle().Underline(true).Render("Top Contributions") + "\n")
+	for i, e := range d.metrics.Impact.TopEvents {
+		if i >= 8 {
+			break
+		}
+		score := lipgloss.NewStyle().Foreground(alertColor).Render(fmt.Sprintf("%5.1f", e.Score))
+		right.WriteString(fmt.Sprintf(" %s %s\n", score, fit(e.Title, 26)))
+	}
+
+	leftCol := lipgloss.NewStyle().Width(38).Render(left.String())
+	rightCol := lipgloss.NewStyle().Width(38).Render(right.String())
+	fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top, leftCol, rightCol))
+	fmt.Println()
+}
+
+func (d *dashboard) renderShare(s analyze.ImpactShare) string {
+	barWidth := 10
+	filled := min(int(s.Percent*float64(barWidth)/100+0.5), barWidth)
+	bar := lipgloss.NewStyle().Foreground(successColor).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(neutralColor).Render(strings.Repeat("░", barWidth-filled))
+
+	return fmt.Sprintf(" %s %s %3.0f%%\n", fit(s.Name, 12), bar, s.Percent)
+}
+
 func (d *dashboard) renderHeatmap() {
 	title := lipgloss.NewStyle().Bold(true).Foreground(primaryColor).Render("ACTIVITY INTENSITY")
 	intensity := fmt.Sprintf("%s %s %s",
@@ -251,11 +324,7 @@ func (d *dashboard) renderUserList(title string, users []analyze.UserStat) strin
 		if i >= 5 {
 			break
 		}
-		login := u.Login
-		if len(login) > 15 {
-			login = login[:12] + "..."
-		}
-		sb.WriteString(fmt.Sprintf(" 👤 %-15s [%2d]\n", login, u.Count))
+		sb.WriteString(fmt.Sprintf(" 👤 %s [%2d]\n", fit(u.Login, 15), u.Count))
 	}
 	return sb.String()
 }
@@ -0,0 +1,30 @@
+package visualize
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"Short is padded", "api", "api       "},
+		{"Long ASCII is cut", "feat: add caching layer", "feat: a..."},
+		{"Wide runes count two cells", "認証フローを刷新", "認証フ... "},
+		{"Emoji is not split", "🚀🚀🚀🚀🚀🚀", "🚀🚀🚀... "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fit(tt.in, 10)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, utf8.ValidString(got))
+			assert.Equal(t, 10, ansi.StringWidth(got))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package visualize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jackchuka/gh-brag/internal/analyze"
+)
+
+type teamSummary struct {
+	name    string
+	metrics analyze.TeamMetrics
+}
+
+// NewTeamSummary creates a manager-facing team summary.
+// It deliberately shows no per-member scores or rankings.
+func NewTeamSummary(name string, metrics analyze.TeamMetrics) *teamSummary {
+	return &teamSummary{
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+func (t *teamSummary) Render() {
+	rollup := t.metrics.Rollup
+
+	heroStyle := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(primaryColor).
+		Padding(1, 2).
+		MarginBottom(1).
+		Width(80)
+
+	name := t.name
+	if name == "" {
+		name = "TEAM"
+	}
+	title := lipgloss.NewStyle().Bold(true).Foreground(primaryColor).Render("👥 " + strings.ToUpper(name) + " SUMMARY")
+	period := lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf("%s - %s",
+		rollup.PeriodStart.Format("Jan 2006"),
+		rollup.PeriodEnd.Format("Jan 2006")))
+
+	merged, issues, reviewed := 0, 0, 0
+	for _, r := range rollup.RepoStats.Summary {
+		merged += r.Merged
+		issues += r.Issues
+		reviewed += r.Reviewed
+	}
+	totals := fmt.Sprintf("%d members · %d merged PRs · %d issues opened · %d reviews across %d repositories",
+		len(t.metrics.Members), merged, issues, reviewed, len(rollup.RepoStats.Summary))
+	fmt.Println(heroStyle.Render(fmt.Sprintf("%s %s\n\n%s", title, period, totals)))
+
+	// Team-level focus
+	left := lipgloss.NewStyle().Width(40).Render(t.renderFocus(rollup))
+	right := lipgloss.NewStyle().Width(40).Render(t.renderRepos(rollup))
+	fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top, left, right))
+	fmt.Println()
+
+	// Members in alphabetical order, described by what they worked on
+	fmt.Println(lipgloss.NewStyle().Bold(true).Foreground(primaryColor).Render("WHAT EACH MEMBER FOCUSED ON"))
+	for _, m := range t.metrics.Members {
+		themes := topNames(m.Metrics.Theme, 3)
+		repos := make([]string, 0, 3)
+		for i, r := range m.Metrics.RepoStats.Summary {
+			if i >= 3 {
+				break
+			}
+			repos = append(repos, r.Name)
+		}
+		fmt.Printf(" 👤 %s\n", lipgloss.NewStyle().Bold(true).Render(m.Login))
+		fmt.Printf("    Themes: %s\n", joinOrDash(themes))
+		fmt.Printf("    Repos:  %s\n", joinOrDash(repos))
+	}
+	fmt.Println()
+}
+
+func (t *teamSummary) renderFocus(m analyze.Metrics) string {
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Foreground(primaryColor).Render("THEME MIX") + "\n\n")
+	for _, th := range m.Theme {
+		sb.WriteString(fmt.Sprintf(" %-14s %5.1f%%\n", th.Name, m.ContributionMix[th.Name]))
+	}
+	return sb.String()
+}
+
+func (t *teamSummary) renderRepos(m analyze.Metrics) string {
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Foreground(primaryColor).Render("WHERE THE TEAM WORKED") + "\n\n")
+	for i, r := range m.RepoStats.Summary {
+		if i >= 8 {
+			break
+		}
+		sb.WriteString(fmt.Sprintf(" %s\n", truncate(r.Name, 30)))
+	}
+	return sb.String()
+}
+
+func topNames(themes []analyze.Theme, n int) []string {
+	names := make([]string, 0, n)
+	for i, th := range themes {
+		if i >= n {
+			break
+		}
+		names = append(names, th.Name)
+	}
+	return names
+}
+
+func joinOrDash(s []string) string {
+	if len(s) == 0 {
+		return "-"
+	}
+	return strings.Join(s, ", ")
+}